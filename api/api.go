@@ -58,3 +58,18 @@ func (c *Client) FS() *FSClient {
 func (c *Client) Close() error {
 	return c.conn.Close()
 }
+
+// Music returns the music API client
+func (c *Client) Music() *MusicClient {
+	return &MusicClient{rpc.NewDRPCMusicClient(c.conn)}
+}
+
+// Jobs returns the jobs API client
+func (c *Client) Jobs() *JobsClient {
+	return &JobsClient{rpc.NewDRPCJobsClient(c.conn)}
+}
+
+// Navigation returns the navigation API client
+func (c *Client) Navigation() *NavigationClient {
+	return &NavigationClient{rpc.NewDRPCNavigationClient(c.conn)}
+}
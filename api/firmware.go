@@ -6,31 +6,172 @@ import (
 	"go.elara.ws/itd/internal/rpc"
 )
 
+// DFUStage is a stage of a firmware upgrade
+type DFUStage int32
+
+const (
+	// DFUStageTransfer reports the progress of transferring the firmware
+	DFUStageTransfer = DFUStage(rpc.DFUProgress_Transfer)
+	// DFUStageReboot means the firmware was transferred,
+	// and itd is waiting for the watch to reboot
+	DFUStageReboot = DFUStage(rpc.DFUProgress_Reboot)
+	// DFUStageDone means the watch reconnected after rebooting.
+	// Version contains the firmware version it booted.
+	DFUStageDone = DFUStage(rpc.DFUProgress_Done)
+)
+
 type DFUProgress struct {
+	// JobID is the ID of the daemon job doing the upgrade
+	JobID    uint64
+	Stage    DFUStage
 	Sent     int64
 	Received int64
 	Total    int64
-	Err      error
+	// PreviousVersion is the version before the upgrade, if it's known
+	PreviousVersion string
+	Version         string
+	Err             error
+}
+
+// UpgradeOptions contains options for [Client.FirmwareUpgradeWithOptions]
+type UpgradeOptions struct {
+	// Detach keeps the upgrade running if ctx is cancelled or the client
+	// disconnects. It can be reattached to using [JobsClient.Watch].
+	Detach bool
 }
 
 func (c *Client) FirmwareUpgrade(ctx context.Context, upgType UpgradeType, files ...string) (chan DFUProgress, error) {
+	return c.FirmwareUpgradeWithOptions(ctx, upgType, UpgradeOptions{}, files...)
+}
+
+// FirmwareUpgradeWithOptions is like [Client.FirmwareUpgrade], but it accepts options
+func (c *Client) FirmwareUpgradeWithOptions(ctx context.Context, upgType UpgradeType, opts UpgradeOptions, files ...string) (chan DFUProgress, error) {
 	progressCh := make(chan DFUProgress, 5)
 	fc, err := c.client.FirmwareUpgrade(ctx, &rpc.FirmwareUpgradeRequest{
-		Type:  rpc.FirmwareUpgradeRequest_Type(upgType),
-		Files: files,
+		Type:   rpc.FirmwareUpgradeRequest_Type(upgType),
+		Files:  files,
+		Detach: opts.Detach,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	go fsRecvToChannel[rpc.DFUProgress](fc, progressCh, func(evt *rpc.DFUProgress, err error) DFUProgress {
-		return DFUProgress{
-			Sent:     evt.Sent,
-			Received: evt.Recieved,
-			Total:    evt.Total,
-			Err:      err,
-		}
+	go fsRecvToChannel[rpc.DFUProgress](fc, progressCh, convertDFUProgress)
+
+	return progressCh, nil
+}
+
+func convertDFUProgress(evt *rpc.DFUProgress, err error) DFUProgress {
+	return DFUProgress{
+		JobID:           evt.JobId,
+		Stage:           DFUStage(evt.Stage),
+		Sent:            evt.Sent,
+		Received:        evt.Recieved,
+		Total:           evt.Total,
+		PreviousVersion: evt.PreviousVersion,
+		Version:         evt.Version,
+		Err:             err,
+	}
+}
+
+// UpdateStage is a stage of a firmware update
+type UpdateStage int32
+
+const (
+	// UpdateStageCheck reports the current and target versions
+	UpdateStageCheck = UpdateStage(rpc.FirmwareUpdateProgress_Check)
+	// UpdateStageUpToDate means the watch is already up to date
+	UpdateStageUpToDate = UpdateStage(rpc.FirmwareUpdateProgress_UpToDate)
+	// UpdateStageDownload reports the progress of downloading the release's files
+	UpdateStageDownload = UpdateStage(rpc.FirmwareUpdateProgress_Download)
+	// UpdateStageResources reports the progress of loading the release's
+	// resources, which happens after the watch reboots into the new firmware
+	UpdateStageResources = UpdateStage(rpc.FirmwareUpdateProgress_Resources)
+	// UpdateStageFirmware reports the progress of flashing the firmware
+	UpdateStageFirmware = UpdateStage(rpc.FirmwareUpdateProgress_Firmware)
+	// UpdateStageReboot means the firmware was flashed,
+	// and itd is waiting for the watch to reboot
+	UpdateStageReboot = UpdateStage(rpc.FirmwareUpdateProgress_Reboot)
+	// UpdateStageDone means the watch booted the new firmware
+	// and its resources were loaded.
+	// CurrentVersion contains the version it's running.
+	UpdateStageDone = UpdateStage(rpc.FirmwareUpdateProgress_Done)
+)
+
+// UpdateOptions contains options for [Client.FirmwareUpdate]
+type UpdateOptions struct {
+	// Catalogue overrides the release catalogue set in itd's config.
+	// It can be a URL or a local directory.
+	Catalogue string
+	// Version is the version to update to. If it's empty,
+	// the latest release in the catalogue is used.
+	Version string
+	// Force updates even if the watch is already running
+	// the same or a newer version
+	Force bool
+	// SkipResources skips loading the release's resources
+	SkipResources bool
+	// Check only reports the current and target versions
+	Check bool
+	// Detach keeps the update running if ctx is cancelled or the client
+	// disconnects. It can be reattached to using [JobsClient.Watch].
+	Detach bool
+}
+
+type UpdateProgress struct {
+	// JobID is the ID of the daemon job doing the update
+	JobID          uint64
+	Stage          UpdateStage
+	CurrentVersion string
+	TargetVersion  string
+	Name           string
+	Sent           int64
+	Total          int64
+	Err            error
+}
+
+// FirmwareUpdate updates the watch to a release from the release catalogue
+func (c *Client) FirmwareUpdate(ctx context.Context, opts UpdateOptions) (chan UpdateProgress, error) {
+	progressCh := make(chan UpdateProgress, 5)
+	fc, err := c.client.FirmwareUpdate(ctx, &rpc.FirmwareUpdateRequest{
+		Catalogue:     opts.Catalogue,
+		Version:       opts.Version,
+		Force:         opts.Force,
+		SkipResources: opts.SkipResources,
+		Check:         opts.Check,
+		Detach:        opts.Detach,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	go fsRecvToChannel[rpc.FirmwareUpdateProgress](fc, progressCh, convertUpdateProgress)
 
 	return progressCh, nil
 }
+
+func convertUpdateProgress(evt *rpc.FirmwareUpdateProgress, err error) UpdateProgress {
+	return UpdateProgress{
+		JobID:          evt.JobId,
+		Stage:          UpdateStage(evt.Stage),
+		CurrentVersion: evt.CurrentVersion,
+		TargetVersion:  evt.TargetVersion,
+		Name:           evt.Name,
+		Sent:           evt.Sent,
+		Total:          evt.Total,
+		Err:            err,
+	}
+}
+
+// FirmwareValidateReminder sends a notification to the watch reminding the
+// user to validate the firmware it's running, and returns its version.
+// InfiniTime only allows validating firmware from its settings app. It
+// fails if the watch reverted to the firmware that was running before
+// the last upgrade.
+func (c *Client) FirmwareValidateReminder(ctx context.Context) (string, error) {
+	res, err := c.client.FirmwareValidateReminder(ctx, &rpc.Empty{})
+	if err != nil {
+		return "", err
+	}
+	return res.Version, nil
+}
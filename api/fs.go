@@ -60,42 +60,57 @@ func convertEntries(e []*rpc.FileInfo) []FileInfo {
 	return out
 }
 
+// TransferOptions contains options for [FSClient.UploadWithOptions]
+// and [FSClient.DownloadWithOptions]
+type TransferOptions struct {
+	// Detach keeps the transfer running if ctx is cancelled or the client
+	// disconnects. It can be reattached to using [JobsClient.Watch].
+	Detach bool
+}
+
 func (c *FSClient) Upload(ctx context.Context, dst, src string) (chan FSTransferProgress, error) {
+	return c.UploadWithOptions(ctx, dst, src, TransferOptions{})
+}
+
+// UploadWithOptions is like [FSClient.Upload], but it accepts options
+func (c *FSClient) UploadWithOptions(ctx context.Context, dst, src string, opts TransferOptions) (chan FSTransferProgress, error) {
 	progressCh := make(chan FSTransferProgress, 5)
-	tc, err := c.client.Upload(ctx, &rpc.TransferRequest{Source: src, Destination: dst})
+	tc, err := c.client.Upload(ctx, &rpc.TransferRequest{Source: src, Destination: dst, Detach: opts.Detach})
 	if err != nil {
 		return nil, err
 	}
 
-	go fsRecvToChannel[rpc.TransferProgress](tc, progressCh, func(evt *rpc.TransferProgress, err error) FSTransferProgress {
-		return FSTransferProgress{
-			Sent:  evt.Sent,
-			Total: evt.Total,
-			Err:   err,
-		}
-	})
+	go fsRecvToChannel[rpc.TransferProgress](tc, progressCh, convertTransferProgress)
 
 	return progressCh, nil
 }
 
 func (c *FSClient) Download(ctx context.Context, dst, src string) (chan FSTransferProgress, error) {
+	return c.DownloadWithOptions(ctx, dst, src, TransferOptions{})
+}
+
+// DownloadWithOptions is like [FSClient.Download], but it accepts options
+func (c *FSClient) DownloadWithOptions(ctx context.Context, dst, src string, opts TransferOptions) (chan FSTransferProgress, error) {
 	progressCh := make(chan FSTransferProgress, 5)
-	tc, err := c.client.Download(ctx, &rpc.TransferRequest{Source: src, Destination: dst})
+	tc, err := c.client.Download(ctx, &rpc.TransferRequest{Source: src, Destination: dst, Detach: opts.Detach})
 	if err != nil {
 		return nil, err
 	}
 
-	go fsRecvToChannel[rpc.TransferProgress](tc, progressCh, func(evt *rpc.TransferProgress, err error) FSTransferProgress {
-		return FSTransferProgress{
-			Sent:  evt.Sent,
-			Total: evt.Total,
-			Err:   err,
-		}
-	})
+	go fsRecvToChannel[rpc.TransferProgress](tc, progressCh, convertTransferProgress)
 
 	return progressCh, nil
 }
 
+func convertTransferProgress(evt *rpc.TransferProgress, err error) FSTransferProgress {
+	return FSTransferProgress{
+		JobID: evt.JobId,
+		Sent:  evt.Sent,
+		Total: evt.Total,
+		Err:   err,
+	}
+}
+
 // fsRecvToChannel converts a DRPC stream client to a Go channel, using cf to convert
 // RPC generated types to API response types.
 func fsRecvToChannel[R any, A any](s StreamClient[R], ch chan<- A, cf func(evt *R, err error) A) {
@@ -2,7 +2,9 @@ package api
 
 import (
 	"context"
+	"time"
 
+	"go.elara.ws/itd/infinitime"
 	"go.elara.ws/itd/internal/rpc"
 )
 
@@ -39,3 +41,50 @@ func (c *Client) Address(ctx context.Context) (out string, err error) {
 	res, err := c.client.Address(ctx, &rpc.Empty{})
 	return res.Value, err
 }
+
+type Capabilities infinitime.Capabilities
+
+// Capabilities returns the optional features supported by the watch
+func (c *Client) Capabilities(ctx context.Context) (Capabilities, error) {
+	res, err := c.client.Capabilities(ctx, &rpc.Empty{})
+	if err != nil {
+		return Capabilities{}, err
+	}
+
+	return Capabilities{
+		Notifications: res.Notifications,
+		CallControl:   res.CallControl,
+		Time:          res.Time,
+		LocalTime:     res.LocalTime,
+		Battery:       res.Battery,
+		HeartRate:     res.HeartRate,
+		StepCount:     res.StepCount,
+		Motion:        res.Motion,
+		Music:         res.Music,
+		Navigation:    res.Navigation,
+		Weather:       res.Weather,
+		FS:            res.Fs,
+		DFU:           res.Dfu,
+	}, nil
+}
+
+// WatchTime is the time on the watch, and how far it's
+// ahead of the host's time. A negative drift means the
+// watch is behind.
+type WatchTime struct {
+	Time  time.Time
+	Drift time.Duration
+}
+
+// GetTime reads the current time from the watch
+func (c *Client) GetTime(ctx context.Context) (WatchTime, error) {
+	res, err := c.client.GetTime(ctx, &rpc.Empty{})
+	if err != nil {
+		return WatchTime{}, err
+	}
+
+	return WatchTime{
+		Time:  time.Unix(0, res.UnixNano),
+		Drift: time.Duration(res.DriftNano),
+	}, nil
+}
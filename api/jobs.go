@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.elara.ws/itd/internal/rpc"
+)
+
+// JobState is the state of a daemon job
+type JobState int32
+
+const (
+	JobRunning   = JobState(rpc.JobInfo_Running)
+	JobDone      = JobState(rpc.JobInfo_Done)
+	JobFailed    = JobState(rpc.JobInfo_Failed)
+	JobCancelled = JobState(rpc.JobInfo_Cancelled)
+)
+
+func (s JobState) String() string {
+	return rpc.JobInfo_State(s).String()
+}
+
+// JobInfo describes a long operation running in the daemon,
+// such as a file transfer or a firmware upgrade
+type JobInfo struct {
+	ID uint64
+	// Kind is the kind of operation, such as "upload" or "firmwareUpgrade"
+	Kind        string
+	Description string
+	Started     time.Time
+	State       JobState
+	// Error is the error the job failed with, if any
+	Error string
+}
+
+// JobEvent is an event sent while watching a job. Only the progress
+// field matching the kind of job is set. The last event sent before
+// the channel is closed contains only the final state of the job.
+type JobEvent struct {
+	Info      JobInfo
+	DFU       *DFUProgress
+	Update    *UpdateProgress
+	Transfer  *FSTransferProgress
+	Resources *ResourceLoadProgress
+	Err       error
+}
+
+type JobsClient struct {
+	client rpc.DRPCJobsClient
+}
+
+// List returns all the running jobs, and the ones that finished recently
+func (c *JobsClient) List(ctx context.Context) ([]JobInfo, error) {
+	res, err := c.client.ListJobs(ctx, &rpc.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]JobInfo, len(res.Jobs))
+	for i, info := range res.Jobs {
+		out[i] = convertJobInfo(info)
+	}
+	return out, nil
+}
+
+// Cancel cancels the job with the given ID
+func (c *JobsClient) Cancel(ctx context.Context, id uint64) error {
+	_, err := c.client.CancelJob(ctx, &rpc.JobRequest{Id: id})
+	return err
+}
+
+// Watch reattaches to the job with the given ID, and returns a channel that
+// receives its progress, starting with the latest event. Cancelling ctx stops
+// watching the job, but doesn't cancel it.
+func (c *JobsClient) Watch(ctx context.Context, id uint64) (<-chan JobEvent, error) {
+	progressCh := make(chan JobEvent, 5)
+	wc, err := c.client.WatchJob(ctx, &rpc.JobRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	go fsRecvToChannel[rpc.JobEvent](wc, progressCh, convertJobEvent)
+
+	return progressCh, nil
+}
+
+func convertJobEvent(evt *rpc.JobEvent, err error) JobEvent {
+	out := JobEvent{Info: convertJobInfo(evt.Info), Err: err}
+
+	switch p := evt.Progress.(type) {
+	case *rpc.JobEvent_Dfu:
+		dfu := convertDFUProgress(p.Dfu, nil)
+		out.DFU = &dfu
+	case *rpc.JobEvent_FirmwareUpdate:
+		upd := convertUpdateProgress(p.FirmwareUpdate, nil)
+		out.Update = &upd
+	case *rpc.JobEvent_Transfer:
+		tp := convertTransferProgress(p.Transfer, nil)
+		out.Transfer = &tp
+	case *rpc.JobEvent_Resources:
+		rp := convertResourceLoadProgress(p.Resources, nil)
+		out.Resources = &rp
+	}
+
+	if out.Err == nil && out.Info.State == JobFailed {
+		out.Err = errors.New(out.Info.Error)
+	}
+
+	return out
+}
+
+func convertJobInfo(info *rpc.JobInfo) JobInfo {
+	if info == nil {
+		return JobInfo{}
+	}
+
+	return JobInfo{
+		ID:          info.Id,
+		Kind:        info.Kind,
+		Description: info.Description,
+		Started:     time.Unix(0, info.StartedUnixNano),
+		State:       JobState(info.State),
+		Error:       info.Error,
+	}
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"go.elara.ws/itd/infinitime"
+	"go.elara.ws/itd/internal/rpc"
+)
+
+type MusicEvent infinitime.MusicEvent
+
+const (
+	MusicEventOpen    = MusicEvent(infinitime.MusicEventOpen)
+	MusicEventPlay    = MusicEvent(infinitime.MusicEventPlay)
+	MusicEventPause   = MusicEvent(infinitime.MusicEventPause)
+	MusicEventNext    = MusicEvent(infinitime.MusicEventNext)
+	MusicEventPrev    = MusicEvent(infinitime.MusicEventPrev)
+	MusicEventVolUp   = MusicEvent(infinitime.MusicEventVolUp)
+	MusicEventVolDown = MusicEvent(infinitime.MusicEventVolDown)
+)
+
+// String returns the name of the music event
+func (me MusicEvent) String() string {
+	switch me {
+	case MusicEventOpen:
+		return "open"
+	case MusicEventPlay:
+		return "play"
+	case MusicEventPause:
+		return "pause"
+	case MusicEventNext:
+		return "next"
+	case MusicEventPrev:
+		return "prev"
+	case MusicEventVolUp:
+		return "volup"
+	case MusicEventVolDown:
+		return "voldown"
+	default:
+		return "unknown"
+	}
+}
+
+// MusicMetadata represents the metadata of the
+// track shown by the watch's music app
+type MusicMetadata struct {
+	Artist string
+	Track  string
+	Album  string
+	Length time.Duration
+}
+
+// MusicStatus represents the playback status
+// shown by the watch's music app
+type MusicStatus struct {
+	Playing  bool
+	Position time.Duration
+	// Rate is the playback speed. If it's zero, a normal speed of 1 is used.
+	Rate    float64
+	Shuffle bool
+	Repeat  bool
+}
+
+// MusicMetadataUpdate changes part of the metadata shown
+// by the watch's music app. Nil fields are left unchanged.
+type MusicMetadataUpdate struct {
+	Artist *string
+	Track  *string
+	Album  *string
+	Length *time.Duration
+}
+
+// MusicStatusUpdate changes part of the playback status shown
+// by the watch's music app. Nil fields are left unchanged.
+type MusicStatusUpdate struct {
+	Playing  *bool
+	Position *time.Duration
+	// Rate is the playback speed. If it's zero, a normal speed of 1 is used.
+	Rate    *float64
+	Shuffle *bool
+	Repeat  *bool
+}
+
+// MusicState represents the full state of the watch's music app
+type MusicState struct {
+	Metadata MusicMetadata
+	Status   MusicStatus
+}
+
+type MusicClient struct {
+	client rpc.DRPCMusicClient
+}
+
+// SetMetadata sets all of the metadata shown by the watch's music app
+func (c *MusicClient) SetMetadata(ctx context.Context, md MusicMetadata) error {
+	return c.UpdateMetadata(ctx, MusicMetadataUpdate{
+		Artist: &md.Artist,
+		Track:  &md.Track,
+		Album:  &md.Album,
+		Length: &md.Length,
+	})
+}
+
+// UpdateMetadata sets the non-nil fields of the metadata
+// shown by the watch's music app
+func (c *MusicClient) UpdateMetadata(ctx context.Context, md MusicMetadataUpdate) error {
+	_, err := c.client.SetMetadata(ctx, &rpc.MusicMetadata{
+		Artist:     md.Artist,
+		Track:      md.Track,
+		Album:      md.Album,
+		LengthNano: durationNano(md.Length),
+	})
+	return err
+}
+
+// SetStatus sets all of the playback status shown by the watch's music app
+func (c *MusicClient) SetStatus(ctx context.Context, st MusicStatus) error {
+	return c.UpdateStatus(ctx, MusicStatusUpdate{
+		Playing:  &st.Playing,
+		Position: &st.Position,
+		Rate:     &st.Rate,
+		Shuffle:  &st.Shuffle,
+		Repeat:   &st.Repeat,
+	})
+}
+
+// UpdateStatus sets the non-nil fields of the playback
+// status shown by the watch's music app
+func (c *MusicClient) UpdateStatus(ctx context.Context, st MusicStatusUpdate) error {
+	_, err := c.client.SetStatus(ctx, &rpc.MusicStatus{
+		Playing:      st.Playing,
+		PositionNano: durationNano(st.Position),
+		Rate:         st.Rate,
+		Shuffle:      st.Shuffle,
+		Repeat:       st.Repeat,
+	})
+	return err
+}
+
+func durationNano(d *time.Duration) *int64 {
+	if d == nil {
+		return nil
+	}
+	nano := int64(*d)
+	return &nano
+}
+
+// GetState returns the music state that itd last sent to the watch
+func (c *MusicClient) GetState(ctx context.Context) (MusicState, error) {
+	res, err := c.client.GetState(ctx, &rpc.Empty{})
+	if err != nil {
+		return MusicState{}, err
+	}
+
+	md, st := res.GetMetadata(), res.GetStatus()
+	return MusicState{
+		Metadata: MusicMetadata{
+			Artist: md.GetArtist(),
+			Track:  md.GetTrack(),
+			Album:  md.GetAlbum(),
+			Length: time.Duration(md.GetLengthNano()),
+		},
+		Status: MusicStatus{
+			Playing:  st.GetPlaying(),
+			Position: time.Duration(st.GetPositionNano()),
+			Rate:     st.GetRate(),
+			Shuffle:  st.GetShuffle(),
+			Repeat:   st.GetRepeat(),
+		},
+	}, nil
+}
+
+// WatchMusicEvents returns a channel that receives the
+// events sent by the watch's music app, such as button presses.
+func (c *MusicClient) WatchMusicEvents(ctx context.Context) (<-chan MusicEvent, error) {
+	outCh := make(chan MusicEvent, 2)
+	wc, err := c.client.WatchMusicEvents(ctx, &rpc.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer close(outCh)
+
+		var err error
+		var evt *rpc.MusicEvent
+
+		for {
+			select {
+			case <-ctx.Done():
+				wc.Close()
+				return
+			default:
+				evt, err = wc.Recv()
+				if err != nil {
+					return
+				}
+			}
+
+			outCh <- MusicEvent(evt.Event)
+		}
+	}()
+
+	return outCh, nil
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+
+	"go.elara.ws/itd/infinitime"
+	"go.elara.ws/itd/internal/rpc"
+)
+
+type NavFlag infinitime.NavFlag
+
+// NavState represents the full state of the watch's navigation app
+type NavState struct {
+	Flag      NavFlag
+	Narrative string
+	ManDist   string
+	Progress  uint8
+}
+
+type NavigationClient struct {
+	client rpc.DRPCNavigationClient
+}
+
+// SetNav sets every field of the watch's navigation app at once
+func (c *NavigationClient) SetNav(ctx context.Context, state NavState) error {
+	_, err := c.client.SetNav(ctx, &rpc.NavigationState{
+		Flag:      string(state.Flag),
+		Narrative: state.Narrative,
+		ManDist:   state.ManDist,
+		Progress:  uint32(state.Progress),
+	})
+	return err
+}
+
+// ClearNav clears the watch's navigation app
+func (c *NavigationClient) ClearNav(ctx context.Context) error {
+	_, err := c.client.ClearNav(ctx, &rpc.Empty{})
+	return err
+}
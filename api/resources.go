@@ -12,9 +12,23 @@ type ResourceOperation infinitime.ResourceOperation
 const (
 	ResourceRemove = infinitime.ResourceRemove
 	ResourceUpload = infinitime.ResourceUpload
+	ResourceSkip   = infinitime.ResourceSkip
 )
 
+// ResourceLoadOptions contains options for [FSClient.LoadResourcesWithOptions]
+type ResourceLoadOptions struct {
+	// DryRun reports the operations that would be done, without doing them
+	DryRun bool
+	// Force uploads every resource, even the ones that are already on the watch
+	Force bool
+	// Detach keeps the resources loading if ctx is cancelled or the client
+	// disconnects. It can be reattached to using [JobsClient.Watch].
+	Detach bool
+}
+
 type ResourceLoadProgress struct {
+	// JobID is the ID of the daemon job loading the resources
+	JobID     uint64
 	Operation ResourceOperation
 	Name      string
 	Total     int64
@@ -23,28 +37,42 @@ type ResourceLoadProgress struct {
 }
 
 // LoadResources loads resources onto the watch from the given
-// file path to the resources zip
+// file path to the resources zip. Resources that are already
+// on the watch are skipped.
 func (c *FSClient) LoadResources(ctx context.Context, path string) (<-chan ResourceLoadProgress, error) {
+	return c.LoadResourcesWithOptions(ctx, path, ResourceLoadOptions{})
+}
+
+// LoadResourcesWithOptions is like [FSClient.LoadResources], but it accepts options
+func (c *FSClient) LoadResourcesWithOptions(ctx context.Context, path string, opts ResourceLoadOptions) (<-chan ResourceLoadProgress, error) {
 	progCh := make(chan ResourceLoadProgress, 2)
 
-	rc, err := c.client.LoadResources(ctx, &rpc.PathRequest{Path: path})
+	rc, err := c.client.LoadResources(ctx, &rpc.LoadResourcesRequest{
+		Path:   path,
+		DryRun: opts.DryRun,
+		Force:  opts.Force,
+		Detach: opts.Detach,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	go fsRecvToChannel[rpc.ResourceLoadProgress](rc, progCh, func(evt *rpc.ResourceLoadProgress, err error) ResourceLoadProgress {
-		return ResourceLoadProgress{
-			Operation: ResourceOperation(evt.Operation),
-			Name:      evt.Name,
-			Sent:      evt.Sent,
-			Total:     evt.Total,
-			Err:       err,
-		}
-	})
+	go fsRecvToChannel[rpc.ResourceLoadProgress](rc, progCh, convertResourceLoadProgress)
 
 	return progCh, nil
 }
 
+func convertResourceLoadProgress(evt *rpc.ResourceLoadProgress, err error) ResourceLoadProgress {
+	return ResourceLoadProgress{
+		JobID:     evt.JobId,
+		Operation: ResourceOperation(evt.Operation),
+		Name:      evt.Name,
+		Sent:      evt.Sent,
+		Total:     evt.Total,
+		Err:       err,
+	}
+}
+
 type StreamClient[T any] interface {
 	Recv() (*T, error)
 	Context() context.Context
@@ -28,6 +28,8 @@ type NotifyData struct {
 }
 
 type FSTransferProgress struct {
+	// JobID is the ID of the daemon job doing the transfer
+	JobID uint64
 	Total uint32
 	Sent  uint32
 	Err   error
@@ -3,12 +3,17 @@ package main
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/godbus/dbus/v5"
 	"go.elara.ws/itd/infinitime"
 	"go.elara.ws/itd/internal/utils"
 )
 
+// callRingTimeout is the longest time to wait for the
+// user to answer or decline a call on the watch
+const callRingTimeout = 2 * time.Minute
+
 func initCallNotifs(ctx context.Context, wg WaitGroup, dev *infinitime.Device) error {
 	// Connect to system bus. This connection is for method calls.
 	conn, err := utils.NewSystemBusConn(ctx)
@@ -80,8 +85,25 @@ func initCallNotifs(ctx context.Context, wg WaitGroup, dev *infinitime.Device) e
 					continue
 				}
 
-				// Send call notification to InfiniTime
-				err = dev.NotifyCall(phoneNum, func(cs infinitime.CallStatus) {
+				// Send call notification to InfiniTime. The call may ring for
+				// longer than the default timeout, so wait for the user to answer
+				// or decline it for as long as a call can reasonably ring.
+				// The call is over by the time a paused scheduler resumes, so
+				// the notification is dropped rather than deferred. Only sending
+				// the call goes through the scheduler, since waiting for the user
+				// doesn't communicate with the watch.
+				callCtx, cancel := context.WithTimeout(ctx, callRingTimeout)
+				var call *infinitime.CallNotification
+				err = sched.run(priorityLow, "call", func() (err error) {
+					call, err = dev.SendCallContext(callCtx, phoneNum)
+					return err
+				})
+				if err != nil {
+					cancel()
+					continue
+				}
+
+				err = call.Wait(func(cs infinitime.CallStatus) {
 					switch cs {
 					case infinitime.CallStatusAccepted:
 						// Attempt to accept call
@@ -100,6 +122,7 @@ func initCallNotifs(ctx context.Context, wg WaitGroup, dev *infinitime.Device) e
 						log.Warn("Muting calls is not implemented")
 					}
 				})
+				cancel()
 				if err != nil {
 					continue
 				}
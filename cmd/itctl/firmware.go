@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/cheggaaa/pb/v3"
@@ -18,7 +19,7 @@ func fwUpgrade(c *cli.Context) error {
 			return err
 		}
 
-		err = resLoad(c.Context, []string{absRes})
+		err = resLoad(c.Context, []string{absRes}, api.ResourceLoadOptions{})
 		if err != nil {
 			log.Error("Resource loading has returned an error. This can happen if your current version of InfiniTime doesn't support BLE FS. Try updating without resource loading, and then load them after using the `itctl res load` command.")
 			return err
@@ -42,36 +43,158 @@ func fwUpgrade(c *cli.Context) error {
 		return cli.Exit("Upgrade command requires either archive or init packet and firmware.", 1)
 	}
 
-	progress, err := client.FirmwareUpgrade(c.Context, upgType, abs(files)...)
+	progress, err := client.FirmwareUpgradeWithOptions(c.Context, upgType, api.UpgradeOptions{
+		Detach: c.Bool("detach"),
+	}, abs(files)...)
 	if err != nil {
 		return err
 	}
 
+	if c.Bool("detach") {
+		return detachJob(progress, func(evt api.DFUProgress) (uint64, error) {
+			return evt.JobID, evt.Err
+		})
+	}
+
 	// Create progress bar template
 	barTmpl := `{{counters . }} B {{bar . "|" "-" (cycle .) " " "|"}} {{percent . }} {{rtime . "%s"}}`
 	// Start full bar at 0 total
 	bar := pb.ProgressBarTemplate(barTmpl).Start(0)
-	// Create new scanner of connection
 	for event := range progress {
 		if event.Err != nil {
+			bar.Finish()
+			return event.Err
+		}
+
+		switch event.Stage {
+		case api.DFUStageReboot:
+			bar.Finish()
+			fmt.Printf("Transferred %d B in %s.\n", bar.Total(), time.Since(start))
+			fmt.Println("Waiting for InfiniTime to reboot...")
+		case api.DFUStageDone:
+			fmt.Printf("InfiniTime rebooted into %s.\n", event.Version)
+			if event.Version == event.PreviousVersion {
+				fmt.Println("That's the version it was running before. Unless the same version was flashed, the new firmware failed to boot and MCUBoot reverted it.")
+			} else {
+				fmt.Println("Remember to validate the new firmware in Settings > Firmware on the watch, or MCUBoot will revert it after the next reboot.")
+			}
+		default:
+			// Set total bytes in progress bar
+			bar.SetTotal(event.Total)
+			// Set amount of bytes received in progress bar
+			bar.SetCurrent(int64(event.Received))
+		}
+	}
+
+	return nil
+}
+
+func fwUpdate(c *cli.Context) error {
+	version := c.String("version")
+	if !c.Bool("latest") && version == "" {
+		return cli.Exit("Update command requires either --latest or --version.", 1)
+	}
+
+	// itd runs in a different directory, so local catalogues need an absolute path
+	catalogue := c.String("catalogue")
+	if catalogue != "" && !strings.Contains(catalogue, "://") {
+		absCatalogue, err := filepath.Abs(catalogue)
+		if err != nil {
+			return err
+		}
+		catalogue = absCatalogue
+	}
+
+	start := time.Now()
+
+	progress, err := client.FirmwareUpdate(c.Context, api.UpdateOptions{
+		Catalogue:     catalogue,
+		Version:       version,
+		Force:         c.Bool("force"),
+		SkipResources: c.Bool("no-resources"),
+		Check:         c.Bool("check"),
+		Detach:        c.Bool("detach"),
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("detach") {
+		return detachJob(progress, func(evt api.UpdateProgress) (uint64, error) {
+			return evt.JobID, evt.Err
+		})
+	}
+
+	barTmpl := `{{string . "stage"}} {{string . "filename"}} {{counters . }} B {{bar . "|" "-" (cycle .) " " "|"}} {{percent . }} {{rtime . "%s"}}`
+	var (
+		bar   *pb.ProgressBar
+		stage api.UpdateStage
+	)
+
+	for event := range progress {
+		if event.Err != nil {
+			if bar != nil {
+				bar.Finish()
+			}
 			return event.Err
 		}
 
-		// Set total bytes in progress bar
-		bar.SetTotal(event.Total)
-		// Set amount of bytes received in progress bar
-		bar.SetCurrent(int64(event.Received))
-		// If transfer finished, break
-		if int64(event.Sent) == event.Total {
-			break
+		switch event.Stage {
+		case api.UpdateStageCheck:
+			current := event.CurrentVersion
+			if current == "" {
+				current = "unknown"
+			}
+			fmt.Printf("Current version: %s, available version: %s\n", current, event.TargetVersion)
+		case api.UpdateStageUpToDate:
+			fmt.Println("InfiniTime is already up to date.")
+		case api.UpdateStageReboot:
+			if bar != nil {
+				bar.Finish()
+				bar = nil
+			}
+			fmt.Println("Waiting for InfiniTime to reboot...")
+		case api.UpdateStageDone:
+			if bar != nil {
+				bar.Finish()
+				bar = nil
+			}
+			fmt.Printf("Updated to %s in %s.\n", event.CurrentVersion, time.Since(start))
+			fmt.Println("Remember to validate the new firmware in Settings > Firmware on the watch, or MCUBoot will revert it after the next reboot.")
+		default:
+			// Start a new bar for each stage, so that the
+			// previous stage's progress stays visible
+			if bar == nil || event.Stage != stage {
+				if bar != nil {
+					bar.Finish()
+				}
+				bar = pb.ProgressBarTemplate(barTmpl).Start(0)
+				stage = event.Stage
+				bar.Set("stage", updateStageNames[stage])
+			}
+
+			bar.Set("filename", event.Name)
+			bar.SetTotal(event.Total)
+			bar.SetCurrent(event.Sent)
 		}
 	}
-	// Finish progress bar
-	bar.Finish()
 
-	fmt.Printf("Transferred %d B in %s.\n", bar.Total(), time.Since(start))
-	fmt.Println("Remember to validate the new firmware in the InfiniTime settings.")
+	return nil
+}
+
+var updateStageNames = map[api.UpdateStage]string{
+	api.UpdateStageDownload:  "Downloading",
+	api.UpdateStageResources: "Loading resources",
+	api.UpdateStageFirmware:  "Flashing",
+}
+
+func fwValidateReminder(c *cli.Context) error {
+	version, err := client.FirmwareValidateReminder(c.Context)
+	if err != nil {
+		return err
+	}
 
+	fmt.Printf("Validate InfiniTime %s in Settings > Firmware on the watch to keep it after the next reboot.\n", version)
 	return nil
 }
 
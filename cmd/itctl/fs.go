@@ -8,6 +8,7 @@ import (
 
 	"github.com/cheggaaa/pb/v3"
 	"github.com/urfave/cli/v2"
+	"go.elara.ws/itd/api"
 )
 
 func fsList(c *cli.Context) error {
@@ -151,17 +152,31 @@ func fsWrite(c *cli.Context) error {
 		}
 	}
 
+	// The temporary file for stdin is removed when itctl exits,
+	// so the upload can't keep running after that
+	if c.Args().Get(0) == "-" && c.Bool("detach") {
+		return cli.Exit("Writing from stdin can't be detached.", 1)
+	}
+
 	if c.Args().Get(0) == "-" {
 		io.Copy(tmpFile, os.Stdin)
 		defer tmpFile.Close()
 		defer os.Remove(path)
 	}
 
-	progress, err := client.FS().Upload(c.Context, c.Args().Get(1), path)
+	progress, err := client.FS().UploadWithOptions(c.Context, c.Args().Get(1), path, api.TransferOptions{
+		Detach: c.Bool("detach"),
+	})
 	if err != nil {
 		return err
 	}
 
+	if c.Bool("detach") {
+		return detachJob(progress, func(evt api.FSTransferProgress) (uint64, error) {
+			return evt.JobID, evt.Err
+		})
+	}
+
 	// Create progress bar template
 	barTmpl := `{{counters . }} B {{bar . "|" "-" (cycle .) " " "|"}} {{percent . }} {{rtime . "%s"}}`
 	// Start full bar at 0 total
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/urfave/cli/v2"
 )
@@ -69,3 +70,68 @@ func getSteps(c *cli.Context) error {
 	fmt.Printf("%d Steps\n", stepCount)
 	return nil
 }
+
+func getCapabilities(c *cli.Context) error {
+	caps, err := client.Capabilities(c.Context)
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("json") {
+		return json.NewEncoder(os.Stdout).Encode(caps)
+	}
+
+	features := []struct {
+		name, shellName string
+		supported       bool
+	}{
+		{"Notifications", "NOTIFICATIONS", caps.Notifications},
+		{"Call control", "CALL_CONTROL", caps.CallControl},
+		{"Time", "TIME", caps.Time},
+		{"Local time", "LOCAL_TIME", caps.LocalTime},
+		{"Battery", "BATTERY", caps.Battery},
+		{"Heart rate", "HEART_RATE", caps.HeartRate},
+		{"Step count", "STEP_COUNT", caps.StepCount},
+		{"Motion", "MOTION", caps.Motion},
+		{"Music", "MUSIC", caps.Music},
+		{"Navigation", "NAVIGATION", caps.Navigation},
+		{"Weather", "WEATHER", caps.Weather},
+		{"Filesystem", "FS", caps.FS},
+		{"Firmware upgrade", "DFU", caps.DFU},
+	}
+
+	for _, f := range features {
+		if c.Bool("shell") {
+			fmt.Printf("%s=%t\n", f.shellName, f.supported)
+		} else if f.supported {
+			fmt.Printf("%s: yes\n", f.name)
+		} else {
+			fmt.Printf("%s: no\n", f.name)
+		}
+	}
+	return nil
+}
+
+func getTime(c *cli.Context) error {
+	wt, err := client.GetTime(c.Context)
+	if err != nil {
+		return err
+	}
+
+	if !c.Bool("drift") {
+		fmt.Println(wt.Time.Format(time.DateTime))
+		return nil
+	}
+
+	// Drift is only accurate to about a second, so don't show more precision than that
+	drift := wt.Drift.Round(100 * time.Millisecond)
+	switch {
+	case drift > 0:
+		fmt.Printf("+%s (watch is ahead)\n", drift)
+	case drift < 0:
+		fmt.Printf("%s (watch is behind)\n", drift)
+	default:
+		fmt.Println("0s")
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cheggaaa/pb/v3"
+	"github.com/urfave/cli/v2"
+	"go.elara.ws/itd/api"
+)
+
+func jobsList(c *cli.Context) error {
+	jobs, err := client.Jobs().List(c.Context)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		fmt.Printf("%-4d %-9s %-15s %s (started %s)\n", job.ID, job.State, job.Kind, job.Description, job.Started.Format(time.TimeOnly))
+		if job.Error != "" {
+			fmt.Printf("     %s\n", job.Error)
+		}
+	}
+
+	return nil
+}
+
+func jobsCancel(c *cli.Context) error {
+	id, err := jobID(c)
+	if err != nil {
+		return err
+	}
+
+	return client.Jobs().Cancel(c.Context, id)
+}
+
+func jobsWatch(c *cli.Context) error {
+	id, err := jobID(c)
+	if err != nil {
+		return err
+	}
+
+	events, err := client.Jobs().Watch(c.Context, id)
+	if err != nil {
+		return err
+	}
+
+	// Create progress bar template
+	barTmpl := `{{string . "name"}} {{counters . }} B {{bar . "|" "-" (cycle .) " " "|"}} {{percent . }} {{rtime . "%s"}}`
+	var bar *pb.ProgressBar
+
+	for evt := range events {
+		var (
+			name        string
+			sent, total int64
+		)
+
+		switch {
+		case evt.Transfer != nil:
+			sent, total = int64(evt.Transfer.Sent), int64(evt.Transfer.Total)
+		case evt.Resources != nil:
+			name, sent, total = evt.Resources.Name, evt.Resources.Sent, evt.Resources.Total
+		case evt.DFU != nil:
+			sent, total = evt.DFU.Received, evt.DFU.Total
+		case evt.Update != nil:
+			name, sent, total = evt.Update.Name, evt.Update.Sent, evt.Update.Total
+		default:
+			// An event without progress contains the final state of the job
+			if bar != nil {
+				bar.Finish()
+				bar = nil
+			}
+			if evt.Err != nil {
+				return evt.Err
+			}
+			fmt.Printf("Job %d %s.\n", evt.Info.ID, jobStateNames[evt.Info.State])
+			continue
+		}
+
+		if bar == nil {
+			fmt.Printf("Job %d: %s %s\n", evt.Info.ID, evt.Info.Kind, evt.Info.Description)
+			bar = pb.ProgressBarTemplate(barTmpl).Start(0)
+		}
+		bar.Set("name", name)
+		bar.SetTotal(total)
+		bar.SetCurrent(sent)
+	}
+
+	if bar != nil {
+		bar.Finish()
+	}
+
+	return nil
+}
+
+var jobStateNames = map[api.JobState]string{
+	api.JobRunning:   "is still running",
+	api.JobDone:      "finished",
+	api.JobFailed:    "failed",
+	api.JobCancelled: "was cancelled",
+}
+
+// jobID parses the job ID argument
+func jobID(c *cli.Context) (uint64, error) {
+	if c.Args().Len() != 1 {
+		return 0, cli.Exit("Command requires a job ID.", 1)
+	}
+
+	id, err := strconv.ParseUint(c.Args().Get(0), 10, 64)
+	if err != nil {
+		return 0, cli.Exit("Invalid job ID.", 1)
+	}
+	return id, nil
+}
+
+// detachJob waits for the first progress event of a job started with
+// --detach, and prints its ID so that it can be followed later.
+func detachJob[T any](progress <-chan T, id func(T) (uint64, error)) error {
+	evt, ok := <-progress
+	if !ok {
+		return nil
+	}
+
+	jobID, err := id(evt)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Started job %d. Run `itctl jobs watch %d` to follow it.\n", jobID, jobID)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -17,6 +18,9 @@ import (
 var (
 	client *api.Client
 	log *slog.Logger
+	// exitMtx is read-locked by commands that clean up after being
+	// interrupted, so that itctl doesn't exit before they're done.
+	exitMtx sync.RWMutex
 )
 
 func main() {
@@ -28,10 +32,12 @@ func main() {
 	)
 
 	// This goroutine ensures that itctl will exit
-	// at most 200ms after the user sends SIGINT/SIGTERM.
+	// at most 200ms after the user sends SIGINT/SIGTERM,
+	// unless a command is still cleaning up.
 	go func() {
 		<-ctx.Done()
 		time.Sleep(200 * time.Millisecond)
+		exitMtx.Lock()
 		os.Exit(0)
 	}()	
 	
@@ -72,11 +78,35 @@ func main() {
 				Usage:   "Handle InfiniTime resource loading",
 				Subcommands: []*cli.Command{
 					{
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "dry-run",
+								Usage: "List the planned operations without changing anything on the watch",
+							},
+							&cli.BoolFlag{
+								Name:  "force",
+								Usage: "Upload every resource, even the ones that are unchanged",
+							},
+						},
 						Name:      "load",
 						ArgsUsage: "<path>",
 						Usage:     "Load an InifiniTime resources package",
 						Action:    resourcesLoad,
 					},
+					{
+						Flags: []cli.Flag{
+							&cli.PathFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Value:   "resources.zip",
+								Usage:   "Path to write the resources package to",
+							},
+						},
+						Name:      "build",
+						ArgsUsage: "<spec.json>",
+						Usage:     "Build a resources package from fonts and images",
+						Action:    resourcesBuild,
+					},
 				},
 			},
 			{
@@ -133,6 +163,13 @@ func main() {
 						Action:    fsRemove,
 					},
 					{
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:    "detach",
+								Aliases: []string{"d"},
+								Usage:   "Keep running in itd after itctl exits, and print the job ID to follow it with",
+							},
+						},
 						Name:        "write",
 						ArgsUsage:   `<local path> <remote path>`,
 						Usage:       "Write a file to InfiniTime",
@@ -168,12 +205,62 @@ func main() {
 								Aliases: []string{"a"},
 								Usage:   "Path to firmware archive (.zip file)",
 							},
+							&cli.BoolFlag{
+								Name:    "detach",
+								Aliases: []string{"d"},
+								Usage:   "Keep running in itd after itctl exits, and print the job ID to follow it with",
+							},
 						},
 						Name:    "upgrade",
 						Aliases: []string{"upg"},
 						Usage:   "Upgrade InfiniTime firmware using files or archive",
 						Action:  fwUpgrade,
 					},
+					{
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:    "latest",
+								Aliases: []string{"l"},
+								Usage:   "Update to the latest release in the catalogue",
+							},
+							&cli.StringFlag{
+								Name:  "version",
+								Usage: "Update to a specific release",
+							},
+							&cli.StringFlag{
+								Name:    "catalogue",
+								Aliases: []string{"c"},
+								Usage:   "URL or local directory of the release catalogue, instead of the one in itd's config",
+							},
+							&cli.BoolFlag{
+								Name:  "force",
+								Usage: "Update even if InfiniTime is already up to date",
+							},
+							&cli.BoolFlag{
+								Name:  "no-resources",
+								Usage: "Don't load the release's resources",
+							},
+							&cli.BoolFlag{
+								Name:  "check",
+								Usage: "Only check whether an update is available",
+							},
+							&cli.BoolFlag{
+								Name:    "detach",
+								Aliases: []string{"d"},
+								Usage:   "Keep running in itd after itctl exits, and print the job ID to follow it with",
+							},
+						},
+						Name:    "update",
+						Aliases: []string{"upd"},
+						Usage:   "Update InfiniTime to a release from the release catalogue",
+						Action:  fwUpdate,
+					},
+					{
+						Name:    "remind-validate",
+						Aliases: []string{"remind"},
+						Usage:   "Remind you on the watch to validate the running firmware in InfiniTime's settings app",
+						Action:  fwValidateReminder,
+					},
 					{
 						Name:    "version",
 						Aliases: []string{"ver"},
@@ -182,6 +269,30 @@ func main() {
 					},
 				},
 			},
+			{
+				Name:  "jobs",
+				Usage: "Manage long operations running in itd, such as transfers and upgrades",
+				Subcommands: []*cli.Command{
+					{
+						Name:    "list",
+						Aliases: []string{"ls"},
+						Usage:   "List running and recently finished jobs",
+						Action:  jobsList,
+					},
+					{
+						Name:      "cancel",
+						ArgsUsage: "<id>",
+						Usage:     "Cancel a job",
+						Action:    jobsCancel,
+					},
+					{
+						Name:      "watch",
+						ArgsUsage: "<id>",
+						Usage:     "Follow the progress of a job until it finishes",
+						Action:    jobsWatch,
+					},
+				},
+			},
 			{
 				Name:  "get",
 				Usage: "Get information from InfiniTime",
@@ -198,6 +309,16 @@ func main() {
 						Usage:   "Get InfiniTime's battery percentage",
 						Action:  getBattery,
 					},
+					{
+						Flags: []cli.Flag{
+							&cli.BoolFlag{Name: "json"},
+							&cli.BoolFlag{Name: "shell"},
+						},
+						Name:    "capabilities",
+						Aliases: []string{"caps"},
+						Usage:   "Get the optional features supported by InfiniTime",
+						Action:  getCapabilities,
+					},
 					{
 						Name:   "heart",
 						Usage:  "Get heart rate from InfiniTime",
@@ -216,6 +337,98 @@ func main() {
 						Usage:  "Get step count from InfiniTime",
 						Action: getSteps,
 					},
+					{
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "drift",
+								Usage: "Print how far the watch's clock is from the host's clock instead",
+							},
+						},
+						Name:   "time",
+						Usage:  "Get the current time from InfiniTime",
+						Action: getTime,
+					},
+				},
+			},
+			{
+				Name:  "music",
+				Usage: "Control the InfiniTime music app",
+				Subcommands: []*cli.Command{
+					{
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "artist", Usage: "Artist of the current track"},
+							&cli.StringFlag{Name: "track", Aliases: []string{"title"}, Usage: "Title of the current track"},
+							&cli.StringFlag{Name: "album", Usage: "Album of the current track"},
+							&cli.DurationFlag{Name: "length", Usage: "Length of the current track"},
+						},
+						Name:   "set",
+						Usage:  "Set the track metadata shown by the music app",
+						Action: musicSet,
+					},
+					{
+						Flags: []cli.Flag{
+							&cli.BoolFlag{Name: "playing", Usage: "Whether the track is playing"},
+							&cli.DurationFlag{Name: "position", Usage: "Playback position of the current track"},
+							&cli.Float64Flag{Name: "rate", Usage: "Playback speed"},
+							&cli.BoolFlag{Name: "shuffle", Usage: "Whether shuffle is enabled"},
+							&cli.BoolFlag{Name: "repeat", Usage: "Whether repeat is enabled"},
+						},
+						Name:   "status",
+						Usage:  "Set the playback status shown by the music app",
+						Action: musicStatus,
+					},
+					{
+						Flags: []cli.Flag{
+							&cli.BoolFlag{Name: "json"},
+							&cli.BoolFlag{Name: "shell"},
+						},
+						Name:   "get",
+						Usage:  "Get the state of the music app",
+						Action: musicGet,
+					},
+					{
+						Flags: []cli.Flag{
+							&cli.BoolFlag{Name: "json"},
+							&cli.BoolFlag{Name: "shell"},
+						},
+						Name:   "watch",
+						Usage:  "Watch for button presses in the music app",
+						Action: musicWatch,
+					},
+				},
+			},
+			{
+				Name:    "navigation",
+				Aliases: []string{"nav"},
+				Usage:   "Control the InfiniTime navigation app",
+				Subcommands: []*cli.Command{
+					{
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "flag", Aliases: []string{"f"}, Value: "flag", Usage: "Name of the maneuver icon"},
+							&cli.StringFlag{Name: "narrative", Aliases: []string{"n"}, Usage: "Description of the next maneuver"},
+							&cli.StringFlag{Name: "distance", Aliases: []string{"d"}, Usage: "Distance to the next maneuver"},
+							&cli.UintFlag{Name: "progress", Aliases: []string{"p"}, Usage: "Route progress percentage"},
+						},
+						Name:   "set",
+						Usage:  "Set all navigation fields",
+						Action: navSet,
+					},
+					{
+						Name:   "clear",
+						Usage:  "Clear the navigation app",
+						Action: navClear,
+					},
+					{
+						Flags: []cli.Flag{
+							&cli.StringFlag{Name: "speed", Aliases: []string{"s"}, Value: "1x", Usage: "Replay speed, relative to real time"},
+							&cli.Float64Flag{Name: "base-speed", Value: 50, Usage: "Travel speed in km/h for routes without timestamps"},
+							&cli.DurationFlag{Name: "interval", Aliases: []string{"i"}, Value: time.Second, Usage: "Time between navigation updates"},
+						},
+						Name:      "replay",
+						ArgsUsage: "<route.gpx|route.geojson>",
+						Usage:     "Replay a GPX or GeoJSON route on the navigation app",
+						Action:    navReplay,
+					},
 				},
 			},
 			{
@@ -292,7 +505,7 @@ func main() {
 			},
 		},
 		Before: func(c *cli.Context) error {
-			if !isHelpCmd() {
+			if !isHelpCmd() && !isOfflineCmd() {
 				newClient, err := api.New(c.String("socket-path"))
 				if err != nil {
 					log.Error("An error occurred trying to connect to ITD. Are you sure it's running?")
@@ -322,6 +535,17 @@ func helpCmd(c *cli.Context) error {
 	return c.App.RunContext(c.Context, cmdArgs)
 }
 
+// isOfflineCmd returns true for commands that don't need itd,
+// so that they can be used without a running daemon
+func isOfflineCmd() bool {
+	for i, arg := range os.Args[:len(os.Args)-1] {
+		if (arg == "resources" || arg == "res") && os.Args[i+1] == "build" {
+			return true
+		}
+	}
+	return false
+}
+
 func isHelpCmd() bool {
 	if len(os.Args) == 1 {
 		return true
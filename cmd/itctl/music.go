@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"go.elara.ws/itd/api"
+)
+
+// musicSet and musicStatus only change the fields of the
+// music state whose flags were set, leaving the rest alone
+func musicSet(c *cli.Context) error {
+	return client.Music().UpdateMetadata(c.Context, api.MusicMetadataUpdate{
+		Artist: setFlag(c, "artist", c.String),
+		Track:  setFlag(c, "track", c.String),
+		Album:  setFlag(c, "album", c.String),
+		Length: setFlag(c, "length", c.Duration),
+	})
+}
+
+func musicStatus(c *cli.Context) error {
+	return client.Music().UpdateStatus(c.Context, api.MusicStatusUpdate{
+		Playing:  setFlag(c, "playing", c.Bool),
+		Position: setFlag(c, "position", c.Duration),
+		Rate:     setFlag(c, "rate", c.Float64),
+		Shuffle:  setFlag(c, "shuffle", c.Bool),
+		Repeat:   setFlag(c, "repeat", c.Bool),
+	})
+}
+
+// setFlag returns the value of a flag if it was set, or nil otherwise
+func setFlag[T any](c *cli.Context, name string, get func(string) T) *T {
+	if !c.IsSet(name) {
+		return nil
+	}
+	v := get(name)
+	return &v
+}
+
+func musicGet(c *cli.Context) error {
+	state, err := client.Music().GetState(c.Context)
+	if err != nil {
+		return err
+	}
+
+	md, st := state.Metadata, state.Status
+	if c.Bool("json") {
+		return json.NewEncoder(os.Stdout).Encode(state)
+	} else if c.Bool("shell") {
+		fmt.Printf(
+			"ARTIST=%q\nTRACK=%q\nALBUM=%q\nLENGTH=%d\nPLAYING=%t\nPOSITION=%d\nRATE=%g\nSHUFFLE=%t\nREPEAT=%t\n",
+			md.Artist, md.Track, md.Album, int64(md.Length.Seconds()),
+			st.Playing, int64(st.Position.Seconds()), st.Rate, st.Shuffle, st.Repeat,
+		)
+	} else {
+		status := "Paused"
+		if st.Playing {
+			status = "Playing"
+		}
+		fmt.Printf("%s - %s (%s)\n", md.Artist, md.Track, md.Album)
+		fmt.Printf("%s %s/%s at %gx\n", status, st.Position.Round(time.Second), md.Length.Round(time.Second), st.Rate)
+		fmt.Printf("Shuffle: %t, Repeat: %t\n", st.Shuffle, st.Repeat)
+	}
+	return nil
+}
+
+func musicWatch(c *cli.Context) error {
+	eventCh, err := client.Music().WatchMusicEvents(c.Context)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case event, ok := <-eventCh:
+			if !ok {
+				return nil
+			}
+
+			if c.Bool("json") {
+				json.NewEncoder(os.Stdout).Encode(
+					map[string]string{"event": event.String()},
+				)
+			} else if c.Bool("shell") {
+				fmt.Printf("MUSIC_EVENT=%s\n", event)
+			} else {
+				fmt.Println(event)
+			}
+		case <-c.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"go.elara.ws/itd/api"
+	"go.elara.ws/itd/infinitime"
+	"go.elara.ws/itd/internal/route"
+)
+
+func navSet(c *cli.Context) error {
+	progress := c.Uint("progress")
+	if progress > 100 {
+		return errors.New("progress must be between 0 and 100")
+	}
+
+	return client.Navigation().SetNav(c.Context, api.NavState{
+		Flag:      api.NavFlag(c.String("flag")),
+		Narrative: c.String("narrative"),
+		ManDist:   c.String("distance"),
+		Progress:  uint8(progress),
+	})
+}
+
+func navClear(c *cli.Context) error {
+	return client.Navigation().ClearNav(c.Context)
+}
+
+// navClearTimeout is how long an interrupted replay waits for the watch to be cleared
+const navClearTimeout = 5 * time.Second
+
+func navReplay(c *cli.Context) error {
+	if c.Args().Len() != 1 {
+		return cli.Exit("Command replay requires one argument.", 1)
+	}
+
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(c.String("speed"), "x"), 64)
+	if err != nil || speed <= 0 {
+		return cli.Exit("Speed must be a positive number, such as 5x", 1)
+	}
+
+	r, err := route.Load(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	// Keep itctl from exiting until the watch has been cleared
+	exitMtx.RLock()
+	defer exitMtx.RUnlock()
+
+	nav := client.Navigation()
+	err = r.Replay(c.Context, route.ReplayOptions{
+		Speed:     speed,
+		BaseSpeed: c.Float64("base-speed") / 3.6,
+		Interval:  c.Duration("interval"),
+	}, func(state infinitime.NavState) error {
+		return nav.SetNav(c.Context, api.NavState{
+			Flag:      api.NavFlag(state.Flag),
+			Narrative: state.Narrative,
+			ManDist:   state.ManDist,
+			Progress:  state.Progress,
+		})
+	})
+	if errors.Is(err, context.Canceled) {
+		// The replay was interrupted, so don't leave a stale maneuver on the watch
+		ctx, cancel := context.WithTimeout(context.Background(), navClearTimeout)
+		defer cancel()
+		return nav.ClearNav(ctx)
+	}
+	return err
+}
@@ -2,47 +2,63 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/cheggaaa/pb/v3"
 	"github.com/urfave/cli/v2"
+	"go.elara.ws/itd/api"
 	"go.elara.ws/itd/infinitime"
+	"go.elara.ws/itd/resources"
 )
 
 func resourcesLoad(c *cli.Context) error {
-	return resLoad(c.Context, c.Args().Slice())
+	return resLoad(c.Context, c.Args().Slice(), api.ResourceLoadOptions{
+		DryRun: c.Bool("dry-run"),
+		Force:  c.Bool("force"),
+	})
 }
 
-func resLoad(ctx context.Context, args []string) error {
+func resLoad(ctx context.Context, args []string, opts api.ResourceLoadOptions) error {
 	if len(args) == 0 {
 		return cli.Exit("Command load requires one argument.", 1)
 	}
 
-	// Create progress bar templates
-	rmTmpl := `Removing {{string . "filename"}}`
-	upTmpl := `Uploading {{string . "filename"}} {{counters . }} B {{bar . "|" "-" (cycle .) " " "|"}} {{percent . }} {{rtime . "%s"}}`
-	// Start full bar at 0 total
-	bar := pb.ProgressBarTemplate(rmTmpl).Start(0)
-
 	path, err := filepath.Abs(args[0])
 	if err != nil {
 		return err
 	}
 
-	progCh, err := client.FS().LoadResources(ctx, path)
+	progCh, err := client.FS().LoadResourcesWithOptions(ctx, path, opts)
 	if err != nil {
 		return err
 	}
 
+	if opts.DryRun {
+		return resPrintPlan(progCh)
+	}
+
+	// Create progress bar templates
+	rmTmpl := `Removing {{string . "filename"}}`
+	skipTmpl := `Skipping unchanged {{string . "filename"}}`
+	upTmpl := `Uploading {{string . "filename"}} {{counters . }} B {{bar . "|" "-" (cycle .) " " "|"}} {{percent . }} {{rtime . "%s"}}`
+	// Start full bar at 0 total
+	bar := pb.ProgressBarTemplate(rmTmpl).Start(0)
+
 	for evt := range progCh {
 		if evt.Err != nil {
 			return evt.Err
 		}
 
-		if evt.Operation == infinitime.ResourceRemove {
+		switch evt.Operation {
+		case infinitime.ResourceRemove:
 			bar.SetTemplateString(rmTmpl)
 			bar.Set("filename", evt.Name)
-		} else {
+		case infinitime.ResourceSkip:
+			bar.SetTemplateString(skipTmpl)
+			bar.Set("filename", evt.Name)
+		default:
 			bar.SetTemplateString(upTmpl)
 			bar.Set("filename", evt.Name)
 
@@ -55,3 +71,60 @@ func resLoad(ctx context.Context, args []string) error {
 
 	return nil
 }
+
+// resPrintPlan prints the operations planned by a dry run
+func resPrintPlan(progCh <-chan api.ResourceLoadProgress) error {
+	var upload, skip, remove int
+	for evt := range progCh {
+		if evt.Err != nil {
+			return evt.Err
+		}
+
+		switch evt.Operation {
+		case infinitime.ResourceRemove:
+			remove++
+			fmt.Printf("remove  %s\n", evt.Name)
+		case infinitime.ResourceSkip:
+			skip++
+			fmt.Printf("skip    %s\n", evt.Name)
+		default:
+			upload++
+			fmt.Printf("upload  %s (%d B)\n", evt.Name, evt.Total)
+		}
+	}
+
+	fmt.Printf("%d to upload, %d unchanged, %d to remove\n", upload, skip, remove)
+	return nil
+}
+
+func resourcesBuild(c *cli.Context) error {
+	if c.Args().Len() != 1 {
+		return cli.Exit("Command build requires one argument.", 1)
+	}
+
+	spec, err := resources.LoadSpec(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	out := c.Path("output")
+	fl, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+
+	err = resources.Build(spec, fl)
+	if err != nil {
+		fl.Close()
+		os.Remove(out)
+		return err
+	}
+
+	err = fl.Close()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Built %d fonts and %d images into %s\n", len(spec.Fonts), len(spec.Images), out)
+	return nil
+}
@@ -79,6 +79,8 @@ func fsTab(ctx context.Context, client *api.Client, w fyne.Window, opened chan s
 						switch evt.Operation {
 						case infinitime.ResourceRemove:
 							progressDlg.SetText("Removing " + evt.Name)
+						case infinitime.ResourceSkip:
+							progressDlg.SetText("Skipping unchanged " + evt.Name)
 						case infinitime.ResourceUpload:
 							progressDlg.SetText("Uploading " + evt.Name)
 							progressDlg.SetTotal(float64(evt.Total))
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"go.elara.ws/itd/infinitime"
+	"go.elara.ws/itd/internal/rpc"
+	"go.elara.ws/itd/internal/updater"
+)
+
+// errFirmwareReverted is returned when the watch isn't running the firmware that was flashed
+var errFirmwareReverted = errors.New("InfiniTime reverted to the previous firmware")
+
+// flashedVersion is the firmware version that was last flashed. MCUBoot
+// reverts to the previous firmware if the new one fails to boot, or if the
+// watch reboots before it's validated, so this is used to notice that.
+var flashedVersion atomic.Pointer[infinitime.Version]
+
+// flashedVersionPath returns the path of the file the flashed version is
+// saved in, so that a revert is noticed even if itd restarts meanwhile
+func flashedVersionPath() string {
+	return filepath.Join(cfg.Dir, "flashed_version")
+}
+
+// setFlashedVersion records v as the flashed version and saves it,
+// or forgets the flashed version if v is nil.
+func setFlashedVersion(v *infinitime.Version) {
+	flashedVersion.Store(v)
+
+	var err error
+	if v == nil {
+		err = os.Remove(flashedVersionPath())
+		if errors.Is(err, fs.ErrNotExist) {
+			err = nil
+		}
+	} else {
+		err = os.WriteFile(flashedVersionPath(), []byte(v.String()), 0o600)
+	}
+	if err != nil {
+		log.Warn("Error saving flashed firmware version", slog.Any("error", err))
+	}
+}
+
+// loadFlashedVersion loads the flashed version saved by setFlashedVersion
+func loadFlashedVersion() {
+	data, err := os.ReadFile(flashedVersionPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	} else if err != nil {
+		log.Warn("Error loading flashed firmware version", slog.Any("error", err))
+		return
+	}
+
+	v, err := infinitime.ParseVersion(string(data))
+	if err != nil {
+		log.Warn("Error loading flashed firmware version", slog.Any("error", err))
+		return
+	}
+	flashedVersion.Store(&v)
+}
+
+// upgradeFirmware flashes the given init packet and firmware image,
+// pausing everything else that communicates with the watch meanwhile.
+// Then, it calls rebooting, waits for the watch to reconnect, and
+// returns the firmware version it booted. If the version of the firmware
+// is known, it's recorded as the flashed version before the watch reboots.
+// Otherwise, the booted version is recorded, since it's the only sign of
+// what was flashed.
+func upgradeFirmware(ctx context.Context, dev *infinitime.Device, initpkt, fwimg io.Reader, version *infinitime.Version, progress func(sent, received, total uint32), rebooting func()) (infinitime.Version, error) {
+	defer sched.pause("firmware upgrade")()
+
+	// Get the channel before upgrading, since the watch
+	// may reconnect before the upgrade function returns
+	reconnected := dev.Reconnected()
+
+	err := dev.UpgradeFirmwareContext(ctx, infinitime.DFUOptions{
+		InitPacket:    initpkt,
+		FirmwareImage: fwimg,
+		Retries:       cfg.DFU.Retries,
+		ProgressFunc:  progress,
+	})
+	if err != nil {
+		return infinitime.Version{}, err
+	}
+
+	// The previously flashed version doesn't apply anymore, so it's
+	// forgotten if the new one is unknown. Otherwise, it would be
+	// reported as reverted when the watch reconnects.
+	setFlashedVersion(version)
+
+	rebooting()
+	log.Info("Firmware transferred, waiting for InfiniTime to reboot")
+
+	timer := time.NewTimer(time.Duration(cfg.DFU.RebootTimeout) * time.Second)
+	defer timer.Stop()
+
+	select {
+	case <-reconnected:
+	case <-timer.C:
+		return infinitime.Version{}, errors.New("InfiniTime didn't reconnect after the firmware upgrade")
+	case <-ctx.Done():
+		return infinitime.Version{}, ctx.Err()
+	}
+
+	booted, err := dev.FirmwareVersionContext(ctx)
+	if err != nil {
+		return infinitime.Version{}, err
+	}
+
+	if version == nil {
+		setFlashedVersion(&booted)
+	}
+
+	log.Info("InfiniTime rebooted after firmware upgrade", slog.String("version", booted.String()))
+	return booted, nil
+}
+
+// checkFirmwareReverted returns errFirmwareReverted if the watch
+// isn't running the firmware version that was last flashed
+func checkFirmwareReverted(current infinitime.Version) error {
+	flashed := flashedVersion.Load()
+	if flashed == nil || *flashed == current {
+		return nil
+	}
+	return fmt.Errorf("%w: %s was flashed, but InfiniTime is running %s", errFirmwareReverted, flashed, current)
+}
+
+// logFirmwareReverted logs a warning if MCUBoot reverted the watch to
+// the previous firmware, which happens if the new firmware reboots
+// before it's validated. The flashed version is forgotten once the
+// warning is logged, so that it's only logged once.
+func logFirmwareReverted(dev *infinitime.Device) {
+	if flashedVersion.Load() == nil {
+		return
+	}
+
+	current, err := dev.FirmwareVersion()
+	if err != nil {
+		log.Debug("Error reading firmware version", slog.Any("error", err))
+		return
+	}
+
+	err = checkFirmwareReverted(current)
+	if err != nil {
+		log.Warn("Firmware wasn't validated before the watch rebooted", slog.Any("error", err))
+		setFlashedVersion(nil)
+	}
+}
+
+// initFirmwareRevertCheck checks whether MCUBoot reverted the watch to the
+// previous firmware now, and every time the watch reconnects. This waits for
+// dev.Reconnected rather than using OnReconnect, since the characteristics
+// haven't been discovered again yet when OnReconnect is called.
+func initFirmwareRevertCheck(ctx context.Context, wg WaitGroup, dev *infinitime.Device) {
+	logFirmwareReverted(dev)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done("firmwareRevertCheck")
+		for {
+			select {
+			case <-dev.Reconnected():
+				logFirmwareReverted(dev)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// updateFirmware updates the watch to a release from the release catalogue.
+// The release's resources are loaded after the watch reboots into the new
+// firmware, so that they're never loaded by firmware they don't belong to.
+func updateFirmware(ctx context.Context, dev *infinitime.Device, req *rpc.FirmwareUpdateRequest, send func(*rpc.FirmwareUpdateProgress) error) error {
+	location := req.Catalogue
+	if location == "" {
+		location = cfg.Update.Catalogue
+	}
+
+	cat, err := updater.Load(ctx, location)
+	if err != nil {
+		return err
+	}
+
+	var release updater.Release
+	if req.Version == "" {
+		release, err = cat.Latest()
+	} else {
+		release, err = cat.Find(req.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	target, err := release.ParsedVersion()
+	if err != nil {
+		return err
+	}
+
+	// The version can only be unknown if the update is forced,
+	// in which case it's reported as empty.
+	var currentStr string
+	current, err := dev.FirmwareVersionContext(ctx)
+	if err == nil {
+		currentStr = current.String()
+	} else if !req.Force {
+		return err
+	}
+
+	err = send(&rpc.FirmwareUpdateProgress{
+		Stage:          rpc.FirmwareUpdateProgress_Check,
+		CurrentVersion: currentStr,
+		TargetVersion:  target.String(),
+	})
+	if err != nil || req.Check {
+		return err
+	}
+
+	if !req.Force && current.AtLeast(target) {
+		return send(&rpc.FirmwareUpdateProgress{
+			Stage:          rpc.FirmwareUpdateProgress_UpToDate,
+			CurrentVersion: currentStr,
+			TargetVersion:  target.String(),
+		})
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return err
+	}
+	dlDir := filepath.Join(cacheDir, "itd", "firmware", target.String())
+
+	fetch := func(f updater.File) (string, error) {
+		return cat.Fetch(ctx, f, dlDir, func(done, total int64) {
+			_ = send(&rpc.FirmwareUpdateProgress{
+				Stage: rpc.FirmwareUpdateProgress_Download,
+				Name:  f.Name(),
+				Sent:  done,
+				Total: total,
+			})
+		})
+	}
+
+	fwPath, err := fetch(release.Firmware)
+	if err != nil {
+		return err
+	}
+
+	archive, err := infinitime.ReadDFUArchive(fwPath)
+	if err != nil {
+		return err
+	}
+
+	// Download the resources before flashing, so that a failed
+	// download doesn't leave the new firmware without them.
+	var resPath string
+	if release.Resources != nil && !req.SkipResources {
+		resPath, err = fetch(*release.Resources)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Keep the scheduler paused between flashing
+	// the firmware and loading the resources
+	defer sched.pause("firmware update")()
+
+	log.Info(
+		"Updating firmware",
+		slog.String("from", currentStr),
+		slog.String("to", target.String()),
+	)
+
+	booted, err := upgradeFirmware(ctx, dev, bytes.NewReader(archive.InitPacket), bytes.NewReader(archive.Image), &target, func(_, received, total uint32) {
+		_ = send(&rpc.FirmwareUpdateProgress{
+			Stage: rpc.FirmwareUpdateProgress_Firmware,
+			Name:  release.Firmware.Name(),
+			Sent:  int64(received),
+			Total: int64(total),
+		})
+	}, func() {
+		_ = send(&rpc.FirmwareUpdateProgress{
+			Stage:          rpc.FirmwareUpdateProgress_Reboot,
+			CurrentVersion: currentStr,
+			TargetVersion:  target.String(),
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if booted != target {
+		// MCUBoot reverted right away, so the new firmware failed to boot
+		return fmt.Errorf("%w: expected %s, but InfiniTime is running %s", errFirmwareReverted, target, booted)
+	}
+
+	if resPath != "" {
+		if booted.Supports(infinitime.FeatureResources) {
+			err = infinitime.LoadResourcesContext(ctx, resPath, dev.FS(), func(evt infinitime.ResourceLoadProgress) {
+				_ = send(&rpc.FirmwareUpdateProgress{
+					Stage: rpc.FirmwareUpdateProgress_Resources,
+					Name:  evt.Name,
+					Sent:  int64(evt.Transferred),
+					Total: int64(evt.Total),
+				})
+			})
+			if err != nil {
+				return fmt.Errorf("firmware updated, but loading resources failed: %w", err)
+			}
+		} else {
+			log.Warn("InfiniTime doesn't support resources, not loading them", slog.String("version", booted.String()))
+		}
+	}
+
+	return send(&rpc.FirmwareUpdateProgress{
+		Stage:          rpc.FirmwareUpdateProgress_Done,
+		CurrentVersion: booted.String(),
+		TargetVersion:  target.String(),
+	})
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"testing"
+
+	"go.elara.ws/itd/infinitime"
+)
+
+func TestCheckFirmwareReverted(t *testing.T) {
+	defer flashedVersion.Store(nil)
+
+	current := infinitime.Version{Major: 1, Minor: 13}
+	if err := checkFirmwareReverted(current); err != nil {
+		t.Errorf("Expected no error before anything was flashed, got %v", err)
+	}
+
+	flashedVersion.Store(&infinitime.Version{Major: 1, Minor: 14})
+	if err := checkFirmwareReverted(current); !errors.Is(err, errFirmwareReverted) {
+		t.Errorf("Expected %v, got %v", errFirmwareReverted, err)
+	}
+
+	if err := checkFirmwareReverted(infinitime.Version{Major: 1, Minor: 14}); err != nil {
+		t.Errorf("Expected no error for the flashed version, got %v", err)
+	}
+}
+
+func TestFlashedVersionSaved(t *testing.T) {
+	log = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	oldDir := cfg.Dir
+	cfg.Dir = t.TempDir()
+	defer func() { cfg.Dir = oldDir }()
+	defer flashedVersion.Store(nil)
+
+	flashed := infinitime.Version{Major: 1, Minor: 14}
+	setFlashedVersion(&flashed)
+
+	// The flashed version is kept when itd restarts
+	flashedVersion.Store(nil)
+	loadFlashedVersion()
+	if loaded := flashedVersion.Load(); loaded == nil || *loaded != flashed {
+		t.Errorf("Expected flashed version %s, got %v", flashed, loaded)
+	}
+
+	setFlashedVersion(nil)
+	loadFlashedVersion()
+	if loaded := flashedVersion.Load(); loaded != nil {
+		t.Errorf("Expected no flashed version after forgetting it, got %s", loaded)
+	}
+	if _, err := os.Stat(flashedVersionPath()); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected the flashed version file to be removed, got %v", err)
+	}
+}
@@ -17,6 +17,7 @@ require (
 	github.com/urfave/cli/v2 v2.23.7
 	go.elara.ws/drpc v0.0.0-20230421021209-fe4c05460a3d
 	go.elara.ws/loggers v0.0.0-20240720233522-c61add53e1a3
+	golang.org/x/image v0.2.0
 	golang.org/x/text v0.21.0
 	google.golang.org/protobuf v1.28.1
 	modernc.org/sqlite v1.20.1
@@ -68,7 +69,6 @@ require (
 	github.com/yuin/goldmark v1.5.3 // indirect
 	github.com/zeebo/errs v1.3.0 // indirect
 	golang.org/x/exp v0.0.0-20241204233417-43b7b7cde48d // indirect
-	golang.org/x/image v0.2.0 // indirect
 	golang.org/x/mobile v0.0.0-20221110043201-43a038452099 // indirect
 	golang.org/x/mod v0.22.0 // indirect
 	golang.org/x/net v0.32.0 // indirect
@@ -0,0 +1,67 @@
+package infinitime
+
+// Capabilities contains the optional features supported by the
+// connected watch. Features can be missing because the watch is running
+// an old version of InfiniTime, or because they were compiled out.
+type Capabilities struct {
+	Notifications bool
+	CallControl   bool
+	Time          bool
+	LocalTime     bool
+	Battery       bool
+	HeartRate     bool
+	StepCount     bool
+	Motion        bool
+	Music         bool
+	Navigation    bool
+	Weather       bool
+	FS            bool
+	DFU           bool
+}
+
+// Capabilities returns the optional features supported by the watch.
+// They're taken from the last time every characteristic was discovered,
+// so this doesn't communicate with the watch or wait for other operations,
+// such as a firmware upgrade. Features are reported even if itd doesn't
+// use them with the running firmware version.
+func (d *Device) Capabilities() Capabilities {
+	// If discovery didn't finish on this connection, try again, unless
+	// something else is using the watch, such as a firmware upgrade
+	if !d.discovered.Load() && !d.updating.Load() && d.deviceMtx.TryLock() {
+		d.discoverAllLocked()
+		d.deviceMtx.Unlock()
+	}
+
+	caps := d.caps.Load()
+	if caps == nil {
+		return Capabilities{}
+	}
+	return *caps
+}
+
+// capabilitiesLocked returns the features whose characteristics are
+// in the cache. The caller must hold deviceMtx.
+func (d *Device) capabilitiesLocked() Capabilities {
+	return Capabilities{
+		Notifications: d.hasCharLocked(newAlertChar),
+		CallControl:   d.hasCharLocked(notifEventChar),
+		Time:          d.hasCharLocked(currentTimeChar),
+		LocalTime:     d.hasCharLocked(localTimeChar),
+		Battery:       d.hasCharLocked(batteryLevelChar),
+		HeartRate:     d.hasCharLocked(heartRateChar),
+		StepCount:     d.hasCharLocked(stepCountChar),
+		Motion:        d.hasCharLocked(rawMotionChar),
+		Music:         d.hasCharLocked(musicEventChar),
+		Navigation:    d.hasCharLocked(navigationFlagsChar),
+		Weather:       d.hasCharLocked(weatherDataChar),
+		FS:            d.hasCharLocked(fsTransferChar),
+		DFU:           d.hasCharLocked(dfuCtrlPointChar),
+	}
+}
+
+// hasCharLocked returns true if the given characteristic is in
+// the cache. The caller must hold deviceMtx.
+func (d *Device) hasCharLocked(c btChar) bool {
+	_, ok := d.chars[charKey{c.ServiceID, c.ID}]
+	return ok
+}
@@ -131,6 +131,41 @@ var (
 		mustParse("00000005-78fc-48fe-8e23-433b3a1942d0"),
 		musicServiceUUID,
 	}
+	musicPositionChar = btChar{
+		"Music Position",
+		mustParse("00000006-78fc-48fe-8e23-433b3a1942d0"),
+		musicServiceUUID,
+	}
+	musicTotalLengthChar = btChar{
+		"Music Total Length",
+		mustParse("00000007-78fc-48fe-8e23-433b3a1942d0"),
+		musicServiceUUID,
+	}
+	musicTrackNumberChar = btChar{
+		"Music Track Number",
+		mustParse("00000008-78fc-48fe-8e23-433b3a1942d0"),
+		musicServiceUUID,
+	}
+	musicTrackTotalChar = btChar{
+		"Music Track Total",
+		mustParse("00000009-78fc-48fe-8e23-433b3a1942d0"),
+		musicServiceUUID,
+	}
+	musicPlaybackSpeedChar = btChar{
+		"Music Playback Speed",
+		mustParse("0000000a-78fc-48fe-8e23-433b3a1942d0"),
+		musicServiceUUID,
+	}
+	musicRepeatChar = btChar{
+		"Music Repeat",
+		mustParse("0000000b-78fc-48fe-8e23-433b3a1942d0"),
+		musicServiceUUID,
+	}
+	musicShuffleChar = btChar{
+		"Music Shuffle",
+		mustParse("0000000c-78fc-48fe-8e23-433b3a1942d0"),
+		musicServiceUUID,
+	}
 )
 
 func mustParse(s string) bluetooth.UUID {
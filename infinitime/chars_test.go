@@ -0,0 +1,65 @@
+package infinitime
+
+import (
+	"testing"
+	"time"
+
+	"tinygo.org/x/bluetooth"
+)
+
+func TestMissingChars(t *testing.T) {
+	key := charKey{newAlertChar.ServiceID, newAlertChar.ID}
+	d := &Device{
+		chars:        map[charKey]bluetooth.DeviceCharacteristic{},
+		missingChars: map[charKey]struct{}{key: {}},
+	}
+
+	// A characteristic that wasn't found isn't looked for again,
+	// so this doesn't touch the device, which isn't connected
+	_, err := d.getChar(newAlertChar)
+	if err == nil {
+		t.Fatal("expected an error for a missing characteristic")
+	}
+	if stats := d.CharCacheStats(); stats.Hits != 1 || stats.Misses != 0 {
+		t.Errorf("got %d hits and %d misses, want 1 hit", stats.Hits, stats.Misses)
+	}
+
+	// Missing characteristics are looked for again after reconnecting
+	d.resetCharsLocked()
+	if len(d.missingChars) != 0 {
+		t.Errorf("got %d missing characteristics after reset, want 0", len(d.missingChars))
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	d := &Device{
+		chars: map[charKey]bluetooth.DeviceCharacteristic{
+			{newAlertChar.ServiceID, newAlertChar.ID}:         {},
+			{fsTransferChar.ServiceID, fsTransferChar.ID}:     {},
+			{dfuCtrlPointChar.ServiceID, dfuCtrlPointChar.ID}: {},
+		},
+	}
+	caps := d.capabilitiesLocked()
+	d.caps.Store(&caps)
+	d.discovered.Store(true)
+
+	// Capabilities are still reported during a firmware upgrade, which
+	// holds deviceMtx, and characteristics gated by the firmware version
+	// are reported as well
+	d.deviceMtx.Lock()
+	defer d.deviceMtx.Unlock()
+	d.updating.Store(true)
+
+	done := make(chan Capabilities)
+	go func() { done <- d.Capabilities() }()
+
+	want := Capabilities{Notifications: true, FS: true, DFU: true}
+	select {
+	case got := <-done:
+		if got != want {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Capabilities blocked during a firmware upgrade")
+	}
+}
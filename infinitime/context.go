@@ -0,0 +1,73 @@
+package infinitime
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tinygo.org/x/bluetooth"
+)
+
+// defaultTimeout is used when [Options.Timeout] isn't set
+const defaultTimeout = 30 * time.Second
+
+var ErrTimeout = errors.New("timed out waiting for response from watch")
+
+// withTimeout returns a context that expires after the device's default
+// timeout, unless ctx already has a deadline.
+func (d *Device) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, d.timeout)
+}
+
+// getCharContext is like getChar, but it returns ctx's
+// error instead if ctx is already done.
+func (d *Device) getCharContext(ctx context.Context, c btChar) (*bluetooth.DeviceCharacteristic, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return d.getChar(c)
+}
+
+// await waits for a value from ch. It returns an [ErrTimeout] error if nothing
+// is received within the timeout, or ctx's error if ctx is done first.
+// ok is false if ch was closed.
+func await[T any](ctx context.Context, ch <-chan T, timeout time.Duration) (val T, ok bool, err error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case val, ok = <-ch:
+		return val, ok, nil
+	case <-timer.C:
+		return val, false, ErrTimeout
+	case <-ctx.Done():
+		return val, false, ctx.Err()
+	}
+}
+
+// do runs fn and waits for it to return, or for ctx to be done. BLE
+// operations can't be interrupted, so if ctx is done first, fn keeps
+// running in the background and its result is discarded.
+func do[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	type result struct {
+		val T
+		err error
+	}
+
+	resCh := make(chan result, 1)
+	go func() {
+		val, err := fn()
+		resCh <- result{val, err}
+	}()
+
+	select {
+	case res := <-resCh:
+		return res.val, res.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,75 @@
+package infinitime
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAwait(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 1
+	val, ok, err := await(context.Background(), ch, time.Second)
+	if err != nil || !ok || val != 1 {
+		t.Errorf("got (%d, %t, %v), want (1, true, nil)", val, ok, err)
+	}
+
+	close(ch)
+	_, ok, err = await(context.Background(), ch, time.Second)
+	if err != nil || ok {
+		t.Errorf("closed channel: got (%t, %v), want (false, nil)", ok, err)
+	}
+
+	_, _, err = await(context.Background(), make(chan int), time.Millisecond)
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("got %v, want %v", err, ErrTimeout)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = await(ctx, make(chan int), time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestDo(t *testing.T) {
+	val, err := do(context.Background(), func() (int, error) { return 1, nil })
+	if err != nil || val != 1 {
+		t.Errorf("got (%d, %v), want (1, nil)", val, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	block := make(chan struct{})
+	defer close(block)
+	_, err = do(ctx, func() (int, error) {
+		<-block
+		return 1, nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	d := &Device{timeout: time.Minute}
+
+	ctx, cancel := d.withTimeout(context.Background())
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("expected the default deadline, got %v", deadline)
+	}
+
+	want := time.Now().Add(time.Hour)
+	parent, cancelParent := context.WithDeadline(context.Background(), want)
+	defer cancelParent()
+
+	ctx, cancel = d.withTimeout(parent)
+	defer cancel()
+	if deadline, _ := ctx.Deadline(); !deadline.Equal(want) {
+		t.Errorf("got deadline %v, want %v", deadline, want)
+	}
+}
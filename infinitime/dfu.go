@@ -2,20 +2,27 @@ package infinitime
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
-	"io/fs"
+	"time"
 
 	"tinygo.org/x/bluetooth"
 )
 
 const (
-	dfuSegmentSize     = 20 // Size of each firmware packet
-	dfuPktRecvInterval = 10 // Amount of packets to send before checking for receipt
+	dfuSegmentSize     = 20               // Size of each firmware packet
+	dfuPktRecvInterval = 10               // Amount of packets to send before checking for receipt
+	dfuRespTimeout     = 30 * time.Second // Time to wait for each response from the watch
+	// Time to wait before retrying a failed upgrade, so that
+	// the watch can abandon the failed transfer.
+	dfuRetryDelay = 10 * time.Second
 )
 
+var ErrDFUTimeout = errors.New("timed out waiting for dfu response")
+
 var (
 	dfuCmdStart              = []byte{0x01, 0x04}
 	dfuCmdRecvInitPkt        = []byte{0x02, 0x00}
@@ -33,15 +40,31 @@ var (
 
 // DFUOptions contains options for [UpgradeFirmware]
 type DFUOptions struct {
-	InitPacket      fs.File
-	FirmwareImage   fs.File
+	InitPacket      io.Reader
+	FirmwareImage   io.Reader
 	ProgressFunc    func(sent, received, total uint32)
 	SegmentSize     int
 	ReceiveInterval uint8
+	// ResponseTimeout is how long to wait for each response from the watch.
+	// It defaults to 30 seconds.
+	ResponseTimeout time.Duration
+	// Retries is the amount of times to retry a failed upgrade. InfiniTime uses
+	// Nordic's legacy DFU protocol, which can't resume a transfer, so every retry
+	// sends the whole image again.
+	Retries int
 }
 
 // UpgradeFirmware upgrades the firmware running on the PineTime.
+// The firmware image is checked against the init packet before
+// anything is sent to the watch.
 func (d *Device) UpgradeFirmware(opts DFUOptions) error {
+	return d.UpgradeFirmwareContext(context.Background(), opts)
+}
+
+// UpgradeFirmwareContext is like [Device.UpgradeFirmware], but it stops the
+// upgrade when ctx is done. The watch abandons the interrupted transfer and
+// keeps running its current firmware.
+func (d *Device) UpgradeFirmwareContext(ctx context.Context, opts DFUOptions) error {
 	if opts.SegmentSize <= 0 {
 		opts.SegmentSize = dfuSegmentSize
 	}
@@ -50,12 +73,51 @@ func (d *Device) UpgradeFirmware(opts DFUOptions) error {
 		opts.ReceiveInterval = dfuPktRecvInterval
 	}
 
-	ctrlPoint, err := d.getChar(dfuCtrlPointChar)
+	if opts.ResponseTimeout <= 0 {
+		opts.ResponseTimeout = dfuRespTimeout
+	}
+
+	initPkt, err := io.ReadAll(opts.InitPacket)
+	if err != nil {
+		return err
+	}
+
+	image, err := io.ReadAll(opts.FirmwareImage)
+	if err != nil {
+		return err
+	}
+
+	parsedInitPkt, err := ParseDFUInitPacket(initPkt)
+	if err != nil {
+		return err
+	}
+
+	err = parsedInitPkt.Verify(image)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = d.upgradeFirmware(ctx, opts, initPkt, image)
+		if err == nil || attempt >= opts.Retries || ctx.Err() != nil {
+			return err
+		}
+
+		select {
+		case <-time.After(dfuRetryDelay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (d *Device) upgradeFirmware(ctx context.Context, opts DFUOptions, initPkt, image []byte) error {
+	ctrlPoint, err := d.getCharContext(ctx, dfuCtrlPointChar)
 	if err != nil {
 		return err
 	}
 
-	packet, err := d.getChar(dfuPacketChar)
+	packet, err := d.getCharContext(ctx, dfuPacketChar)
 	if err != nil {
 		return err
 	}
@@ -71,11 +133,7 @@ func (d *Device) UpgradeFirmware(opts DFUOptions) error {
 		return err
 	}
 
-	fi, err := opts.FirmwareImage.Stat()
-	if err != nil {
-		return err
-	}
-	size := uint32(fi.Size())
+	size := uint32(len(image))
 
 	sizePacket := make([]byte, 8, 12)
 	sizePacket = binary.LittleEndian.AppendUint32(sizePacket, size)
@@ -84,12 +142,12 @@ func (d *Device) UpgradeFirmware(opts DFUOptions) error {
 		return err
 	}
 
-	_, err = awaitDFUResponse(ctrlPoint, dfuResponseStart)
+	_, err = awaitDFUResponse(ctx, ctrlPoint, dfuResponseStart, opts.ResponseTimeout)
 	if err != nil {
 		return err
 	}
 
-	err = writeDFUInitPacket(ctrlPoint, packet, opts.InitPacket)
+	err = writeDFUInitPacket(ctx, ctrlPoint, packet, initPkt, opts.ResponseTimeout)
 	if err != nil {
 		return err
 	}
@@ -99,21 +157,21 @@ func (d *Device) UpgradeFirmware(opts DFUOptions) error {
 		return err
 	}
 
-	err = sendFirmware(ctrlPoint, packet, opts, size)
+	err = sendFirmware(ctx, ctrlPoint, packet, opts, image)
 	if err != nil {
 		return err
 	}
 
-	return finalize(ctrlPoint)
+	return finalize(ctx, ctrlPoint, opts.ResponseTimeout)
 }
 
-func finalize(ctrlPoint *bluetooth.DeviceCharacteristic) error {
+func finalize(ctx context.Context, ctrlPoint *bluetooth.DeviceCharacteristic, timeout time.Duration) error {
 	_, err := ctrlPoint.WriteWithoutResponse(dfuCmdValidate)
 	if err != nil {
 		return err
 	}
 
-	_, err = awaitDFUResponse(ctrlPoint, dfuResponseValidate)
+	_, err = awaitDFUResponse(ctx, ctrlPoint, dfuResponseValidate, timeout)
 	if err != nil {
 		return err
 	}
@@ -122,7 +180,7 @@ func finalize(ctrlPoint *bluetooth.DeviceCharacteristic) error {
 	return nil
 }
 
-func sendFirmware(ctrlPoint, packet *bluetooth.DeviceCharacteristic, opts DFUOptions, totalSize uint32) error {
+func sendFirmware(ctx context.Context, ctrlPoint, packet *bluetooth.DeviceCharacteristic, opts DFUOptions, image []byte) error {
 	_, err := ctrlPoint.WriteWithoutResponse(dfuCmdRecvFirmware)
 	if err != nil {
 		return err
@@ -131,33 +189,32 @@ func sendFirmware(ctrlPoint, packet *bluetooth.DeviceCharacteristic, opts DFUOpt
 	var (
 		chunksSinceReceipt uint8
 		bytesSent          uint32
+		totalSize          = uint32(len(image))
 	)
 
-	chunk := make([]byte, opts.SegmentSize)
-	for {
-		n, err := opts.FirmwareImage.Read(chunk)
-		if err != nil && !errors.Is(err, io.EOF) {
+	for len(image) > 0 {
+		if err := ctx.Err(); err != nil {
 			return err
-		} else if n == 0 {
-			break
 		}
 
-		bytesSent += uint32(n)
-		_, err = packet.WriteWithoutResponse(chunk[:n])
+		chunk := image[:min(opts.SegmentSize, len(image))]
+		image = image[len(chunk):]
+
+		bytesSent += uint32(len(chunk))
+		_, err = packet.WriteWithoutResponse(chunk)
 		if err != nil {
 			return err
 		}
 
-		if errors.Is(err, io.EOF) {
-			break
-		}
-
 		chunksSinceReceipt += 1
 		if chunksSinceReceipt == opts.ReceiveInterval && bytesSent < totalSize {
-			sizeData, err := awaitDFUResponse(ctrlPoint, []byte{0x11})
+			sizeData, err := awaitDFUResponse(ctx, ctrlPoint, []byte{0x11}, opts.ResponseTimeout)
 			if err != nil {
 				return err
 			}
+			if len(sizeData) < 4 {
+				return fmt.Errorf("invalid packet receipt notification: %x", sizeData)
+			}
 			size := binary.LittleEndian.Uint32(sizeData)
 			if size != bytesSent {
 				return fmt.Errorf("size mismatch: expected %d, got %d", bytesSent, size)
@@ -169,7 +226,7 @@ func sendFirmware(ctrlPoint, packet *bluetooth.DeviceCharacteristic, opts DFUOpt
 		}
 	}
 
-	_, err = awaitDFUResponse(ctrlPoint, dfuResponseRecvFwImgSuccess)
+	_, err = awaitDFUResponse(ctx, ctrlPoint, dfuResponseRecvFwImgSuccess, opts.ResponseTimeout)
 	if err != nil {
 		return err
 	}
@@ -181,18 +238,13 @@ func sendFirmware(ctrlPoint, packet *bluetooth.DeviceCharacteristic, opts DFUOpt
 	return nil
 }
 
-func writeDFUInitPacket(ctrlPoint, packet *bluetooth.DeviceCharacteristic, initPkt fs.File) error {
+func writeDFUInitPacket(ctx context.Context, ctrlPoint, packet *bluetooth.DeviceCharacteristic, initPkt []byte, timeout time.Duration) error {
 	_, err := ctrlPoint.WriteWithoutResponse(dfuCmdRecvInitPkt)
 	if err != nil {
 		return err
 	}
 
-	initData, err := io.ReadAll(initPkt)
-	if err != nil {
-		return err
-	}
-
-	_, err = packet.WriteWithoutResponse(initData)
+	_, err = packet.WriteWithoutResponse(initPkt)
 	if err != nil {
 		return err
 	}
@@ -202,7 +254,7 @@ func writeDFUInitPacket(ctrlPoint, packet *bluetooth.DeviceCharacteristic, initP
 		return err
 	}
 
-	_, err = awaitDFUResponse(ctrlPoint, dfuResponseInitParams)
+	_, err = awaitDFUResponse(ctx, ctrlPoint, dfuResponseInitParams, timeout)
 	return err
 }
 
@@ -211,17 +263,30 @@ func setRecvInterval(ctrlPoint *bluetooth.DeviceCharacteristic, interval uint8)
 	return err
 }
 
-func awaitDFUResponse(ctrlPoint *bluetooth.DeviceCharacteristic, expect []byte) ([]byte, error) {
+// awaitDFUResponse waits for the next response from the watch, and
+// returns an error if it doesn't arrive within the timeout, if ctx is
+// done first, or if it doesn't start with expect.
+func awaitDFUResponse(ctx context.Context, ctrlPoint *bluetooth.DeviceCharacteristic, expect []byte, timeout time.Duration) ([]byte, error) {
 	respCh := make(chan []byte, 1)
 	err := ctrlPoint.EnableNotifications(func(buf []byte) {
-		respCh <- buf
+		// Drop responses that arrive after the first one,
+		// so that the callback never blocks.
+		select {
+		case respCh <- buf:
+		default:
+		}
 	})
 	if err != nil {
 		return nil, err
 	}
+	defer ctrlPoint.EnableNotifications(nil)
 
-	data := <-respCh
-	ctrlPoint.EnableNotifications(nil)
+	data, _, err := await(ctx, respCh, timeout)
+	if errors.Is(err, ErrTimeout) {
+		return nil, fmt.Errorf("%w %x", ErrDFUTimeout, expect)
+	} else if err != nil {
+		return nil, err
+	}
 
 	if !bytes.HasPrefix(data, expect) {
 		return nil, fmt.Errorf("unexpected dfu response %x (expected %x)", data, expect)
@@ -0,0 +1,220 @@
+package infinitime
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+)
+
+var (
+	ErrDFUNoApplication    = errors.New("dfu archive doesn't contain an application image")
+	ErrDFUMissingFile      = errors.New("file referenced by dfu manifest is missing from the archive")
+	ErrInvalidInitPacket   = errors.New("invalid dfu init packet")
+	ErrDFUChecksum         = errors.New("firmware image doesn't match the init packet's checksum")
+	ErrDFUManifestMismatch = errors.New("dfu manifest doesn't match the init packet")
+)
+
+// DFUManifest is the manifest.json file in a Nordic DFU archive.
+// InfiniTime only supports application updates, so the bootloader
+// and softdevice entries are ignored.
+type DFUManifest struct {
+	Application *DFUManifestImage `json:"application"`
+	DFUVersion  float64           `json:"dfu_version"`
+}
+
+// DFUManifestImage describes a single image in a DFU archive
+type DFUManifestImage struct {
+	BinFile        string            `json:"bin_file"`
+	DatFile        string            `json:"dat_file"`
+	InitPacketData DFUInitPacketData `json:"init_packet_data"`
+}
+
+// DFUInitPacketData contains the values that the archive's
+// tooling wrote into the init packet.
+type DFUInitPacketData struct {
+	ApplicationVersion uint32   `json:"application_version"`
+	DeviceRevision     uint16   `json:"device_revision"`
+	DeviceType         uint16   `json:"device_type"`
+	FirmwareCRC16      *uint16  `json:"firmware_crc16"`
+	SoftdeviceReq      []uint16 `json:"softdevice_req"`
+}
+
+// DFUArchive is the contents of a DFU archive
+type DFUArchive struct {
+	// Manifest is nil if the archive doesn't have a manifest.json
+	Manifest   *DFUManifest
+	InitPacket []byte
+	Image      []byte
+}
+
+// ReadDFUArchive reads the DFU archive at path. If the archive has a manifest,
+// the files it references are used, and its init packet data is checked against
+// the init packet. Otherwise, the first .dat and .bin files are used.
+func ReadDFUArchive(path string) (DFUArchive, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return DFUArchive{}, err
+	}
+	defer zr.Close()
+
+	var out DFUArchive
+
+	mf, err := zr.Open("manifest.json")
+	if err == nil {
+		var manifest struct {
+			Manifest DFUManifest `json:"manifest"`
+		}
+		err = json.NewDecoder(mf).Decode(&manifest)
+		mf.Close()
+		if err != nil {
+			return DFUArchive{}, fmt.Errorf("invalid dfu manifest: %w", err)
+		}
+		out.Manifest = &manifest.Manifest
+	}
+
+	datFile, binFile := findDFUFiles(zr, out.Manifest)
+	if datFile == "" || binFile == "" {
+		return DFUArchive{}, ErrDFUNoApplication
+	}
+
+	out.InitPacket, err = readZipFile(zr, datFile)
+	if err != nil {
+		return DFUArchive{}, err
+	}
+
+	out.Image, err = readZipFile(zr, binFile)
+	if err != nil {
+		return DFUArchive{}, err
+	}
+
+	initPkt, err := ParseDFUInitPacket(out.InitPacket)
+	if err != nil {
+		return DFUArchive{}, err
+	}
+
+	if out.Manifest != nil {
+		err = initPkt.checkManifest(out.Manifest.Application.InitPacketData)
+		if err != nil {
+			return DFUArchive{}, err
+		}
+	}
+
+	return out, initPkt.Verify(out.Image)
+}
+
+// findDFUFiles returns the names of the init packet and image in the archive
+func findDFUFiles(zr *zip.ReadCloser, manifest *DFUManifest) (datFile, binFile string) {
+	if manifest != nil {
+		if manifest.Application == nil {
+			return "", ""
+		}
+		return manifest.Application.DatFile, manifest.Application.BinFile
+	}
+
+	for _, file := range zr.File {
+		switch path.Ext(file.Name) {
+		case ".dat":
+			if datFile == "" {
+				datFile = file.Name
+			}
+		case ".bin":
+			if binFile == "" {
+				binFile = file.Name
+			}
+		}
+	}
+	return datFile, binFile
+}
+
+func readZipFile(zr *zip.ReadCloser, name string) ([]byte, error) {
+	fl, err := zr.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDFUMissingFile, name)
+	}
+	defer fl.Close()
+	return io.ReadAll(fl)
+}
+
+// DFUInitPacket is a legacy Nordic DFU init packet, which describes
+// the firmware image and contains its CRC16 checksum.
+type DFUInitPacket struct {
+	DeviceType         uint16
+	DeviceRevision     uint16
+	ApplicationVersion uint32
+	SoftdeviceReq      []uint16
+	FirmwareCRC16      uint16
+}
+
+// dfuInitPacketMinLen is the length of an init packet with no softdevices
+const dfuInitPacketMinLen = 12
+
+// ParseDFUInitPacket parses a legacy Nordic DFU init packet. Only init packets
+// that use a CRC16 checksum are supported, since that's what InfiniTime uses.
+func ParseDFUInitPacket(b []byte) (DFUInitPacket, error) {
+	if len(b) < dfuInitPacketMinLen {
+		return DFUInitPacket{}, fmt.Errorf("%w: too short (%d bytes)", ErrInvalidInitPacket, len(b))
+	}
+
+	pkt := DFUInitPacket{
+		DeviceType:         binary.LittleEndian.Uint16(b[0:]),
+		DeviceRevision:     binary.LittleEndian.Uint16(b[2:]),
+		ApplicationVersion: binary.LittleEndian.Uint32(b[4:]),
+	}
+
+	sdLen := int(binary.LittleEndian.Uint16(b[8:]))
+	if len(b) != dfuInitPacketMinLen+sdLen*2 {
+		return DFUInitPacket{}, fmt.Errorf(
+			"%w: expected %d bytes for %d softdevices, got %d",
+			ErrInvalidInitPacket, dfuInitPacketMinLen+sdLen*2, sdLen, len(b),
+		)
+	}
+
+	pkt.SoftdeviceReq = make([]uint16, sdLen)
+	for i := range pkt.SoftdeviceReq {
+		pkt.SoftdeviceReq[i] = binary.LittleEndian.Uint16(b[10+i*2:])
+	}
+	pkt.FirmwareCRC16 = binary.LittleEndian.Uint16(b[len(b)-2:])
+
+	return pkt, nil
+}
+
+// Verify checks that image matches the init packet's checksum
+func (p DFUInitPacket) Verify(image []byte) error {
+	if len(image) == 0 {
+		return fmt.Errorf("%w: image is empty", ErrDFUChecksum)
+	}
+	if crc := crc16(image); crc != p.FirmwareCRC16 {
+		return fmt.Errorf("%w: expected %04x, got %04x", ErrDFUChecksum, p.FirmwareCRC16, crc)
+	}
+	return nil
+}
+
+// checkManifest checks that the manifest's init packet data matches the init packet
+func (p DFUInitPacket) checkManifest(data DFUInitPacketData) error {
+	switch {
+	case data.FirmwareCRC16 != nil && *data.FirmwareCRC16 != p.FirmwareCRC16:
+		return fmt.Errorf("%w: firmware crc16 is %04x in the manifest, but %04x in the init packet", ErrDFUManifestMismatch, *data.FirmwareCRC16, p.FirmwareCRC16)
+	case data.DeviceType != p.DeviceType:
+		return fmt.Errorf("%w: device type is %d in the manifest, but %d in the init packet", ErrDFUManifestMismatch, data.DeviceType, p.DeviceType)
+	case data.ApplicationVersion != p.ApplicationVersion:
+		return fmt.Errorf("%w: application version is %d in the manifest, but %d in the init packet", ErrDFUManifestMismatch, data.ApplicationVersion, p.ApplicationVersion)
+	}
+	return nil
+}
+
+// crc16 calculates the CRC-16/CCITT-FALSE checksum used by Nordic's DFU
+func crc16(data []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, b := range data {
+		crc = crc>>8 | crc<<8
+		crc ^= uint16(b)
+		crc ^= (crc & 0xFF) >> 4
+		crc ^= crc << 12
+		crc ^= (crc & 0xFF) << 5
+	}
+	return crc
+}
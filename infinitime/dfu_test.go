@@ -0,0 +1,134 @@
+package infinitime
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestCRC16(t *testing.T) {
+	// Check value for CRC-16/CCITT-FALSE
+	if got := crc16([]byte("123456789")); got != 0x29b1 {
+		t.Errorf("got %04x, want 29b1", got)
+	}
+}
+
+func TestParseDFUInitPacket(t *testing.T) {
+	image := []byte("firmware image")
+	pkt := makeInitPacket(image)
+
+	parsed, err := ParseDFUInitPacket(pkt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.DeviceType != 0x52 || len(parsed.SoftdeviceReq) != 1 || parsed.SoftdeviceReq[0] != 0xfffe {
+		t.Errorf("unexpected init packet: %+v", parsed)
+	}
+
+	if err := parsed.Verify(image); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if err := parsed.Verify([]byte("corrupted image")); !errors.Is(err, ErrDFUChecksum) {
+		t.Errorf("expected ErrDFUChecksum, got %v", err)
+	}
+
+	for _, bad := range [][]byte{pkt[:10], pkt[:len(pkt)-1], append(pkt, 0)} {
+		if _, err := ParseDFUInitPacket(bad); !errors.Is(err, ErrInvalidInitPacket) {
+			t.Errorf("%x: expected ErrInvalidInitPacket, got %v", bad, err)
+		}
+	}
+}
+
+func TestReadDFUArchive(t *testing.T) {
+	image := []byte("firmware image")
+	initPkt := makeInitPacket(image)
+	crc := crc16(image)
+
+	manifest := func(crc uint16) string {
+		return `{"manifest": {"application": {"bin_file": "fw.bin", "dat_file": "fw.dat", "init_packet_data": {"application_version": 4294967295, "device_revision": 65535, "device_type": 82, "firmware_crc16": ` + strconv.Itoa(int(crc)) + `, "softdevice_req": [65534]}}, "dfu_version": 0.5}}`
+	}
+
+	tests := []struct {
+		name  string
+		files map[string][]byte
+		want  error
+	}{
+		{
+			name:  "valid",
+			files: map[string][]byte{"manifest.json": []byte(manifest(crc)), "fw.dat": initPkt, "fw.bin": image},
+		},
+		{
+			name:  "no manifest",
+			files: map[string][]byte{"other.dat": initPkt, "other.bin": image},
+		},
+		{
+			name:  "manifest mismatch",
+			files: map[string][]byte{"manifest.json": []byte(manifest(crc + 1)), "fw.dat": initPkt, "fw.bin": image},
+			want:  ErrDFUManifestMismatch,
+		},
+		{
+			name:  "missing file",
+			files: map[string][]byte{"manifest.json": []byte(manifest(crc)), "fw.dat": initPkt},
+			want:  ErrDFUMissingFile,
+		},
+		{
+			name:  "corrupted image",
+			files: map[string][]byte{"manifest.json": []byte(manifest(crc)), "fw.dat": initPkt, "fw.bin": []byte("firmware imagf")},
+			want:  ErrDFUChecksum,
+		},
+		{
+			name:  "no application",
+			files: map[string][]byte{"manifest.json": []byte(`{"manifest": {"bootloader": {}}}`)},
+			want:  ErrDFUNoApplication,
+		},
+	}
+
+	for _, tt := range tests {
+		path := writeZip(t, tt.files)
+		archive, err := ReadDFUArchive(path)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+			continue
+		}
+		if err == nil && string(archive.Image) != string(image) {
+			t.Errorf("%s: unexpected image %q", tt.name, archive.Image)
+		}
+	}
+}
+
+func makeInitPacket(image []byte) []byte {
+	var pkt []byte
+	pkt = binary.LittleEndian.AppendUint16(pkt, 0x52)       // device type
+	pkt = binary.LittleEndian.AppendUint16(pkt, 0xffff)     // device revision
+	pkt = binary.LittleEndian.AppendUint32(pkt, 0xffffffff) // application version
+	pkt = binary.LittleEndian.AppendUint16(pkt, 1)          // softdevice count
+	pkt = binary.LittleEndian.AppendUint16(pkt, 0xfffe)     // any softdevice
+	return binary.LittleEndian.AppendUint16(pkt, crc16(image))
+}
+
+func writeZip(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dfu.zip")
+	fl, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fl.Close()
+
+	zw := zip.NewWriter(fl)
+	for name, data := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -1,15 +1,17 @@
 package infinitime
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"io"
 	"io/fs"
 	"math"
 	"path"
 	"strings"
 	"sync"
-	"sync/atomic"
 
+	"go.elara.ws/itd/infinitime/wire"
 	"go.elara.ws/itd/internal/fsproto"
 	"tinygo.org/x/bluetooth"
 )
@@ -26,8 +28,13 @@ type FS struct {
 // this function does a ReadDir and then finds the requested file
 // in the results, which makes it pretty slow.
 func (ifs *FS) Stat(p string) (fs.FileInfo, error) {
+	return ifs.StatContext(context.Background(), p)
+}
+
+// StatContext is like [FS.Stat], but it stops waiting for the watch when ctx is done.
+func (ifs *FS) StatContext(ctx context.Context, p string) (fs.FileInfo, error) {
 	dir := path.Dir(p)
-	entries, err := ifs.ReadDir(dir)
+	entries, err := ifs.ReadDirContext(ctx, dir)
 	if err != nil {
 		return nil, err
 	}
@@ -45,50 +52,52 @@ func (ifs *FS) Stat(p string) (fs.FileInfo, error) {
 //
 // For a function that removes directories recursively, see [FS.RemoveAll]
 func (ifs *FS) Remove(path string) error {
+	return ifs.RemoveContext(context.Background(), path)
+}
+
+// RemoveContext is like [FS.Remove], but it stops waiting for the watch when ctx is done.
+func (ifs *FS) RemoveContext(ctx context.Context, path string) error {
 	ifs.mtx.Lock()
 	defer ifs.mtx.Unlock()
 
-	char, err := ifs.dev.getChar(fsTransferChar)
+	char, err := ifs.dev.getCharContext(ctx, fsTransferChar)
 	if err != nil {
 		return err
 	}
 
 	return ifs.requestThenAwaitResponse(
+		ctx,
 		char,
-		fsproto.DeleteFileOpcode,
-		fsproto.DeleteFileRequest{
-			PathLen: uint16(len(path)),
-			Path:    path,
-		},
+		wire.DeleteFileRequest{Path: path},
 		func(buf []byte) (bool, error) {
-			var mdr fsproto.DeleteFileResponse
-			return true, fsproto.ReadResponse(buf, fsproto.DeleteFileResp, &mdr)
+			_, err := readFSResponse[wire.DeleteFileResponse](buf)
+			return true, err
 		},
 	)
 }
 
 // Rename moves a file or directory from an old path to a new path.
 func (ifs *FS) Rename(old, new string) error {
+	return ifs.RenameContext(context.Background(), old, new)
+}
+
+// RenameContext is like [FS.Rename], but it stops waiting for the watch when ctx is done.
+func (ifs *FS) RenameContext(ctx context.Context, old, new string) error {
 	ifs.mtx.Lock()
 	defer ifs.mtx.Unlock()
 
-	char, err := ifs.dev.getChar(fsTransferChar)
+	char, err := ifs.dev.getCharContext(ctx, fsTransferChar)
 	if err != nil {
 		return err
 	}
 
 	return ifs.requestThenAwaitResponse(
+		ctx,
 		char,
-		fsproto.MoveFileOpcode,
-		fsproto.MoveFileRequest{
-			OldPathLen: uint16(len(old)),
-			OldPath:    old,
-			NewPathLen: uint16(len(new)),
-			NewPath:    new,
-		},
+		wire.MoveFileRequest{OldPath: old, NewPath: new},
 		func(buf []byte) (bool, error) {
-			var mfr fsproto.MoveFileResponse
-			return true, fsproto.ReadResponse(buf, fsproto.MoveFileResp, &mfr)
+			_, err := readFSResponse[wire.MoveFileResponse](buf)
+			return true, err
 		},
 	)
 }
@@ -97,49 +106,52 @@ func (ifs *FS) Rename(old, new string) error {
 //
 // For a function that creates necessary parents as well, see [FS.MkdirAll]
 func (ifs *FS) Mkdir(path string) error {
+	return ifs.MkdirContext(context.Background(), path)
+}
+
+// MkdirContext is like [FS.Mkdir], but it stops waiting for the watch when ctx is done.
+func (ifs *FS) MkdirContext(ctx context.Context, path string) error {
 	ifs.mtx.Lock()
 	defer ifs.mtx.Unlock()
 
-	char, err := ifs.dev.getChar(fsTransferChar)
+	char, err := ifs.dev.getCharContext(ctx, fsTransferChar)
 	if err != nil {
 		return err
 	}
 
 	return ifs.requestThenAwaitResponse(
+		ctx,
 		char,
-		fsproto.MakeDirectoryOpcode,
-		fsproto.MkdirRequest{
-			PathLen: uint16(len(path)),
-			Path:    path,
-		},
+		wire.MkdirRequest{Path: path},
 		func(buf []byte) (bool, error) {
-			var mdr fsproto.MkdirResponse
-			return true, fsproto.ReadResponse(buf, fsproto.MakeDirectoryResp, &mdr)
+			_, err := readFSResponse[wire.MkdirResponse](buf)
+			return true, err
 		},
 	)
 }
 
 // ReadDir reads the directory at the specified path and returns a list of directory entries.
 func (ifs *FS) ReadDir(path string) ([]fs.DirEntry, error) {
+	return ifs.ReadDirContext(context.Background(), path)
+}
+
+// ReadDirContext is like [FS.ReadDir], but it stops waiting for the watch when ctx is done.
+func (ifs *FS) ReadDirContext(ctx context.Context, path string) ([]fs.DirEntry, error) {
 	ifs.mtx.Lock()
 	defer ifs.mtx.Unlock()
 
-	char, err := ifs.dev.getChar(fsTransferChar)
+	char, err := ifs.dev.getCharContext(ctx, fsTransferChar)
 	if err != nil {
 		return nil, err
 	}
 
 	var out []fs.DirEntry
 	return out, ifs.requestThenAwaitResponse(
+		ctx,
 		char,
-		fsproto.ListDirectoryOpcode,
-		fsproto.ListDirRequest{
-			PathLen: uint16(len(path)),
-			Path:    path,
-		},
+		wire.ListDirRequest{Path: path},
 		func(buf []byte) (bool, error) {
-			var ldr fsproto.ListDirResponse
-			err := fsproto.ReadResponse(buf, fsproto.ListDirectoryResp, &ldr)
+			ldr, err := readFSResponse[wire.ListDirResponse](buf)
 			if err != nil {
 				return true, err
 			}
@@ -152,7 +164,7 @@ func (ifs *FS) ReadDir(path string) ([]fs.DirEntry, error) {
 				flags:   ldr.Flags,
 				modtime: ldr.ModTime,
 				size:    ldr.FileSize,
-				path:    string(ldr.Path),
+				path:    ldr.Path,
 			})
 
 			return false, nil
@@ -163,6 +175,11 @@ func (ifs *FS) ReadDir(path string) ([]fs.DirEntry, error) {
 // RemoveAll removes the file at the specified path and any children it contains,
 // similar to the rm -r command.
 func (ifs *FS) RemoveAll(p string) error {
+	return ifs.RemoveAllContext(context.Background(), p)
+}
+
+// RemoveAllContext is like [FS.RemoveAll], but it stops when ctx is done.
+func (ifs *FS) RemoveAllContext(ctx context.Context, p string) error {
 	if p == "" {
 		return nil
 	}
@@ -171,15 +188,15 @@ func (ifs *FS) RemoveAll(p string) error {
 		return fsproto.ErrNoRemoveRoot
 	}
 
-	fi, err := ifs.Stat(p)
+	fi, err := ifs.StatContext(ctx, p)
 	if err != nil {
 		return nil
 	}
 
 	if fi.IsDir() {
-		return ifs.removeWithChildren(p)
+		return ifs.removeWithChildren(ctx, p)
 	} else {
-		err = ifs.Remove(p)
+		err = ifs.RemoveContext(ctx, p)
 
 		var code int8
 		if err, ok := err.(fsproto.Error); ok {
@@ -195,8 +212,8 @@ func (ifs *FS) RemoveAll(p string) error {
 }
 
 // removeWithChildren removes the directory at the given path and its children recursively.
-func (ifs *FS) removeWithChildren(p string) error {
-	list, err := ifs.ReadDir(p)
+func (ifs *FS) removeWithChildren(ctx context.Context, p string) error {
+	list, err := ifs.ReadDirContext(ctx, p)
 	if err != nil {
 		return err
 	}
@@ -210,9 +227,9 @@ func (ifs *FS) removeWithChildren(p string) error {
 		entryPath := path.Join(p, name)
 
 		if entry.IsDir() {
-			err = ifs.removeWithChildren(entryPath)
+			err = ifs.removeWithChildren(ctx, entryPath)
 		} else {
-			err = ifs.Remove(entryPath)
+			err = ifs.RemoveContext(ctx, entryPath)
 		}
 
 		var code int8
@@ -225,12 +242,17 @@ func (ifs *FS) removeWithChildren(p string) error {
 		}
 	}
 
-	return ifs.Remove(p)
+	return ifs.RemoveContext(ctx, p)
 }
 
 // MkdirAll creates a directory and any necessary parents in the file system,
 // similar to the mkdir -p command.
 func (ifs *FS) MkdirAll(path string) error {
+	return ifs.MkdirAllContext(context.Background(), path)
+}
+
+// MkdirAllContext is like [FS.MkdirAll], but it stops when ctx is done.
+func (ifs *FS) MkdirAllContext(ctx context.Context, path string) error {
 	if path == "" || path == "/" {
 		return nil
 	}
@@ -239,7 +261,7 @@ func (ifs *FS) MkdirAll(path string) error {
 	for i := 1; i < len(splitPath); i++ {
 		curPath := strings.Join(splitPath[0:i+1], "/")
 
-		err := ifs.Mkdir(curPath)
+		err := ifs.MkdirContext(ctx, curPath)
 
 		var code int8
 		if err, ok := err.(fsproto.Error); ok {
@@ -262,6 +284,7 @@ var _ fs.File = (*File)(nil)
 // with the amount of bytes transferred and the total size of the file.
 type File struct {
 	fs           *FS
+	ctx          context.Context
 	path         string
 	offset       uint32
 	size         uint32
@@ -273,8 +296,15 @@ type File struct {
 // Open opens an existing file at the specified path.
 // It returns a handle for the file and an error, if any.
 func (ifs *FS) Open(path string) (*File, error) {
+	return ifs.OpenContext(context.Background(), path)
+}
+
+// OpenContext is like [FS.Open], but reads from the returned
+// file stop waiting for the watch when ctx is done.
+func (ifs *FS) OpenContext(ctx context.Context, path string) (*File, error) {
 	return &File{
 		fs:       ifs,
+		ctx:      ctx,
 		path:     path,
 		offset:   0,
 		readOnly: true,
@@ -284,14 +314,27 @@ func (ifs *FS) Open(path string) (*File, error) {
 // Create creates a new file with the specified path and size.
 // It returns a handle for the created file and an error, if any.
 func (ifs *FS) Create(path string, size uint32) (*File, error) {
+	return ifs.CreateContext(context.Background(), path, size)
+}
+
+// CreateContext is like [FS.Create], but writes to the returned
+// file stop waiting for the watch when ctx is done.
+func (ifs *FS) CreateContext(ctx context.Context, path string, size uint32) (*File, error) {
 	return &File{
 		fs:     ifs,
+		ctx:    ctx,
 		path:   path,
 		offset: 0,
 		size:   size,
 	}, nil
 }
 
+// fsResult is the result of processing a BLE FS response
+type fsResult struct {
+	done bool
+	err  error
+}
+
 // Write writes data from the byte slice b to the file.
 // It returns the number of bytes written and an error, if any.
 func (fl *File) Write(b []byte) (int, error) {
@@ -306,7 +349,7 @@ func (fl *File) Write(b []byte) (int, error) {
 	fl.fs.mtx.Lock()
 	defer fl.fs.mtx.Unlock()
 
-	char, err := fl.fs.dev.getChar(fsTransferChar)
+	char, err := fl.fs.dev.getCharContext(fl.ctx, fsTransferChar)
 	if err != nil {
 		return 0, err
 	}
@@ -318,41 +361,38 @@ func (fl *File) Write(b []byte) (int, error) {
 	transferred := uint32(0)
 	mtu := uint32(fl.fs.mtu(char))
 
-	// continueCh is used to prevent race conditions. When the
-	// request loop starts, it reads from continueCh, blocking it
-	// until it's "released" by the notification function after
-	// the response is processed.
-	continueCh := make(chan struct{}, 2)
-	var notifErr error
+	// The request loop waits for each response to be processed
+	// by the notification function before sending the next request.
+	// stopCh unblocks the notification function if the loop gives up.
+	resCh := make(chan fsResult)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
 	err = char.EnableNotifications(func(buf []byte) {
-		var wfr fsproto.WriteFileResponse
-		err = fsproto.ReadResponse(buf, fsproto.WriteFileResp, &wfr)
+		var res fsResult
+		wfr, err := readFSResponse[wire.WriteFileResponse](buf)
 		if err != nil {
-			notifErr = err
-			char.EnableNotifications(nil)
-			close(continueCh)
-			return
-		}
-
-		transferred += chunkLen
-		fl.offset += chunkLen
+			res = fsResult{true, err}
+		} else {
+			transferred += chunkLen
+			fl.offset += chunkLen
 
-		if wfr.FreeSpace == 0 || transferred == dataLen {
-			char.EnableNotifications(nil)
-			close(continueCh)
-			return
+			if wfr.FreeSpace == 0 || transferred == dataLen {
+				res.done = true
+			} else if fl.ProgressFunc != nil {
+				fl.ProgressFunc(transferred, fl.size)
+			}
 		}
 
-		if fl.ProgressFunc != nil {
-			fl.ProgressFunc(transferred, fl.size)
+		select {
+		case resCh <- res:
+		case <-stopCh:
 		}
-
-		// Release the request loop
-		continueCh <- struct{}{}
 	})
+	if err != nil {
+		return 0, err
+	}
 
-	err = fsproto.WriteRequest(char, fsproto.WriteFileHeaderOpcode, fsproto.WriteFileHeaderRequest{
-		PathLen:  uint16(len(fl.path)),
+	err = writeFSRequest(char, wire.WriteFileHeaderRequest{
 		Offset:   fl.offset,
 		FileSize: fl.size,
 		Path:     fl.path,
@@ -361,9 +401,14 @@ func (fl *File) Write(b []byte) (int, error) {
 		return int(transferred), err
 	}
 
-	for range continueCh {
-		if notifErr != nil {
-			return int(transferred), notifErr
+	for {
+		res, _, err := await(fl.ctx, resCh, fl.fs.dev.timeout)
+		if err != nil {
+			return int(transferred), err
+		}
+
+		if res.done || res.err != nil {
+			return int(transferred), res.err
 		}
 
 		amountLeft := dataLen - transferred
@@ -372,18 +417,15 @@ func (fl *File) Write(b []byte) (int, error) {
 			chunkLen = amountLeft
 		}
 
-		err = fsproto.WriteRequest(char, fsproto.WriteFileOpcode, fsproto.WriteFileRequest{
-			Status:   0x01,
-			Offset:   fl.offset,
-			ChunkLen: chunkLen,
-			Data:     b[transferred : transferred+chunkLen],
+		err = writeFSRequest(char, wire.WriteFileRequest{
+			Status: 0x01,
+			Offset: fl.offset,
+			Data:   b[transferred : transferred+chunkLen],
 		})
 		if err != nil {
 			return int(transferred), err
 		}
 	}
-
-	return int(transferred), notifErr
 }
 
 // Read reads data from the file into the byte slice b.
@@ -396,55 +438,43 @@ func (fl *File) Read(b []byte) (int, error) {
 	fl.fs.mtx.Lock()
 	defer fl.fs.mtx.Unlock()
 
-	char, err := fl.fs.dev.getChar(fsTransferChar)
+	char, err := fl.fs.dev.getCharContext(fl.ctx, fsTransferChar)
 	if err != nil {
 		return 0, err
 	}
+	defer char.EnableNotifications(nil)
 
 	transferred := uint32(0)
 	maxLen := uint32(len(b))
 	mtu := uint32(fl.fs.mtu(char))
 
-	var (
-		notifErr error
-		done     bool
-	)
-
-	// continueCh is used to prevent race conditions. When the
-	// request loop starts, it reads from continueCh, blocking it
-	// until it's "released" by the notification function after
-	// the response is processed.
-	continueCh := make(chan struct{}, 2)
+	// The request loop waits for each response to be processed
+	// by the notification function before sending the next request.
+	// stopCh unblocks the notification function if the loop gives up.
+	resCh := make(chan fsResult)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
 	err = char.EnableNotifications(func(buf []byte) {
-		var rfr fsproto.ReadFileResponse
-		err = fsproto.ReadResponse(buf, fsproto.ReadFileResp, &rfr)
+		var res fsResult
+		rfr, err := readFSResponse[wire.ReadFileResponse](buf)
 		if err != nil {
-			notifErr = err
-			char.EnableNotifications(nil)
-			close(continueCh)
-			return
-		}
+			res = fsResult{true, err}
+		} else if fl.size = rfr.FileSize; rfr.Offset == rfr.FileSize || len(rfr.Data) == 0 {
+			res = fsResult{true, io.EOF}
+		} else {
+			n := copy(b[transferred:], rfr.Data)
+			fl.offset += uint32(n)
+			transferred += uint32(n)
 
-		fl.size = rfr.FileSize
-		
-		if rfr.Offset == rfr.FileSize || rfr.ChunkLen == 0 {
-			notifErr = io.EOF
-			done = true
-			char.EnableNotifications(nil)
-			close(continueCh)
-			return
+			if fl.ProgressFunc != nil {
+				fl.ProgressFunc(transferred, rfr.FileSize)
+			}
 		}
 
-		n := copy(b[transferred:], rfr.Data[:rfr.ChunkLen])
-		fl.offset += uint32(n)
-		transferred += uint32(n)
-
-		if fl.ProgressFunc != nil {
-			fl.ProgressFunc(transferred, rfr.FileSize)
+		select {
+		case resCh <- res:
+		case <-stopCh:
 		}
-
-		// Release the request loop
-		continueCh <- struct{}{}
 	})
 	if err != nil {
 		return 0, err
@@ -456,8 +486,7 @@ func (fl *File) Read(b []byte) (int, error) {
 		chunkLen = amountLeft
 	}
 
-	err = fsproto.WriteRequest(char, fsproto.ReadFileHeaderOpcode, fsproto.ReadFileHeaderRequest{
-		PathLen: uint16(len(fl.path)),
+	err = writeFSRequest(char, wire.ReadFileHeaderRequest{
 		Offset:  fl.offset,
 		ReadLen: chunkLen,
 		Path:    fl.path,
@@ -466,16 +495,14 @@ func (fl *File) Read(b []byte) (int, error) {
 		return 0, err
 	}
 
-	if notifErr != nil {
-		return int(transferred), notifErr
-	}
-
-	for !done {
-		// Wait for the notification function to release the loop
-		<-continueCh
+	for {
+		res, _, err := await(fl.ctx, resCh, fl.fs.dev.timeout)
+		if err != nil {
+			return int(transferred), err
+		}
 
-		if notifErr != nil {
-			return int(transferred), notifErr
+		if res.done || res.err != nil {
+			return int(transferred), res.err
 		}
 
 		amountLeft = maxLen - transferred
@@ -484,7 +511,7 @@ func (fl *File) Read(b []byte) (int, error) {
 			chunkLen = amountLeft
 		}
 
-		err = fsproto.WriteRequest(char, fsproto.ReadFileOpcode, fsproto.ReadFileRequest{
+		err = writeFSRequest(char, wire.ReadFileRequest{
 			Status:  0x01,
 			Offset:  fl.offset,
 			ReadLen: chunkLen,
@@ -493,13 +520,11 @@ func (fl *File) Read(b []byte) (int, error) {
 			return int(transferred), err
 		}
 	}
-
-	return int(transferred), notifErr
 }
 
 // Stat returns information about the file,
 func (fl *File) Stat() (fs.FileInfo, error) {
-	return fl.fs.Stat(fl.path)
+	return fl.fs.StatContext(fl.ctx, fl.path)
 }
 
 // Seek sets the offset for the next Read or Write on the file to the specified offset.
@@ -554,40 +579,66 @@ func (fl *File) Close() error {
 }
 
 // requestThenAwaitResponse executes a BLE FS request and then waits for one or more responses,
-// until fn returns true or an error is encountered.
-func (ifs *FS) requestThenAwaitResponse(char *bluetooth.DeviceCharacteristic, opcode fsproto.FSReqOpcode, req any, fn func(buf []byte) (bool, error)) error {
-	var stopped atomic.Bool
-	errCh := make(chan error, 1)
-	char.EnableNotifications(func(buf []byte) {
+// until fn returns true or an error is encountered. It gives up if a response doesn't arrive
+// within the device's timeout, or if ctx is done.
+func (ifs *FS) requestThenAwaitResponse(ctx context.Context, char *bluetooth.DeviceCharacteristic, req wire.FSRequest, fn func(buf []byte) (bool, error)) error {
+	resCh := make(chan fsResult)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	err := char.EnableNotifications(func(buf []byte) {
 		stop, err := fn(buf)
-		if err != nil && !stopped.Load() {
-			errCh <- err
-			char.EnableNotifications(nil)
-			return
-		} else if !stopped.Load() {
-			errCh <- nil
-		}
-
-		if stop && !stopped.Load() {
-			stopped.Store(true)
-			close(errCh)
-			char.EnableNotifications(nil)
+		select {
+		case resCh <- fsResult{stop, err}:
+		case <-stopCh:
 		}
 	})
-	//defer char.EnableNotifications(nil)
+	if err != nil {
+		return err
+	}
+	defer char.EnableNotifications(nil)
 
-	err := fsproto.WriteRequest(char, opcode, req)
+	err = writeFSRequest(char, req)
 	if err != nil {
 		return err
 	}
 
-	for err := range errCh {
+	for {
+		res, _, err := await(ctx, resCh, ifs.dev.timeout)
 		if err != nil {
 			return err
 		}
+
+		if res.done || res.err != nil {
+			return res.err
+		}
 	}
+}
 
-	return nil
+// writeFSRequest sends a BLE FS request
+func writeFSRequest(char *bluetooth.DeviceCharacteristic, req wire.FSRequest) error {
+	_, err := char.WriteWithoutResponse(req.Encode())
+	return err
+}
+
+// readFSResponse decodes a BLE FS response of type T,
+// returning an error if the watch reported one.
+func readFSResponse[T wire.FSResponse](buf []byte) (T, error) {
+	var out T
+	resp, err := wire.DecodeFSResponse(buf)
+	if err != nil {
+		return out, err
+	}
+
+	out, ok := resp.(T)
+	if !ok {
+		return out, fmt.Errorf("unexpected response opcode: expected %x, got %x", out.Opcode(), resp.Opcode())
+	}
+
+	if code := out.StatusCode(); code != 0 && code != 0x01 {
+		return out, fsproto.Error{Code: code}
+	}
+
+	return out, nil
 }
 
 func (ifs *FS) mtu(char *bluetooth.DeviceCharacteristic) uint16 {
@@ -2,6 +2,7 @@ package infinitime
 
 import (
 	"fmt"
+	"log/slog"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,6 +14,14 @@ type Options struct {
 	Allowlist    []string
 	Blocklist    []string
 	ScanInterval time.Duration
+	// Timeout is the default deadline for operations on the device,
+	// used when the context passed to them doesn't have one. Filesystem
+	// operations can take much longer, so they apply it to each response
+	// from the watch instead. It defaults to 30 seconds.
+	Timeout time.Duration
+	// Logger receives errors that aren't returned to the caller, such as
+	// failures to discover characteristics. It defaults to slog.Default().
+	Logger *slog.Logger
 
 	OnDisconnect func(dev *Device)
 	OnReconnect  func(dev *Device)
@@ -39,8 +48,15 @@ func reconnect(opts Options, adapter *bluetooth.Adapter, device *Device, mac str
 
 			device.deviceMtx.Lock()
 			device.device = dev
+			// The cached characteristics belong to the old connection,
+			// so discover them again for the new one.
+			device.resetCharsLocked()
+			device.discoverAllLocked()
 			device.deviceMtx.Unlock()
 
+			// The firmware may have been upgraded while disconnected
+			device.resetVersion()
+
 			device.notifierMtx.Lock()
 			for char, notifier := range device.notifierMap {
 				c, err := device.getChar(char)
@@ -60,6 +76,7 @@ func reconnect(opts Options, adapter *bluetooth.Adapter, device *Device, mac str
 			}
 			device.notifierMtx.Unlock()
 
+			device.signalReconnected()
 			done = true
 		})
 
@@ -78,6 +95,10 @@ func Connect(opts Options) (device *Device, err error) {
 		opts.ScanInterval = 2 * time.Minute
 	}
 
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultTimeout
+	}
+
 	var mac string
 	adapter.SetConnectHandler(func(dev bluetooth.Device, connected bool) {
 		if mac == "" || dev.Address.String() != mac {
@@ -89,6 +110,14 @@ func Connect(opts Options) (device *Device, err error) {
 				opts.OnReconnect(device)
 			}
 		} else {
+			if device != nil {
+				device.deviceMtx.Lock()
+				device.resetCharsLocked()
+				device.deviceMtx.Unlock()
+				// The firmware may be upgraded before the watch reconnects
+				device.resetVersion()
+			}
+
 			if opts.OnDisconnect != nil {
 				opts.OnDisconnect(device)
 			}
@@ -115,7 +144,29 @@ func Connect(opts Options) (device *Device, err error) {
 		}
 		mac = dev.Address.String()
 
-		device = &Device{adapter: a, device: dev, notifierMap: map[btChar]notifier{}}
+		logger := opts.Logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+
+		device = &Device{
+			adapter:      a,
+			device:       dev,
+			timeout:      opts.Timeout,
+			log:          logger,
+			notifierMap:  map[btChar]notifier{},
+			chars:        map[charKey]bluetooth.DeviceCharacteristic{},
+			missingChars: map[charKey]struct{}{},
+			reconnected:  make(chan struct{}),
+		}
+
+		// Discover all the characteristics up front, so that the first
+		// call to each feature doesn't have to wait for discovery.
+		// If this fails, they'll be discovered when they're first used.
+		device.deviceMtx.Lock()
+		device.discoverAllLocked()
+		device.deviceMtx.Unlock()
+
 		if opts.OnConnect != nil {
 			opts.OnConnect(device)
 		}
@@ -139,9 +190,52 @@ type Device struct {
 	deviceMtx sync.Mutex
 	device    bluetooth.Device
 	updating  atomic.Bool
+	timeout   time.Duration
+	log       *slog.Logger
+	// chars caches the characteristics discovered on the current
+	// connection. It's protected by deviceMtx.
+	chars map[charKey]bluetooth.DeviceCharacteristic
+	// missingChars contains the characteristics that discovery didn't
+	// find on the current connection, so that they aren't looked for
+	// again until the watch reconnects. It's protected by deviceMtx.
+	missingChars map[charKey]struct{}
+	// discovered is true once every characteristic has been discovered
+	// on the current connection, and caps contains the capabilities found
+	// the last time that happened.
+	discovered        atomic.Bool
+	caps              atomic.Pointer[Capabilities]
+	charHits          atomic.Uint64
+	charMisses        atomic.Uint64
+	charDiscoveryTime atomic.Int64
 
 	notifierMtx sync.Mutex
 	notifierMap map[btChar]notifier
+
+	versionMtx sync.Mutex
+	version    *Version
+
+	reconnectMtx sync.Mutex
+	// reconnected is closed and replaced every time the watch reconnects
+	reconnected chan struct{}
+
+	navMtx sync.Mutex
+}
+
+// Reconnected returns a channel that's closed the next time the watch
+// reconnects, once the connection is ready to use. It's meant to be
+// called before something that makes the watch disconnect, such as a
+// firmware upgrade, so that the reconnect can't be missed.
+func (d *Device) Reconnected() <-chan struct{} {
+	d.reconnectMtx.Lock()
+	defer d.reconnectMtx.Unlock()
+	return d.reconnected
+}
+
+func (d *Device) signalReconnected() {
+	d.reconnectMtx.Lock()
+	defer d.reconnectMtx.Unlock()
+	close(d.reconnected)
+	d.reconnected = make(chan struct{})
 }
 
 // FS returns a handle for InifniTime's filesystem'
@@ -151,23 +245,153 @@ func (d *Device) FS() *FS {
 	}
 }
 
+// charKey identifies a characteristic in the characteristic cache
+type charKey struct {
+	service bluetooth.UUID
+	char    bluetooth.UUID
+}
+
+// CharCacheStats contains statistics about the characteristic cache
+type CharCacheStats struct {
+	// Hits is the number of characteristic lookups that used the cache
+	Hits uint64
+	// Misses is the number of characteristic lookups that required discovery
+	Misses uint64
+	// DiscoveryTime is the total time spent discovering
+	// characteristics that weren't in the cache
+	DiscoveryTime time.Duration
+}
+
+// CharCacheStats returns statistics about the characteristic cache.
+// The statistics are kept for the lifetime of the device, across reconnects.
+func (d *Device) CharCacheStats() CharCacheStats {
+	return CharCacheStats{
+		Hits:          d.charHits.Load(),
+		Misses:        d.charMisses.Load(),
+		DiscoveryTime: time.Duration(d.charDiscoveryTime.Load()),
+	}
+}
+
 func (d *Device) getChar(c btChar) (*bluetooth.DeviceCharacteristic, error) {
 	if d.updating.Load() {
 		return nil, fmt.Errorf("device is currently updating")
 	}
 
+	// Don't use characteristics that this firmware version may implement differently
+	if f, ok := charFeatures[c]; ok {
+		if err := d.Supports(f); err != nil {
+			return nil, err
+		}
+	}
+
 	d.deviceMtx.Lock()
 	defer d.deviceMtx.Unlock()
 
-	services, err := d.device.DiscoverServices([]bluetooth.UUID{c.ServiceID})
+	key := charKey{c.ServiceID, c.ID}
+	if char, ok := d.chars[key]; ok {
+		d.charHits.Add(1)
+		// Return a copy, since callers may change the characteristic's
+		// notification state
+		return &char, nil
+	}
+	if _, ok := d.missingChars[key]; ok {
+		d.charHits.Add(1)
+		return nil, charNotFound(c)
+	}
+	d.charMisses.Add(1)
+
+	start := time.Now()
+	defer func() { d.charDiscoveryTime.Add(int64(time.Since(start))) }()
+
+	// Discover everything and look for the characteristic, since BlueZ
+	// reports missing services and characteristics as errors, which can't
+	// be told apart from other errors, such as timeouts.
+	services, err := d.device.DiscoverServices(nil)
 	if err != nil {
-		return nil, fmt.Errorf("characteristic %s (%s) not found", c.ID, c.Name)
+		return nil, d.discoveryError(c, err)
 	}
 
-	chars, err := services[0].DiscoverCharacteristics([]bluetooth.UUID{c.ID})
+	for _, service := range services {
+		if service.UUID() != c.ServiceID {
+			continue
+		}
+
+		chars, err := service.DiscoverCharacteristics(nil)
+		if err != nil {
+			return nil, d.discoveryError(c, err)
+		}
+
+		for _, char := range chars {
+			d.chars[charKey{service.UUID(), char.UUID()}] = char
+		}
+	}
+
+	if char, ok := d.chars[key]; ok {
+		return &char, nil
+	}
+
+	// Discovery worked, but the watch doesn't have the characteristic,
+	// so don't look for it again until the watch reconnects
+	d.missingChars[key] = struct{}{}
+	return nil, charNotFound(c)
+}
+
+// discoveryError logs and wraps an error that occurred while discovering c.
+// Nothing is cached, since the error may be temporary.
+func (d *Device) discoveryError(c btChar, err error) error {
+	d.log.Warn(
+		"Error discovering characteristic",
+		slog.String("name", c.Name),
+		slog.String("uuid", c.ID.String()),
+		slog.Any("error", err),
+	)
+	return fmt.Errorf("discovering characteristic %s (%s): %w", c.ID, c.Name, err)
+}
+
+func charNotFound(c btChar) error {
+	return fmt.Errorf("characteristic %s (%s) not found", c.ID, c.Name)
+}
+
+// discoverAllLocked discovers every characteristic, logging any error
+// instead of returning it. The caller must hold deviceMtx.
+func (d *Device) discoverAllLocked() {
+	err := d.discoverCharsLocked()
+	if err != nil {
+		d.log.Warn("Error discovering characteristics", slog.Any("error", err))
+		return
+	}
+
+	caps := d.capabilitiesLocked()
+	d.caps.Store(&caps)
+	d.discovered.Store(true)
+}
+
+// discoverCharsLocked discovers every characteristic on the device
+// and adds them to the cache. The caller must hold deviceMtx.
+func (d *Device) discoverCharsLocked() error {
+	services, err := d.device.DiscoverServices(nil)
 	if err != nil {
-		return nil, fmt.Errorf("characteristic %s (%s) not found", c.ID, c.Name)
+		return err
 	}
 
-	return &chars[0], err
+	for _, service := range services {
+		chars, err := service.DiscoverCharacteristics(nil)
+		if err != nil {
+			return err
+		}
+
+		for _, char := range chars {
+			d.chars[charKey{service.UUID(), char.UUID()}] = char
+		}
+	}
+
+	return nil
+}
+
+// resetCharsLocked empties the characteristic cache, including the
+// characteristics that weren't found. The caller must hold deviceMtx.
+func (d *Device) resetCharsLocked() {
+	clear(d.chars)
+	clear(d.missingChars)
+	d.discovered.Store(false)
 }
@@ -2,7 +2,10 @@ package infinitime
 
 import (
 	"context"
-	"encoding/binary"
+	"strings"
+
+	"go.elara.ws/itd/infinitime/wire"
+	"tinygo.org/x/bluetooth"
 )
 
 // Address returns the MAC address of the connected device.
@@ -12,90 +15,131 @@ func (d *Device) Address() string {
 
 // Version returns the version of InifniTime that the connected device is running.
 func (d *Device) Version() (string, error) {
-	c, err := d.getChar(firmwareVerChar)
+	return d.VersionContext(context.Background())
+}
+
+// VersionContext is like [Device.Version], but it stops waiting for the watch when ctx is done.
+func (d *Device) VersionContext(ctx context.Context) (string, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	c, err := d.getCharContext(ctx, firmwareVerChar)
 	if err != nil {
 		return "", err
 	}
 
-	ver := make([]byte, 16)
-	n, err := c.Read(ver)
-	return string(ver[:n]), err
+	return do(ctx, func() (string, error) {
+		ver := make([]byte, 32)
+		n, err := c.Read(ver)
+		return strings.TrimRight(string(ver[:n]), "\x00"), err
+	})
 }
 
 // BatteryLevel returns the current battery level of the connected PineTime.
-func (d *Device) BatteryLevel() (lvl uint8, err error) {
-	c, err := d.getChar(batteryLevelChar)
-	if err != nil {
-		return 0, err
-	}
+func (d *Device) BatteryLevel() (uint8, error) {
+	return d.BatteryLevelContext(context.Background())
+}
 
-	err = binary.Read(c, binary.LittleEndian, &lvl)
-	return lvl, err
+// BatteryLevelContext is like [Device.BatteryLevel], but it stops waiting for the watch when ctx is done.
+func (d *Device) BatteryLevelContext(ctx context.Context) (uint8, error) {
+	return readCharContext(ctx, d, batteryLevelChar, wire.DecodeBatteryLevel)
 }
 
 // WatchBatteryLevel calls fn whenever the battery level changes.
 func (d *Device) WatchBatteryLevel(ctx context.Context, fn func(level uint8, err error)) error {
-	return watchChar(ctx, d, batteryLevelChar, fn)
+	return watchChar(ctx, d, batteryLevelChar, wire.DecodeBatteryLevel, fn)
 }
 
 // StepCount returns the current step count recorded on the watch.
-func (d *Device) StepCount() (sc uint32, err error) {
-	c, err := d.getChar(stepCountChar)
-	if err != nil {
-		return 0, err
-	}
+func (d *Device) StepCount() (uint32, error) {
+	return d.StepCountContext(context.Background())
+}
 
-	err = binary.Read(c, binary.LittleEndian, &sc)
-	return sc, err
+// StepCountContext is like [Device.StepCount], but it stops waiting for the watch when ctx is done.
+func (d *Device) StepCountContext(ctx context.Context) (uint32, error) {
+	return readCharContext(ctx, d, stepCountChar, wire.DecodeStepCount)
 }
 
 // WatchStepCount calls fn whenever the step count changes.
 func (d *Device) WatchStepCount(ctx context.Context, fn func(count uint32, err error)) error {
-	return watchChar(ctx, d, stepCountChar, fn)
+	return watchChar(ctx, d, stepCountChar, wire.DecodeStepCount, fn)
 }
 
 // HeartRate returns the current heart rate recorded on the watch.
 func (d *Device) HeartRate() (uint8, error) {
-	c, err := d.getChar(heartRateChar)
-	if err != nil {
-		return 0, err
-	}
-
-	data := make([]byte, 2)
-	_, err = c.Read(data)
-	if err != nil {
-		return 0, err
-	}
+	return d.HeartRateContext(context.Background())
+}
 
-	return data[1], nil
+// HeartRateContext is like [Device.HeartRate], but it stops waiting for the watch when ctx is done.
+func (d *Device) HeartRateContext(ctx context.Context) (uint8, error) {
+	return readCharContext(ctx, d, heartRateChar, wire.DecodeHeartRate)
 }
 
 // WatchHeartRate calls fn whenever the heart rate changes.
 func (d *Device) WatchHeartRate(ctx context.Context, fn func(rate uint8, err error)) error {
-	return watchChar(ctx, d, heartRateChar, func(rate [2]uint8, err error) {
-		fn(rate[1], err)
-	})
+	return watchChar(ctx, d, heartRateChar, wire.DecodeHeartRate, fn)
 }
 
 // MotionValues represents gyroscope coordinates.
-type MotionValues struct {
-	X int16
-	Y int16
-	Z int16
-}
+type MotionValues = wire.MotionValues
 
 // Motion returns the current gyroscope coordinates of the PineTime.
-func (d *Device) Motion() (mv MotionValues, err error) {
-	c, err := d.getChar(rawMotionChar)
-	if err != nil {
-		return MotionValues{}, err
-	}
+func (d *Device) Motion() (MotionValues, error) {
+	return d.MotionContext(context.Background())
+}
 
-	err = binary.Read(c, binary.LittleEndian, &mv)
-	return mv, err
+// MotionContext is like [Device.Motion], but it stops waiting for the watch when ctx is done.
+func (d *Device) MotionContext(ctx context.Context) (MotionValues, error) {
+	return readCharContext(ctx, d, rawMotionChar, wire.DecodeMotion)
 }
 
 // WatchMotion calls fn whenever the gyroscope coordinates change.
 func (d *Device) WatchMotion(ctx context.Context, fn func(level MotionValues, err error)) error {
-	return watchChar(ctx, d, rawMotionChar, fn)
+	return watchChar(ctx, d, rawMotionChar, wire.DecodeMotion, fn)
+}
+
+// readCharContext reads the value of ch and decodes it, giving up when ctx is
+// done. The device's default timeout is used if ctx doesn't have a deadline.
+func readCharContext[T any](ctx context.Context, d *Device, ch btChar, decode func([]byte) (T, error)) (T, error) {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	c, err := d.getCharContext(ctx, ch)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return do(ctx, func() (T, error) {
+		return readChar(c, decode)
+	})
+}
+
+// writeCharContext writes b to ch, giving up when ctx is done. The
+// device's default timeout is used if ctx doesn't have a deadline.
+func (d *Device) writeCharContext(ctx context.Context, ch btChar, b []byte) error {
+	ctx, cancel := d.withTimeout(ctx)
+	defer cancel()
+
+	c, err := d.getCharContext(ctx, ch)
+	if err != nil {
+		return err
+	}
+
+	_, err = do(ctx, func() (int, error) {
+		return c.WriteWithoutResponse(b)
+	})
+	return err
+}
+
+// readChar reads the value of c and decodes it
+func readChar[T any](c *bluetooth.DeviceCharacteristic, decode func([]byte) (T, error)) (T, error) {
+	buf := make([]byte, 32)
+	n, err := c.Read(buf)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	// Read returns the full length of the value, even if it didn't fit in buf
+	return decode(buf[:min(n, len(buf))])
 }
@@ -1,68 +1,140 @@
 package infinitime
 
-import "context"
+import (
+	"context"
+	"time"
 
-type MusicEvent uint8
+	"go.elara.ws/itd/infinitime/wire"
+)
+
+type MusicEvent = wire.MusicEvent
 
 const (
-	MusicEventOpen    MusicEvent = 0xe0
-	MusicEventPlay    MusicEvent = 0x00
-	MusicEventPause   MusicEvent = 0x01
-	MusicEventNext    MusicEvent = 0x03
-	MusicEventPrev    MusicEvent = 0x04
-	MusicEventVolUp   MusicEvent = 0x05
-	MusicEventVolDown MusicEvent = 0x06
+	MusicEventOpen    = wire.MusicEventOpen
+	MusicEventPlay    = wire.MusicEventPlay
+	MusicEventPause   = wire.MusicEventPause
+	MusicEventNext    = wire.MusicEventNext
+	MusicEventPrev    = wire.MusicEventPrev
+	MusicEventVolUp   = wire.MusicEventVolUp
+	MusicEventVolDown = wire.MusicEventVolDown
 )
 
 // SetMusicStatus sets whether the music is playing or paused.
 func (d *Device) SetMusicStatus(playing bool) error {
-	char, err := d.getChar(musicStatusChar)
-	if err != nil {
-		return err
-	}
+	return d.SetMusicStatusContext(context.Background(), playing)
+}
 
-	if playing {
-		_, err = char.WriteWithoutResponse([]byte{0x1})
-	} else {
-		_, err = char.WriteWithoutResponse([]byte{0x0})
-	}
-	return err
+// SetMusicStatusContext is like [Device.SetMusicStatus], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetMusicStatusContext(ctx context.Context, playing bool) error {
+	return d.writeCharContext(ctx, musicStatusChar, wire.EncodeBool(playing))
 }
 
 // SetMusicArtist sets the music artist.
 func (d *Device) SetMusicArtist(artist string) error {
-	char, err := d.getChar(musicArtistChar)
-	if err != nil {
-		return err
-	}
+	return d.SetMusicArtistContext(context.Background(), artist)
+}
 
-	_, err = char.WriteWithoutResponse([]byte(artist))
-	return err
+// SetMusicArtistContext is like [Device.SetMusicArtist], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetMusicArtistContext(ctx context.Context, artist string) error {
+	return d.writeCharContext(ctx, musicArtistChar, []byte(artist))
 }
 
 // SetMusicTrack sets the music track name.
 func (d *Device) SetMusicTrack(track string) error {
-	char, err := d.getChar(musicTrackChar)
-	if err != nil {
-		return err
-	}
+	return d.SetMusicTrackContext(context.Background(), track)
+}
 
-	_, err = char.WriteWithoutResponse([]byte(track))
-	return err
+// SetMusicTrackContext is like [Device.SetMusicTrack], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetMusicTrackContext(ctx context.Context, track string) error {
+	return d.writeCharContext(ctx, musicTrackChar, []byte(track))
 }
 
 // SetMusicAlbum sets the music album name.
 func (d *Device) SetMusicAlbum(album string) error {
-	char, err := d.getChar(musicAlbumChar)
-	if err != nil {
-		return err
-	}
+	return d.SetMusicAlbumContext(context.Background(), album)
+}
+
+// SetMusicAlbumContext is like [Device.SetMusicAlbum], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetMusicAlbumContext(ctx context.Context, album string) error {
+	return d.writeCharContext(ctx, musicAlbumChar, []byte(album))
+}
+
+// SetMusicPosition sets the current playback position of the track.
+// InfiniTime only stores whole seconds, so pos is truncated.
+func (d *Device) SetMusicPosition(pos time.Duration) error {
+	return d.SetMusicPositionContext(context.Background(), pos)
+}
+
+// SetMusicPositionContext is like [Device.SetMusicPosition], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetMusicPositionContext(ctx context.Context, pos time.Duration) error {
+	return d.writeCharContext(ctx, musicPositionChar, wire.EncodeMusicUint32(uint32(pos/time.Second)))
+}
+
+// SetMusicLength sets the total length of the track.
+// InfiniTime only stores whole seconds, so length is truncated.
+func (d *Device) SetMusicLength(length time.Duration) error {
+	return d.SetMusicLengthContext(context.Background(), length)
+}
+
+// SetMusicLengthContext is like [Device.SetMusicLength], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetMusicLengthContext(ctx context.Context, length time.Duration) error {
+	return d.writeCharContext(ctx, musicTotalLengthChar, wire.EncodeMusicUint32(uint32(length/time.Second)))
+}
+
+// SetMusicTrackNumber sets the number of the current track
+// within its album or playlist.
+func (d *Device) SetMusicTrackNumber(num uint32) error {
+	return d.SetMusicTrackNumberContext(context.Background(), num)
+}
+
+// SetMusicTrackNumberContext is like [Device.SetMusicTrackNumber], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetMusicTrackNumberContext(ctx context.Context, num uint32) error {
+	return d.writeCharContext(ctx, musicTrackNumberChar, wire.EncodeMusicUint32(num))
+}
+
+// SetMusicTrackTotal sets the total amount of tracks
+// in the current album or playlist.
+func (d *Device) SetMusicTrackTotal(total uint32) error {
+	return d.SetMusicTrackTotalContext(context.Background(), total)
+}
+
+// SetMusicTrackTotalContext is like [Device.SetMusicTrackTotal], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetMusicTrackTotalContext(ctx context.Context, total uint32) error {
+	return d.writeCharContext(ctx, musicTrackTotalChar, wire.EncodeMusicUint32(total))
+}
+
+// SetMusicPlaybackSpeed sets the playback speed, where 1.0 is normal speed.
+// The watch uses this to advance its progress bar between position updates.
+func (d *Device) SetMusicPlaybackSpeed(speed float64) error {
+	return d.SetMusicPlaybackSpeedContext(context.Background(), speed)
+}
+
+// SetMusicPlaybackSpeedContext is like [Device.SetMusicPlaybackSpeed], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetMusicPlaybackSpeedContext(ctx context.Context, speed float64) error {
+	return d.writeCharContext(ctx, musicPlaybackSpeedChar, wire.EncodeMusicUint32(uint32(speed*100)))
+}
+
+// SetMusicRepeat sets whether repeat is enabled.
+func (d *Device) SetMusicRepeat(repeat bool) error {
+	return d.SetMusicRepeatContext(context.Background(), repeat)
+}
+
+// SetMusicRepeatContext is like [Device.SetMusicRepeat], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetMusicRepeatContext(ctx context.Context, repeat bool) error {
+	return d.writeCharContext(ctx, musicRepeatChar, wire.EncodeBool(repeat))
+}
+
+// SetMusicShuffle sets whether shuffle is enabled.
+func (d *Device) SetMusicShuffle(shuffle bool) error {
+	return d.SetMusicShuffleContext(context.Background(), shuffle)
+}
 
-	_, err = char.WriteWithoutResponse([]byte(album))
-	return err
+// SetMusicShuffleContext is like [Device.SetMusicShuffle], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetMusicShuffleContext(ctx context.Context, shuffle bool) error {
+	return d.writeCharContext(ctx, musicShuffleChar, wire.EncodeBool(shuffle))
 }
 
 // WatchMusicEvents calls fn whenever the InfiniTime music app broadcasts an event.
 func (d *Device) WatchMusicEvents(ctx context.Context, fn func(event MusicEvent, err error)) error {
-	return watchChar(ctx, d, musicEventChar, fn)
+	return watchChar(ctx, d, musicEventChar, wire.DecodeMusicEvent, fn)
 }
@@ -1,5 +1,11 @@
 package infinitime
 
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
 type NavFlag string
 
 const (
@@ -96,42 +102,329 @@ const (
 	NavFlagUTurn                   NavFlag = "uturn"
 )
 
-// SetNavFlag sets the navigation flag icon.
-func (d *Device) SetNavFlag(flag NavFlag) error {
-	char, err := d.getChar(navigationFlagsChar)
+// navFlags contains every navigation flag known by InfiniTime
+var navFlags = map[NavFlag]struct{}{
+	NavFlagArrive:                  {},
+	NavFlagArriveLeft:              {},
+	NavFlagArriveRight:             {},
+	NavFlagArriveStraight:          {},
+	NavFlagClose:                   {},
+	NavFlagContinue:                {},
+	NavFlagContinueLeft:            {},
+	NavFlagContinueRight:           {},
+	NavFlagContinueSlightLeft:      {},
+	NavFlagContinueSlightRight:     {},
+	NavFlagContinueStraight:        {},
+	NavFlagContinueUturn:           {},
+	NavFlagDepart:                  {},
+	NavFlagDepartLeft:              {},
+	NavFlagDepartRight:             {},
+	NavFlagDepartStraight:          {},
+	NavFlagEndOfRoadLeft:           {},
+	NavFlagEndOfRoadRight:          {},
+	NavFlagFerry:                   {},
+	NavFlagFlag:                    {},
+	NavFlagFork:                    {},
+	NavFlagForkLeft:                {},
+	NavFlagForkRight:               {},
+	NavFlagForkSlightLeft:          {},
+	NavFlagForkSlightRight:         {},
+	NavFlagForkStraight:            {},
+	NavFlagInvalid:                 {},
+	NavFlagInvalidLeft:             {},
+	NavFlagInvalidRight:            {},
+	NavFlagInvalidSlightLeft:       {},
+	NavFlagInvalidSlightRight:      {},
+	NavFlagInvalidStraight:         {},
+	NavFlagInvalidUturn:            {},
+	NavFlagMergeLeft:               {},
+	NavFlagMergeRight:              {},
+	NavFlagMergeSlightLeft:         {},
+	NavFlagMergeSlightRight:        {},
+	NavFlagMergeStraight:           {},
+	NavFlagNewNameLeft:             {},
+	NavFlagNewNameRight:            {},
+	NavFlagNewNameSharpLeft:        {},
+	NavFlagNewNameSharpRight:       {},
+	NavFlagNewNameSlightLeft:       {},
+	NavFlagNewNameSlightRight:      {},
+	NavFlagNewNameStraight:         {},
+	NavFlagNotificationLeft:        {},
+	NavFlagNotificationRight:       {},
+	NavFlagNotificationSharpLeft:   {},
+	NavFlagNotificationSharpRight:  {},
+	NavFlagNotificationSlightLeft:  {},
+	NavFlagNotificationSlightRight: {},
+	NavFlagNotificationStraight:    {},
+	NavFlagOffRampLeft:             {},
+	NavFlagOffRampRight:            {},
+	NavFlagOffRampSharpLeft:        {},
+	NavFlagOffRampSharpRight:       {},
+	NavFlagOffRampSlightLeft:       {},
+	NavFlagOffRampSlightRight:      {},
+	NavFlagOffRampStraight:         {},
+	NavFlagOnRampLeft:              {},
+	NavFlagOnRampRight:             {},
+	NavFlagOnRampSharpLeft:         {},
+	NavFlagOnRampSharpRight:        {},
+	NavFlagOnRampSlightLeft:        {},
+	NavFlagOnRampSlightRight:       {},
+	NavFlagOnRampStraight:          {},
+	NavFlagRotary:                  {},
+	NavFlagRotaryLeft:              {},
+	NavFlagRotaryRight:             {},
+	NavFlagRotarySharpLeft:         {},
+	NavFlagRotarySharpRight:        {},
+	NavFlagRotarySlightLeft:        {},
+	NavFlagRotarySlightRight:       {},
+	NavFlagRotaryStraight:          {},
+	NavFlagRoundabout:              {},
+	NavFlagRoundaboutLeft:          {},
+	NavFlagRoundaboutRight:         {},
+	NavFlagRoundaboutSharpLeft:     {},
+	NavFlagRoundaboutSharpRight:    {},
+	NavFlagRoundaboutSlightLeft:    {},
+	NavFlagRoundaboutSlightRight:   {},
+	NavFlagRoundaboutStraight:      {},
+	NavFlagTurnLeft:                {},
+	NavFlagTurnRight:               {},
+	NavFlagTurnSharpLeft:           {},
+	NavFlagTurnSharpRight:          {},
+	NavFlagTurnSlightLeft:          {},
+	NavFlagTurnSlightRight:         {},
+	NavFlagTurnStraight:            {},
+	NavFlagUpDown:                  {},
+	NavFlagUTurn:                   {},
+}
+
+// navFlagAliases maps maneuver types used by other routing
+// engines to the closest type used by InfiniTime
+var navFlagAliases = map[string]string{
+	"start":            "depart",
+	"destination":      "arrive",
+	"becomes":          "new-name",
+	"stay":             "continue",
+	"ramp":             "on-ramp",
+	"exit":             "off-ramp",
+	"roundabout-enter": "roundabout",
+	"roundabout-exit":  "roundabout",
+	"roundabout-turn":  "roundabout",
+	"exit-roundabout":  "roundabout",
+	"rotary-exit":      "rotary",
+	"exit-rotary":      "rotary",
+	"ferry-enter":      "ferry",
+	"ferry-exit":       "ferry",
+}
+
+// Valid returns true if InfiniTime has an icon for the flag
+func (f NavFlag) Valid() bool {
+	_, ok := navFlags[f]
+	return ok
+}
+
+// ParseNavFlag returns the navigation flag that most closely matches s.
+// Along with the flags known by InfiniTime, it accepts OSRM maneuvers
+// such as "sharp left" or "roundabout exit 2", and Valhalla maneuvers
+// such as "kSlightRight". If nothing matches, NavFlagInvalid is returned.
+func ParseNavFlag(s string) NavFlag {
+	if f := NavFlag(s); f.Valid() {
+		return f
+	}
+
+	// Valhalla maneuver names have a k prefix, like kSlightRight
+	if len(s) > 1 && s[0] == 'k' && unicode.IsUpper(rune(s[1])) {
+		s = s[1:]
+	}
+
+	var kind []string
+	var adj, dir string
+	uturn := false
+	for _, word := range splitWords(s) {
+		switch word {
+		case "left", "right", "straight":
+			dir = word
+		case "sharp", "slight":
+			adj = word
+		case "uturn":
+			uturn = true
+		default:
+			if !isDigits(word) {
+				kind = append(kind, word)
+			}
+		}
+	}
+
+	modifier := dir
+	if adj != "" && dir != "" {
+		modifier = adj + "-" + dir
+	}
+	if uturn {
+		modifier = "uturn"
+	}
+
+	k := strings.Join(kind, "-")
+	if alias, ok := navFlagAliases[k]; ok {
+		k = alias
+	}
+
+	var candidates []string
+	if k != "" {
+		candidates = append(candidates, k+"-"+modifier)
+	} else if modifier != "" {
+		candidates = append(candidates, "turn-"+modifier)
+	}
+	if uturn {
+		candidates = append(candidates, string(NavFlagUTurn))
+	}
+	if k != "" {
+		candidates = append(candidates, k, k+"-straight")
+	}
+	candidates = append(candidates, "invalid-"+modifier)
+
+	for _, c := range candidates {
+		if f := NavFlag(c); f.Valid() {
+			return f
+		}
+	}
+	return NavFlagInvalid
+}
+
+// splitWords splits s into lowercase words, treating any
+// non-alphanumeric character or change to uppercase as a
+// word boundary. "u turn" and "u-turn" become "uturn".
+func splitWords(s string) []string {
+	var sb strings.Builder
+	for i, r := range s {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte(' ')
+		}
+	}
+
+	words := strings.Fields(sb.String())
+	out := words[:0]
+	for i := 0; i < len(words); i++ {
+		if words[i] == "u" && i+1 < len(words) && words[i+1] == "turn" {
+			out = append(out, "uturn")
+			i++
+			continue
+		}
+		out = append(out, words[i])
+	}
+	return out
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// NavState represents the full state of InfiniTime's navigation app
+type NavState struct {
+	Flag      NavFlag
+	Narrative string
+	ManDist   string
+	Progress  uint8
+}
+
+// SetNav sets every navigation field. No other navigation
+// writes are interleaved with the ones made by SetNav.
+// The flag is mapped in the same way as in SetNavFlag.
+func (d *Device) SetNav(state NavState) error {
+	return d.SetNavContext(context.Background(), state)
+}
+
+// SetNavContext is like [Device.SetNav], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetNavContext(ctx context.Context, state NavState) error {
+	d.navMtx.Lock()
+	defer d.navMtx.Unlock()
+
+	err := d.writeCharContext(ctx, navigationFlagsChar, []byte(ParseNavFlag(string(state.Flag))))
 	if err != nil {
 		return err
 	}
-	_, err = char.WriteWithoutResponse([]byte(flag))
-	return err
-}
 
-// SetNavNarrative sets the navigation narrative string.
-func (d *Device) SetNavNarrative(narrative string) error {
-	char, err := d.getChar(navigationNarrativeChar)
+	err = d.writeCharContext(ctx, navigationNarrativeChar, []byte(state.Narrative))
 	if err != nil {
 		return err
 	}
-	_, err = char.WriteWithoutResponse([]byte(narrative))
-	return err
-}
 
-// SetNavManeuverDistance sets the navigation maneuver distance.
-func (d *Device) SetNavManeuverDistance(manDist string) error {
-	char, err := d.getChar(navigationManDist)
+	err = d.writeCharContext(ctx, navigationManDist, []byte(state.ManDist))
 	if err != nil {
 		return err
 	}
-	_, err = char.WriteWithoutResponse([]byte(manDist))
-	return err
+
+	return d.writeCharContext(ctx, navigationProgress, []byte{state.Progress})
+}
+
+// ClearNav clears the navigation app. InfiniTime has no way to
+// remove the navigation data, so this sets the flag icon and
+// empties the other fields.
+func (d *Device) ClearNav() error {
+	return d.ClearNavContext(context.Background())
+}
+
+// ClearNavContext is like [Device.ClearNav], but it stops waiting for the watch when ctx is done.
+func (d *Device) ClearNavContext(ctx context.Context) error {
+	return d.SetNavContext(ctx, NavState{Flag: NavFlagFlag})
+}
+
+// SetNavFlag sets the navigation flag icon. Flags that InfiniTime
+// doesn't know are mapped to the closest known flag using ParseNavFlag.
+func (d *Device) SetNavFlag(flag NavFlag) error {
+	return d.SetNavFlagContext(context.Background(), flag)
+}
+
+// SetNavFlagContext is like [Device.SetNavFlag], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetNavFlagContext(ctx context.Context, flag NavFlag) error {
+	d.navMtx.Lock()
+	defer d.navMtx.Unlock()
+	return d.writeCharContext(ctx, navigationFlagsChar, []byte(ParseNavFlag(string(flag))))
+}
+
+// SetNavNarrative sets the navigation narrative string.
+func (d *Device) SetNavNarrative(narrative string) error {
+	return d.SetNavNarrativeContext(context.Background(), narrative)
+}
+
+// SetNavNarrativeContext is like [Device.SetNavNarrative], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetNavNarrativeContext(ctx context.Context, narrative string) error {
+	d.navMtx.Lock()
+	defer d.navMtx.Unlock()
+	return d.writeCharContext(ctx, navigationNarrativeChar, []byte(narrative))
+}
+
+// SetNavManeuverDistance sets the navigation maneuver distance.
+func (d *Device) SetNavManeuverDistance(manDist string) error {
+	return d.SetNavManeuverDistanceContext(context.Background(), manDist)
+}
+
+// SetNavManeuverDistanceContext is like [Device.SetNavManeuverDistance], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetNavManeuverDistanceContext(ctx context.Context, manDist string) error {
+	d.navMtx.Lock()
+	defer d.navMtx.Unlock()
+	return d.writeCharContext(ctx, navigationManDist, []byte(manDist))
 }
 
 // SetNavProgress sets the navigation progress.
 func (d *Device) SetNavProgress(progress uint8) error {
-	char, err := d.getChar(navigationProgress)
-	if err != nil {
-		return err
-	}
-	_, err = char.WriteWithoutResponse([]byte{progress})
-	return err
+	return d.SetNavProgressContext(context.Background(), progress)
+}
+
+// SetNavProgressContext is like [Device.SetNavProgress], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetNavProgressContext(ctx context.Context, progress uint8) error {
+	d.navMtx.Lock()
+	defer d.navMtx.Unlock()
+	return d.writeCharContext(ctx, navigationProgress, []byte{progress})
 }
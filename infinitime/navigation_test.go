@@ -0,0 +1,47 @@
+package infinitime
+
+import "testing"
+
+func TestParseNavFlag(t *testing.T) {
+	tests := []struct {
+		in   string
+		want NavFlag
+	}{
+		{"turn-left", NavFlagTurnLeft},
+		{"roundabout-sharp-right", NavFlagRoundaboutSharpRight},
+		{"sharp left", NavFlagTurnSharpLeft},
+		{"Slight Right", NavFlagTurnSlightRight},
+		{"turn straight", NavFlagTurnStraight},
+		{"uturn", NavFlagUTurn},
+		{"u-turn", NavFlagUTurn},
+		{"continue uturn", NavFlagContinueUturn},
+		{"turn u turn", NavFlagUTurn},
+		{"new name slight left", NavFlagNewNameSlightLeft},
+		{"end of road right", NavFlagEndOfRoadRight},
+		{"on ramp", NavFlagOnRampStraight},
+		{"off_ramp_left", NavFlagOffRampLeft},
+		{"roundabout exit 2", NavFlagRoundabout},
+		{"exit roundabout", NavFlagRoundabout},
+		{"rotary exit 1", NavFlagRotary},
+		{"merge", NavFlagMergeStraight},
+		{"kSlightRight", NavFlagTurnSlightRight},
+		{"kUturnLeft", NavFlagUTurn},
+		{"kStart", NavFlagDepart},
+		{"kDestinationLeft", NavFlagArriveLeft},
+		{"kExitRight", NavFlagOffRampRight},
+		{"kRampLeft", NavFlagOnRampLeft},
+		{"kStayStraight", NavFlagContinueStraight},
+		{"kBecomes", NavFlagNewNameStraight},
+		{"kFerryEnter", NavFlagFerry},
+		{"kRoundaboutEnter", NavFlagRoundabout},
+		{"teleport left", NavFlagInvalidLeft},
+		{"teleport", NavFlagInvalid},
+		{"", NavFlagInvalid},
+	}
+
+	for _, tt := range tests {
+		if got := ParseNavFlag(tt.in); got != tt.want {
+			t.Errorf("ParseNavFlag(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -1,42 +1,90 @@
 package infinitime
 
-var (
-	regularNotifHeader = []byte{0x00, 0x01, 0x00}
-	callNotifHeader    = []byte{0x03, 0x01, 0x00}
+import (
+	"context"
+
+	"go.elara.ws/itd/infinitime/wire"
+)
+
+type CallStatus = wire.CallStatus
+
+const (
+	CallStatusDeclined = wire.CallStatusDeclined
+	CallStatusAccepted = wire.CallStatusAccepted
+	CallStatusMuted    = wire.CallStatusMuted
 )
 
 // Notify sends a notification to the PineTime using the Alert Notification Service
 func (d *Device) Notify(title, body string) error {
-	c, err := d.getChar(newAlertChar)
+	return d.NotifyContext(context.Background(), title, body)
+}
+
+// NotifyContext is like [Device.Notify], but it stops waiting for the watch when ctx is done.
+func (d *Device) NotifyContext(ctx context.Context, title, body string) error {
+	return d.writeCharContext(ctx, newAlertChar, wire.Alert{
+		Category: wire.AlertCategorySimple,
+		Count:    1,
+		Title:    title,
+		Body:     body,
+	}.Encode())
+}
+
+// NotifyCall sends a call to the PineTime using the Alert Notification Service,
+// then executes fn once the user presses a button on the watch.
+func (d *Device) NotifyCall(from string, fn func(CallStatus)) error {
+	return d.NotifyCallContext(context.Background(), from, fn)
+}
+
+// NotifyCallContext is like [Device.NotifyCall], but it stops waiting for the
+// user to press a button when ctx is done. If ctx doesn't have a deadline,
+// the device's default timeout is used.
+func (d *Device) NotifyCallContext(ctx context.Context, from string, fn func(CallStatus)) error {
+	call, err := d.SendCallContext(ctx, from)
 	if err != nil {
 		return err
 	}
+	return call.Wait(fn)
+}
 
-	content := title + "\x00" + body
-	_, err = c.WriteWithoutResponse(append(regularNotifHeader, content...))
-	return err
+// CallNotification is a call that was sent to the PineTime
+type CallNotification struct {
+	wait func(func(CallStatus)) error
 }
 
-type CallStatus uint8
+// Wait waits for the user to press a button on the watch, then executes fn.
+// It stops waiting when the context passed to [Device.SendCallContext] is done.
+func (c *CallNotification) Wait(fn func(CallStatus)) error {
+	return c.wait(fn)
+}
 
-const (
-	CallStatusDeclined CallStatus = iota
-	CallStatusAccepted
-	CallStatusMuted
-)
+// SendCallContext sends a call to the PineTime like [Device.NotifyCallContext],
+// but returns as soon as the call is sent, so that waiting for the user to press
+// a button is separate from communicating with the watch. ctx limits both
+// sending the call and [CallNotification.Wait]. If ctx doesn't have a deadline,
+// the device's default timeout is used.
+func (d *Device) SendCallContext(ctx context.Context, from string) (*CallNotification, error) {
+	ctx, cancel := d.withTimeout(ctx)
 
-// NotifyCall sends a call to the PineTime using the Alert Notification Service,
-// then executes fn once the user presses a button on the watch.
-func (d *Device) NotifyCall(from string, fn func(CallStatus)) error {
-	c, err := d.getChar(newAlertChar)
+	err := d.writeCharContext(ctx, newAlertChar, wire.Alert{
+		Category: wire.AlertCategoryCall,
+		Count:    1,
+		Title:    from,
+	}.Encode())
 	if err != nil {
-		return err
+		cancel()
+		return nil, err
 	}
 
-	_, err = c.WriteWithoutResponse(append(callNotifHeader, from...))
+	wait, err := watchCharNext(ctx, d, notifEventChar, wire.DecodeCallStatus)
 	if err != nil {
-		return err
+		cancel()
+		return nil, err
 	}
 
-	return watchCharOnce(d, notifEventChar, fn)
+	return &CallNotification{
+		wait: func(fn func(CallStatus)) error {
+			defer cancel()
+			return wait(fn)
+		},
+	}, nil
 }
@@ -2,12 +2,25 @@ package infinitime
 
 import (
 	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"io"
+	"path"
 	"path/filepath"
+	"strings"
+
+	"go.elara.ws/itd/internal/fsproto"
 )
 
+// ResourceManifestPath is the path of the manifest that records the
+// size and checksum of every resource loaded onto the watch, so that
+// unchanged resources can be skipped the next time they're loaded.
+const ResourceManifestPath = "/.itd/resources.json"
+
 type ResourceOperation int
 
 const (
@@ -17,6 +30,9 @@ const (
 	// ResourceRemove represents the obsolete
 	// file removal phase of resource loading
 	ResourceRemove
+	// ResourceSkip represents a resource that's
+	// already on the watch, so it isn't uploaded
+	ResourceSkip
 )
 
 // resourceManifest is the structure of the resource manifest file
@@ -37,6 +53,17 @@ type obsoleteResource struct {
 	Since string `json:"since"`
 }
 
+// watchManifest is the structure of the manifest stored on the watch
+type watchManifest struct {
+	Files map[string]watchResource `json:"files"`
+}
+
+// watchResource represents a resource entry in the watch manifest
+type watchResource struct {
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
 // ResourceLoadProgress contains information on the progress of
 // a resource load
 type ResourceLoadProgress struct {
@@ -46,8 +73,45 @@ type ResourceLoadProgress struct {
 	Transferred uint32
 }
 
+// ResourceLoadOptions contains options for [LoadResourcesWithOptions]
+type ResourceLoadOptions struct {
+	// DryRun reports the operations that would be done
+	// through Progress, without changing anything on the watch
+	DryRun bool
+	// Force uploads every resource, even the ones that are unchanged
+	Force bool
+	// Progress is called with the progress of each operation, if it's non-nil
+	Progress func(ResourceLoadProgress)
+}
+
 // LoadResources accepts the path of an InfiniTime resource archive and loads its contents to the watch's filesystem.
+// Resources that are already on the watch are skipped.
 func LoadResources(archivePath string, fs *FS, progress func(ResourceLoadProgress)) error {
+	return LoadResourcesContext(context.Background(), archivePath, fs, progress)
+}
+
+// LoadResourcesContext is like [LoadResources], but it stops loading resources when ctx is done.
+func LoadResourcesContext(ctx context.Context, archivePath string, fs *FS, progress func(ResourceLoadProgress)) error {
+	return LoadResourcesWithOptions(ctx, archivePath, fs, ResourceLoadOptions{Progress: progress})
+}
+
+// LoadResourcesWithOptions is like [LoadResourcesContext], but it accepts options.
+//
+// A resource is skipped if a file with the same size is on the watch, and the
+// watch's resource manifest records the same checksum for it. Obsolete files are
+// only removed if they exist. The manifest is updated after loading the resources,
+// even if loading fails partway through, so that a retry can skip what was loaded.
+func LoadResourcesWithOptions(ctx context.Context, archivePath string, fs *FS, opts ResourceLoadOptions) (err error) {
+	err = fs.dev.Supports(FeatureResources)
+	if err != nil {
+		return err
+	}
+
+	progress := opts.Progress
+	if progress == nil {
+		progress = func(ResourceLoadProgress) {}
+	}
+
 	r, err := zip.OpenReader(archivePath)
 	if err != nil {
 		return err
@@ -70,66 +134,302 @@ func LoadResources(archivePath string, fs *FS, progress func(ResourceLoadProgres
 		return err
 	}
 
-	for _, file := range manifest.Obsolete {
-		err := fs.RemoveAll(file.Path)
-		if err != nil {
-			return err
+	files, err := hashResources(r, manifest)
+	if err != nil {
+		return err
+	}
+
+	onWatch, err := listResourceDirs(ctx, fs, manifest)
+	if err != nil {
+		return err
+	}
+
+	wm, err := readWatchManifest(ctx, fs, onWatch)
+	if err != nil {
+		return err
+	}
+
+	ops := planResources(manifest, files, onWatch, wm, opts.Force)
+
+	if !opts.DryRun {
+		changed := false
+		defer func() {
+			if changed {
+				err = errors.Join(err, writeWatchManifest(ctx, fs, wm))
+			}
+		}()
+
+		for _, op := range ops {
+			if op.op != ResourceSkip {
+				changed = true
+			}
+
+			err = runResourceOp(ctx, fs, r, op, wm, progress)
+			if err != nil {
+				return err
+			}
 		}
+		return nil
+	}
 
+	for _, op := range ops {
 		progress(ResourceLoadProgress{
-			Operation: ResourceRemove,
-			Name:      filepath.Base(file.Path),
+			Operation: op.op,
+			Name:      op.displayName(),
+			Total:     uint32(op.size),
 		})
 	}
+	return nil
+}
+
+// archiveResource contains the size and checksum of a resource in the archive
+type archiveResource struct {
+	size int64
+	sum  string
+}
 
+// hashResources computes the size and checksum of every resource in the archive
+func hashResources(r *zip.ReadCloser, manifest resourceManifest) (map[string]archiveResource, error) {
+	out := make(map[string]archiveResource, len(manifest.Resources))
 	for _, file := range manifest.Resources {
 		src, err := r.Open(file.Name)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		fi, err := src.Stat()
+		h := sha256.New()
+		n, err := io.Copy(h, src)
+		src.Close()
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		err = fs.MkdirAll(filepath.Dir(file.Path))
-		if err != nil {
-			return err
+		out[file.Name] = archiveResource{n, hex.EncodeToString(h.Sum(nil))}
+	}
+	return out, nil
+}
+
+// listResourceDirs lists every directory that the resources and obsolete
+// files are in, as well as the manifest's directory, and returns the
+// sizes of the files in them, keyed by path.
+func listResourceDirs(ctx context.Context, fs *FS, manifest resourceManifest) (map[string]int64, error) {
+	dirs := map[string]bool{path.Dir(ResourceManifestPath): true}
+	for _, file := range manifest.Resources {
+		dirs[path.Dir(path.Clean(file.Path))] = true
+	}
+	for _, file := range manifest.Obsolete {
+		dirs[path.Dir(path.Clean(file.Path))] = true
+	}
+
+	out := map[string]int64{}
+	for dir := range dirs {
+		entries, err := fs.ReadDirContext(ctx, dir)
+		if code, ok := fsErrCode(err); ok && code == -2 {
+			// The directory doesn't exist, so neither do its files
+			continue
+		} else if err != nil {
+			return nil, err
 		}
 
-		dst, err := fs.Create(file.Path, uint32(fi.Size()))
-		if err != nil {
-			return err
+		for _, entry := range entries {
+			name := entry.Name()
+			if name == "." || name == ".." {
+				continue
+			}
+
+			fi, err := entry.Info()
+			if err != nil {
+				return nil, err
+			}
+			out[path.Join(dir, name)] = fi.Size()
 		}
+	}
+	return out, nil
+}
 
-		dst.ProgressFunc = func(transferred, total uint32) {
-			progress(ResourceLoadProgress{
-				Name:        file.Name,
-				Transferred: transferred,
-				Total:       total,
-			})
+// readWatchManifest reads the resource manifest from the watch. If it
+// doesn't exist or it's invalid, an empty manifest is returned, so that
+// every resource is uploaded.
+func readWatchManifest(ctx context.Context, fs *FS, onWatch map[string]int64) (watchManifest, error) {
+	wm := watchManifest{Files: map[string]watchResource{}}
+	if _, ok := onWatch[ResourceManifestPath]; !ok {
+		return wm, nil
+	}
+
+	fl, err := fs.OpenContext(ctx, ResourceManifestPath)
+	if err != nil {
+		return wm, err
+	}
+	defer fl.Close()
+
+	data, err := io.ReadAll(fl)
+	if err != nil {
+		return wm, err
+	}
+
+	if json.Unmarshal(data, &wm) != nil || wm.Files == nil {
+		return watchManifest{Files: map[string]watchResource{}}, nil
+	}
+	return wm, nil
+}
+
+// writeWatchManifest writes the resource manifest to the watch
+func writeWatchManifest(ctx context.Context, fs *FS, wm watchManifest) error {
+	data, err := json.Marshal(wm)
+	if err != nil {
+		return err
+	}
+
+	err = fs.MkdirAllContext(ctx, path.Dir(ResourceManifestPath))
+	if err != nil {
+		return err
+	}
+
+	fl, err := fs.CreateContext(ctx, ResourceManifestPath, uint32(len(data)))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fl, bytes.NewReader(data))
+	return errors.Join(err, fl.Close())
+}
+
+// resourceOp is an operation planned by planResources
+type resourceOp struct {
+	op ResourceOperation
+	// name is the name of the resource in the archive.
+	// It's empty for obsolete files.
+	name string
+	path string
+	size int64
+	sum  string
+}
+
+// displayName returns the name that's reported in progress updates
+func (op resourceOp) displayName() string {
+	if op.name == "" {
+		return filepath.Base(op.path)
+	}
+	return op.name
+}
+
+// planResources decides which operations are needed to load the resources.
+// onWatch contains the sizes of the files on the watch, and wm is the watch's
+// resource manifest. Obsolete files are removed first, and only if they exist.
+// Resources are skipped if they're unchanged, unless force is true.
+func planResources(manifest resourceManifest, files map[string]archiveResource, onWatch map[string]int64, wm watchManifest, force bool) []resourceOp {
+	var ops []resourceOp
+
+	for _, file := range manifest.Obsolete {
+		p := path.Clean(file.Path)
+		if _, ok := onWatch[p]; !ok {
+			continue
 		}
+		ops = append(ops, resourceOp{op: ResourceRemove, path: p})
+	}
 
-		_, err = io.Copy(dst, src)
-		if err != nil {
-			return errors.Join(
-				err,
-				src.Close(),
-				dst.Close(),
-			)
+	for _, file := range manifest.Resources {
+		p := path.Clean(file.Path)
+		ar := files[file.Name]
+		op := resourceOp{op: ResourceUpload, name: file.Name, path: p, size: ar.size, sum: ar.sum}
+
+		size, ok := onWatch[p]
+		recorded, known := wm.Files[p]
+		if !force && ok && known && size == ar.size && recorded.Size == ar.size && recorded.SHA256 == ar.sum {
+			op.op = ResourceSkip
 		}
+		ops = append(ops, op)
+	}
+
+	return ops
+}
 
-		err = src.Close()
+// runResourceOp runs a single planned operation and updates the watch manifest accordingly
+func runResourceOp(ctx context.Context, fs *FS, r *zip.ReadCloser, op resourceOp, wm watchManifest, progress func(ResourceLoadProgress)) error {
+	switch op.op {
+	case ResourceSkip:
+		progress(ResourceLoadProgress{
+			Operation:   ResourceSkip,
+			Name:        op.name,
+			Total:       uint32(op.size),
+			Transferred: uint32(op.size),
+		})
+		return nil
+	case ResourceRemove:
+		err := fs.RemoveAllContext(ctx, op.path)
 		if err != nil {
 			return err
 		}
 
-		err = dst.Close()
-		if err != nil {
-			return err
+		// The obsolete path may be a directory, so forget everything under it
+		for p := range wm.Files {
+			if p == op.path || strings.HasPrefix(p, op.path+"/") {
+				delete(wm.Files, p)
+			}
 		}
+
+		progress(ResourceLoadProgress{
+			Operation: ResourceRemove,
+			Name:      op.displayName(),
+		})
+		return nil
+	}
+
+	// Forget the old checksum first, so that a partially
+	// uploaded file is never recorded as unchanged
+	delete(wm.Files, op.path)
+
+	src, err := r.Open(op.name)
+	if err != nil {
+		return err
 	}
 
+	err = fs.MkdirAllContext(ctx, path.Dir(op.path))
+	if err != nil {
+		return errors.Join(err, src.Close())
+	}
+
+	dst, err := fs.CreateContext(ctx, op.path, uint32(op.size))
+	if err != nil {
+		return errors.Join(err, src.Close())
+	}
+
+	dst.ProgressFunc = func(transferred, total uint32) {
+		progress(ResourceLoadProgress{
+			Name:        op.name,
+			Transferred: transferred,
+			Total:       total,
+		})
+	}
+
+	_, err = io.Copy(dst, src)
+	if err != nil {
+		return errors.Join(
+			err,
+			src.Close(),
+			dst.Close(),
+		)
+	}
+
+	err = src.Close()
+	if err != nil {
+		return err
+	}
+
+	err = dst.Close()
+	if err != nil {
+		return err
+	}
+
+	wm.Files[op.path] = watchResource{Size: op.size, SHA256: op.sum}
 	return nil
 }
+
+// fsErrCode returns the code of err if it's a BLE FS error
+func fsErrCode(err error) (int8, bool) {
+	var fsErr fsproto.Error
+	if errors.As(err, &fsErr) {
+		return fsErr.Code, true
+	}
+	return 0, false
+}
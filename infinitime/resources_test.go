@@ -0,0 +1,74 @@
+package infinitime
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPlanResources(t *testing.T) {
+	manifest := resourceManifest{
+		Resources: []resource{
+			{Name: "unchanged.bin", Path: "/fonts/unchanged.bin"},
+			{Name: "changed.bin", Path: "/fonts/changed.bin"},
+			{Name: "resized.bin", Path: "/fonts/resized.bin"},
+			{Name: "unknown.bin", Path: "/fonts/unknown.bin"},
+			{Name: "new.bin", Path: "/images/new.bin"},
+		},
+		Obsolete: []obsoleteResource{
+			{Path: "/fonts/old.bin"},
+			{Path: "/fonts/gone.bin"},
+		},
+	}
+
+	files := map[string]archiveResource{
+		"unchanged.bin": {10, "aa"},
+		"changed.bin":   {10, "bb"},
+		"resized.bin":   {20, "cc"},
+		"unknown.bin":   {10, "dd"},
+		"new.bin":       {10, "ee"},
+	}
+
+	onWatch := map[string]int64{
+		"/fonts/unchanged.bin": 10,
+		"/fonts/changed.bin":   10,
+		"/fonts/resized.bin":   10,
+		"/fonts/unknown.bin":   10,
+		"/fonts/old.bin":       5,
+	}
+
+	wm := watchManifest{Files: map[string]watchResource{
+		"/fonts/unchanged.bin": {10, "aa"},
+		"/fonts/changed.bin":   {10, "00"},
+		"/fonts/resized.bin":   {10, "cc"},
+	}}
+
+	type planned struct {
+		op   ResourceOperation
+		path string
+	}
+	summarize := func(ops []resourceOp) []planned {
+		var out []planned
+		for _, op := range ops {
+			out = append(out, planned{op.op, op.path})
+		}
+		return out
+	}
+
+	got := summarize(planResources(manifest, files, onWatch, wm, false))
+	want := []planned{
+		{ResourceRemove, "/fonts/old.bin"},
+		{ResourceSkip, "/fonts/unchanged.bin"},
+		{ResourceUpload, "/fonts/changed.bin"},
+		{ResourceUpload, "/fonts/resized.bin"},
+		{ResourceUpload, "/fonts/unknown.bin"},
+		{ResourceUpload, "/images/new.bin"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected plan %v, got %v", want, got)
+	}
+
+	got = summarize(planResources(manifest, files, onWatch, wm, true))
+	if got[1] != (planned{ResourceUpload, "/fonts/unchanged.bin"}) {
+		t.Errorf("Expected forced upload of unchanged resource, got %v", got[1])
+	}
+}
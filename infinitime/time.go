@@ -1,58 +1,132 @@
 package infinitime
 
 import (
-	"bytes"
-	"encoding/binary"
+	"context"
+	"math"
 	"time"
+
+	"go.elara.ws/itd/infinitime/wire"
 )
 
 // SetTime sets the current time, and then sets the timezone data,
 // if the local time characteristic is available.
 func (d *Device) SetTime(t time.Time) error {
-	c, err := d.getChar(currentTimeChar)
+	return d.SetTimeContext(context.Background(), t)
+}
+
+// SetTimeContext is like [Device.SetTime], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetTimeContext(ctx context.Context, t time.Time) error {
+	ct := wire.CurrentTime{Time: t, Adjust: wire.AdjustManual}
+	err := d.writeCharContext(ctx, currentTimeChar, ct.Encode())
 	if err != nil {
 		return err
 	}
 
-	buf := &bytes.Buffer{}
-	binary.Write(buf, binary.LittleEndian, uint16(t.Year()))
-	binary.Write(buf, binary.LittleEndian, uint8(t.Month()))
-	binary.Write(buf, binary.LittleEndian, uint8(t.Day()))
-	binary.Write(buf, binary.LittleEndian, uint8(t.Hour()))
-	binary.Write(buf, binary.LittleEndian, uint8(t.Minute()))
-	binary.Write(buf, binary.LittleEndian, uint8(t.Second()))
-	binary.Write(buf, binary.LittleEndian, uint8(t.Weekday()))
-	binary.Write(buf, binary.LittleEndian, uint8((t.Nanosecond()/1000)/1e6*256))
-	binary.Write(buf, binary.LittleEndian, uint8(0b0001))
-
-	_, err = c.WriteWithoutResponse(buf.Bytes())
+	if _, err := d.getCharContext(ctx, localTimeChar); err != nil {
+		return nil
+	}
+
+	return d.writeCharContext(ctx, localTimeChar, localTimeInfo(t).Encode())
+}
+
+// GetTime reads the current time from the watch. The watch only keeps its
+// local wall clock time, so it's interpreted in the host's timezone.
+func (d *Device) GetTime() (time.Time, error) {
+	return d.GetTimeContext(context.Background())
+}
+
+// GetTimeContext is like [Device.GetTime], but it stops waiting for the watch when ctx is done.
+func (d *Device) GetTimeContext(ctx context.Context) (time.Time, error) {
+	ct, err := readCharContext(ctx, d, currentTimeChar, wire.DecodeCurrentTime)
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
 
-	ltc, err := d.getChar(localTimeChar)
+	t := ct.Time
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local), nil
+}
+
+// ClockDrift returns how far the watch's clock is ahead of the host's clock.
+// A negative drift means the watch is behind. The host's time is taken halfway
+// through the read, to account for BLE latency, but the watch may only report
+// whole seconds, so the drift is only accurate to about a second.
+func (d *Device) ClockDrift() (time.Duration, error) {
+	return d.ClockDriftContext(context.Background())
+}
+
+// ClockDriftContext is like [Device.ClockDrift], but it stops waiting for the watch when ctx is done.
+func (d *Device) ClockDriftContext(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	watchTime, err := d.GetTimeContext(ctx)
 	if err != nil {
-		return nil
+		return 0, err
+	}
+	hostTime := start.Add(time.Since(start) / 2)
+	return watchTime.Sub(hostTime), nil
+}
+
+// quarterHour is the unit used by the local time characteristic, in seconds
+const quarterHour = 15 * 60
+
+// localTimeInfo returns the local time information for t. The watch expects
+// the timezone offset to stay the same over DST, with the DST offset holding
+// the difference while DST is in effect. Both are in quarters of an hour.
+func localTimeInfo(t time.Time) wire.LocalTimeInfo {
+	_, offset := t.Zone()
+	dst := dstOffset(t)
+	return wire.LocalTimeInfo{
+		TimeZone:  int8(quarterHours(offset - dst)),
+		DSTOffset: uint8(quarterHours(dst)),
+	}
+}
+
+// dstOffset returns the amount of seconds that DST adds to the offset of t.
+// Go doesn't expose this, so it's calculated as the difference between the
+// offset of t and the offset of the closest period of standard time.
+func dstOffset(t time.Time) int {
+	if !t.IsDST() {
+		return 0
 	}
 
 	_, offset := t.Zone()
-	dst := 0
-
-	// Local time expects two values: the timezone offset and the dst offset, both
-	// expressed in quarters of an hour.
-	// Timezone offset is to be constant over DST, with dst offset holding the offset != 0
-	// when DST is in effect.
-	// As there is no standard way in go to get the actual dst offset, we assume it to be 1h
-	// when DST is in effect
-	if t.IsDST() {
-		dst = 3600
-		offset -= 3600
+	before, after := t, t
+	// DST periods are normally right next to standard time,
+	// but look a bit further in case the rules changed.
+	for range 8 {
+		start, _ := before.ZoneBounds()
+		_, end := after.ZoneBounds()
+		if start.IsZero() && end.IsZero() {
+			break
+		}
+
+		if !start.IsZero() {
+			before = start.Add(-time.Second)
+			if !before.IsDST() {
+				return positiveDelta(offset, before)
+			}
+		}
+
+		if !end.IsZero() {
+			after = end
+			if !after.IsDST() {
+				return positiveDelta(offset, after)
+			}
+		}
 	}
 
-	buf.Reset()
-	binary.Write(buf, binary.LittleEndian, uint8(offset/3600*4))
-	binary.Write(buf, binary.LittleEndian, uint8(dst/3600*4))
+	// No standard time was found, so fall back to the most common DST offset
+	return 3600
+}
+
+// positiveDelta returns the difference between offset and the offset of std.
+// The DST offset can't be negative, so zones that use negative DST, such as
+// Europe/Dublin in the winter, are sent as standard time with their full offset.
+func positiveDelta(offset int, std time.Time) int {
+	_, stdOffset := std.Zone()
+	return max(offset-stdOffset, 0)
+}
 
-	_, err = ltc.WriteWithoutResponse(buf.Bytes())
-	return err
+// quarterHours converts an offset in seconds to the nearest amount of quarter hours
+func quarterHours(secs int) int {
+	return int(math.Round(float64(secs) / quarterHour))
 }
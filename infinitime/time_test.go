@@ -0,0 +1,59 @@
+package infinitime
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"go.elara.ws/itd/infinitime/wire"
+)
+
+func TestLocalTimeInfo(t *testing.T) {
+	tests := []struct {
+		zone string
+		time time.Time
+		want wire.LocalTimeInfo
+	}{
+		{"UTC", time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), wire.LocalTimeInfo{TimeZone: 0}},
+		{"Europe/Berlin", time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), wire.LocalTimeInfo{TimeZone: 4}},
+		{"Europe/Berlin", time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC), wire.LocalTimeInfo{TimeZone: 4, DSTOffset: 4}},
+		{"America/Los_Angeles", time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC), wire.LocalTimeInfo{TimeZone: -32, DSTOffset: 4}},
+		{"Asia/Kathmandu", time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC), wire.LocalTimeInfo{TimeZone: 23}},
+		{"America/St_Johns", time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), wire.LocalTimeInfo{TimeZone: -14}},
+		{"America/St_Johns", time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC), wire.LocalTimeInfo{TimeZone: -14, DSTOffset: 4}},
+		// Lord Howe Island has a 30 minute DST offset
+		{"Australia/Lord_Howe", time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC), wire.LocalTimeInfo{TimeZone: 42}},
+		{"Australia/Lord_Howe", time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), wire.LocalTimeInfo{TimeZone: 42, DSTOffset: 2}},
+	}
+
+	for _, tt := range tests {
+		loc, err := time.LoadLocation(tt.zone)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := localTimeInfo(tt.time.In(loc))
+		if got != tt.want {
+			t.Errorf("%s at %s: got %+v, want %+v", tt.zone, tt.time.Format(time.DateOnly), got, tt.want)
+		}
+	}
+}
+
+func TestLocalTimeInfoTotalOffset(t *testing.T) {
+	// Europe/Dublin uses negative DST in the winter, which can't be sent to the
+	// watch, so only check that the total offset is correct.
+	loc, err := time.LoadLocation("Europe/Dublin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, month := range []time.Month{time.January, time.July} {
+		lt := time.Date(2024, month, 15, 12, 0, 0, 0, loc)
+		_, offset := lt.Zone()
+
+		lti := localTimeInfo(lt)
+		if got := (int(lti.TimeZone) + int(lti.DSTOffset)) * quarterHour; got != offset {
+			t.Errorf("%s: got total offset %d, want %d", month, got, offset)
+		}
+	}
+}
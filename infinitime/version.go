@@ -0,0 +1,206 @@
+package infinitime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidVersion = errors.New("invalid firmware version")
+
+// Version represents an InfiniTime firmware version
+type Version struct {
+	Major, Minor, Patch int
+	// Suffix contains anything after the version number,
+	// such as "-dev" or a commit hash
+	Suffix string
+}
+
+// ParseVersion parses an InfiniTime version string, such as "1.14.0".
+// A "v" prefix and a suffix after the version number are allowed.
+func ParseVersion(s string) (Version, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return r != '.' && (r < '0' || r > '9')
+	})
+	num, suffix := s, ""
+	if end != -1 {
+		num, suffix = s[:end], s[end:]
+	}
+
+	parts := strings.Split(num, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+		}
+		nums[i] = n
+	}
+
+	return Version{nums[0], nums[1], nums[2], suffix}, nil
+}
+
+// String returns the version in major.minor.patch form, followed by the suffix
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d%s", v.Major, v.Minor, v.Patch, v.Suffix)
+}
+
+// Compare returns -1 if v is older than o, 1 if v is newer than o, and 0
+// if they're the same version. The suffix is ignored, so development
+// builds are treated like the release they're based on.
+func (v Version) Compare(o Version) int {
+	switch {
+	case v.Major != o.Major:
+		return cmpInt(v.Major, o.Major)
+	case v.Minor != o.Minor:
+		return cmpInt(v.Minor, o.Minor)
+	default:
+		return cmpInt(v.Patch, o.Patch)
+	}
+}
+
+// AtLeast returns true if v is the same as or newer than o
+func (v Version) AtLeast(o Version) bool {
+	return v.Compare(o) >= 0
+}
+
+// Supports returns true if InfiniTime supports
+// the given feature in this version
+func (v Version) Supports(f Feature) bool {
+	return v.AtLeast(f.Version())
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Feature represents a protocol feature that was added in a certain InfiniTime release
+type Feature uint8
+
+const (
+	// FeatureFS is the BLE filesystem service
+	FeatureFS Feature = iota + 1
+	// FeatureResources is support for external resources stored on the filesystem
+	FeatureResources
+	// FeatureLocalTime is the local time information characteristic
+	FeatureLocalTime
+	// FeatureSimpleWeather is the simple weather service
+	FeatureSimpleWeather
+	// FeatureWeatherSunTimes is sunrise and sunset support in the simple weather service
+	FeatureWeatherSunTimes
+)
+
+// features contains the name of each feature and the release that introduced it
+var features = map[Feature]struct {
+	name    string
+	version Version
+}{
+	FeatureFS:              {"filesystem", Version{Major: 1, Minor: 9}},
+	FeatureResources:       {"external resources", Version{Major: 1, Minor: 11}},
+	FeatureLocalTime:       {"local time", Version{Major: 1, Minor: 14}},
+	FeatureSimpleWeather:   {"simple weather", Version{Major: 1, Minor: 14}},
+	FeatureWeatherSunTimes: {"weather sunrise and sunset", Version{Major: 1, Minor: 15}},
+}
+
+// charFeatures maps characteristics to the features that introduced them
+var charFeatures = map[btChar]Feature{
+	fsVersionChar:   FeatureFS,
+	fsTransferChar:  FeatureFS,
+	localTimeChar:   FeatureLocalTime,
+	weatherDataChar: FeatureSimpleWeather,
+}
+
+// String returns the name of the feature
+func (f Feature) String() string {
+	if info, ok := features[f]; ok {
+		return info.name
+	}
+	return "unknown feature"
+}
+
+// Version returns the first InfiniTime version that supports the feature
+func (f Feature) Version() Version {
+	return features[f].version
+}
+
+// ErrUnsupported is returned when the firmware
+// running on the watch doesn't support a feature
+type ErrUnsupported struct {
+	Feature Feature
+	Version Version
+}
+
+func (e ErrUnsupported) Error() string {
+	return fmt.Sprintf(
+		"%s requires InfiniTime %s or newer, but the watch is running %s",
+		e.Feature, e.Feature.Version(), e.Version,
+	)
+}
+
+// FirmwareVersion returns the parsed version of InfiniTime that the
+// connected device is running. The version is cached until the watch
+// reconnects, since it can only change after a firmware upgrade.
+func (d *Device) FirmwareVersion() (Version, error) {
+	return d.FirmwareVersionContext(context.Background())
+}
+
+// FirmwareVersionContext is like [Device.FirmwareVersion], but it stops waiting for the watch when ctx is done.
+func (d *Device) FirmwareVersionContext(ctx context.Context) (Version, error) {
+	d.versionMtx.Lock()
+	defer d.versionMtx.Unlock()
+
+	if d.version != nil {
+		return *d.version, nil
+	}
+
+	s, err := d.VersionContext(ctx)
+	if err != nil {
+		return Version{}, err
+	}
+
+	v, err := ParseVersion(s)
+	if err != nil {
+		return Version{}, err
+	}
+
+	d.version = &v
+	return v, nil
+}
+
+// Supports returns an [ErrUnsupported] error if the firmware running
+// on the watch doesn't support the given feature. If the version can't
+// be determined, such as when a fork uses its own version scheme, the
+// feature is assumed to be supported.
+func (d *Device) Supports(f Feature) error {
+	v, err := d.FirmwareVersion()
+	if err != nil {
+		return nil
+	}
+
+	if !v.Supports(f) {
+		return ErrUnsupported{Feature: f, Version: v}
+	}
+	return nil
+}
+
+// resetVersion forgets the cached firmware version
+func (d *Device) resetVersion() {
+	d.versionMtx.Lock()
+	defer d.versionMtx.Unlock()
+	d.version = nil
+}
@@ -0,0 +1,68 @@
+package infinitime
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Version
+		wantErr bool
+	}{
+		{in: "1.14.0", want: Version{1, 14, 0, ""}},
+		{in: "v1.11.0", want: Version{1, 11, 0, ""}},
+		{in: "1.9", want: Version{1, 9, 0, ""}},
+		{in: "1.15.0-dev", want: Version{1, 15, 0, "-dev"}},
+		{in: " 1.13.0 ", want: Version{1, 13, 0, ""}},
+		{in: "", wantErr: true},
+		{in: "1", wantErr: true},
+		{in: "1.2.3.4", wantErr: true},
+		{in: "custom", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.in)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidVersion) {
+				t.Errorf("ParseVersion(%q): expected ErrInvalidVersion, got %v", tt.in, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ParseVersion(%q): unexpected error: %s", tt.in, err)
+		} else if got != tt.want {
+			t.Errorf("ParseVersion(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestVersionSupports(t *testing.T) {
+	tests := []struct {
+		version string
+		feature Feature
+		want    bool
+	}{
+		{"1.8.0", FeatureFS, false},
+		{"1.9.0", FeatureFS, true},
+		{"1.10.0", FeatureResources, false},
+		{"1.11.0", FeatureResources, true},
+		{"1.13.0", FeatureSimpleWeather, false},
+		{"1.14.0-dev", FeatureSimpleWeather, true},
+		{"1.14.0", FeatureWeatherSunTimes, false},
+		{"2.0.0", FeatureWeatherSunTimes, true},
+	}
+
+	for _, tt := range tests {
+		v, err := ParseVersion(tt.version)
+		if err != nil {
+			t.Fatalf("ParseVersion(%q): %s", tt.version, err)
+		}
+
+		if got := v.Supports(tt.feature); got != tt.want {
+			t.Errorf("%s supports %s = %t, want %t", tt.version, tt.feature, got, tt.want)
+		}
+	}
+}
@@ -1,9 +1,7 @@
 package infinitime
 
 import (
-	"bytes"
 	"context"
-	"encoding/binary"
 	"sync"
 
 	"tinygo.org/x/bluetooth"
@@ -14,6 +12,7 @@ type notifier interface {
 }
 
 type watcher[T any] struct {
+	decode     func([]byte) (T, error)
 	mu         sync.Mutex
 	nextFuncID int
 	callbacks  map[int]func(T, error)
@@ -30,8 +29,7 @@ func (w *watcher[T]) addCallback(fn func(T, error)) int {
 }
 
 func (w *watcher[T]) notify(b []byte) {
-	var val T
-	err := binary.Read(bytes.NewReader(b), binary.LittleEndian, &val)
+	val, err := w.decode(b)
 	w.mu.Lock()
 	for _, fn := range w.callbacks {
 		go fn(val, err)
@@ -54,7 +52,8 @@ func (w *watcher[T]) cancelFn(d *Device, ch btChar, id int) func() {
 	}
 }
 
-func watchChar[T any](ctx context.Context, d *Device, ch btChar, fn func(T, error)) error {
+// watchChar calls fn with the decoded value of ch whenever it changes
+func watchChar[T any](ctx context.Context, d *Device, ch btChar, decode func([]byte) (T, error), fn func(T, error)) error {
 	d.notifierMtx.Lock()
 	defer d.notifierMtx.Unlock()
 
@@ -73,7 +72,7 @@ func watchChar[T any](ctx context.Context, d *Device, ch btChar, fn func(T, erro
 			return err
 		}
 
-		w := &watcher[T]{callbacks: map[int]func(T, error){}}
+		w := &watcher[T]{decode: decode, callbacks: map[int]func(T, error){}}
 		err = c.EnableNotifications(w.notify)
 		if err != nil {
 			return err
@@ -87,22 +86,54 @@ func watchChar[T any](ctx context.Context, d *Device, ch btChar, fn func(T, erro
 	}
 }
 
-func watchCharOnce[T any](d *Device, ch btChar, fn func(T)) error {
-	ctx, cancel := context.WithCancel(context.Background())
+// watchCharOnce waits for the next value of ch and calls fn with it. It
+// returns ctx's error if ctx is done before a value is received.
+func watchCharOnce[T any](ctx context.Context, d *Device, ch btChar, decode func([]byte) (T, error), fn func(T)) error {
+	wait, err := watchCharNext(ctx, d, ch, decode)
+	if err != nil {
+		return err
+	}
+	return wait(fn)
+}
 
-	var watchErr error
-	err := watchChar(ctx, d, ch, func(val T, err error) {
-		defer cancel()
-		if err != nil {
-			watchErr = err
-			return
+// watchCharNext starts watching ch, and returns a function that waits for
+// the next value and calls fn with it. The wait function returns ctx's error
+// if ctx is done before a value is received. ch stops being watched once
+// the wait function returns or ctx is done.
+func watchCharNext[T any](ctx context.Context, d *Device, ch btChar, decode func([]byte) (T, error)) (func(fn func(T)) error, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	type result struct {
+		val T
+		err error
+	}
+
+	resCh := make(chan result, 1)
+	err := watchChar(ctx, d, ch, decode, func(val T, err error) {
+		// Only the first value is used, so drop the others
+		// instead of blocking the callback.
+		select {
+		case resCh <- result{val, err}:
+		default:
 		}
-		fn(val)
 	})
 	if err != nil {
-		return err
+		cancel()
+		return nil, err
 	}
 
-	<-ctx.Done()
-	return watchErr
+	return func(fn func(T)) error {
+		defer cancel()
+
+		select {
+		case res := <-resCh:
+			if res.err != nil {
+				return res.err
+			}
+			fn(res.val)
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, nil
 }
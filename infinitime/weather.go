@@ -1,124 +1,70 @@
 package infinitime
 
 import (
-	"bytes"
-	"encoding/binary"
-	"errors"
-	"time"
-)
-
-const (
-	weatherVersion = 0
+	"context"
 
-	currentWeatherType  = 0
-	forecastWeatherType = 1
+	"go.elara.ws/itd/infinitime/wire"
 )
 
-type WeatherIcon uint8
+type (
+	WeatherVersion = wire.WeatherVersion
+	WeatherIcon    = wire.WeatherIcon
+	CurrentWeather = wire.CurrentWeather
+	Forecast       = wire.Forecast
+	ForecastDay    = wire.ForecastDay
+)
 
 const (
-	WeatherIconClear WeatherIcon = iota
-	WeatherIconFewClouds
-	WeatherIconClouds
-	WeatherIconHeavyClouds
-	WeatherIconCloudsWithRain
-	WeatherIconRain
-	WeatherIconThunderstorm
-	WeatherIconSnow
-	WeatherIconMist
+	WeatherVersion0 = wire.WeatherVersion0
+	WeatherVersion1 = wire.WeatherVersion1
 )
 
-// CurrentWeather represents the current weather
-type CurrentWeather struct {
-	Time        time.Time
-	CurrentTemp float32
-	MinTemp     float32
-	MaxTemp     float32
-	Location    string
-	Icon        WeatherIcon
-}
-
-// Bytes returns the [CurrentWeather] struct encoded using the InfiniTime
-// weather wire protocol.
-func (cw CurrentWeather) Bytes() []byte {
-	buf := &bytes.Buffer{}
-
-	buf.WriteByte(currentWeatherType)
-	buf.WriteByte(weatherVersion)
-
-	_, offset := cw.Time.Zone()
-	binary.Write(buf, binary.LittleEndian, cw.Time.Unix()+int64(offset))
-
-	binary.Write(buf, binary.LittleEndian, int16(cw.CurrentTemp*100))
-	binary.Write(buf, binary.LittleEndian, int16(cw.MinTemp*100))
-	binary.Write(buf, binary.LittleEndian, int16(cw.MaxTemp*100))
-
-	location := make([]byte, 32)
-	copy(location, cw.Location)
-	buf.Write(location)
-
-	buf.WriteByte(byte(cw.Icon))
-
-	return buf.Bytes()
-}
-
-// Forecast represents a weather forecast
-type Forecast struct {
-	Time time.Time
-	Days []ForecastDay
-}
+const (
+	WeatherIconClear          = wire.WeatherIconClear
+	WeatherIconFewClouds      = wire.WeatherIconFewClouds
+	WeatherIconClouds         = wire.WeatherIconClouds
+	WeatherIconHeavyClouds    = wire.WeatherIconHeavyClouds
+	WeatherIconCloudsWithRain = wire.WeatherIconCloudsWithRain
+	WeatherIconRain           = wire.WeatherIconRain
+	WeatherIconThunderstorm   = wire.WeatherIconThunderstorm
+	WeatherIconSnow           = wire.WeatherIconSnow
+	WeatherIconMist           = wire.WeatherIconMist
+)
 
-// ForecastDay represents a forecast for a single day
-type ForecastDay struct {
-	MinTemp int16
-	MaxTemp int16
-	Icon    WeatherIcon
+// SetCurrentWeather updates the current weather data on the PineTime.
+// Sunrise and sunset times are only sent if they're set and the
+// watch's firmware supports them.
+func (d *Device) SetCurrentWeather(cw CurrentWeather) error {
+	return d.SetCurrentWeatherContext(context.Background(), cw)
 }
 
-// Bytes returns the [Forecast] struct encoded using the InfiniTime
-// weather wire protocol.
-func (f Forecast) Bytes() []byte {
-	buf := &bytes.Buffer{}
-
-	buf.WriteByte(forecastWeatherType)
-	buf.WriteByte(weatherVersion)
-
-	_, offset := f.Time.Zone()
-	binary.Write(buf, binary.LittleEndian, f.Time.Unix()+int64(offset))
-
-	buf.WriteByte(uint8(len(f.Days)))
-
-	for _, day := range f.Days {
-		binary.Write(buf, binary.LittleEndian, day.MinTemp*100)
-		binary.Write(buf, binary.LittleEndian, day.MaxTemp*100)
-		buf.WriteByte(byte(day.Icon))
+// SetCurrentWeatherContext is like [Device.SetCurrentWeather], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetCurrentWeatherContext(ctx context.Context, cw CurrentWeather) error {
+	v := WeatherVersion0
+	hasSunTimes := !cw.Sunrise.IsZero() || !cw.Sunset.IsZero()
+	if hasSunTimes && d.Supports(FeatureWeatherSunTimes) == nil {
+		v = WeatherVersion1
 	}
 
-	return buf.Bytes()
-}
-
-// SetCurrentWeather updates the current weather data on the PineTime
-func (d *Device) SetCurrentWeather(cw CurrentWeather) error {
-	c, err := d.getChar(weatherDataChar)
+	b, err := cw.Encode(v)
 	if err != nil {
 		return err
 	}
 
-	_, err = c.WriteWithoutResponse(cw.Bytes())
-	return err
+	return d.writeCharContext(ctx, weatherDataChar, b)
 }
 
 // SetForecast sets future forecast data on the PineTime
 func (d *Device) SetForecast(f Forecast) error {
-	c, err := d.getChar(weatherDataChar)
+	return d.SetForecastContext(context.Background(), f)
+}
+
+// SetForecastContext is like [Device.SetForecast], but it stops waiting for the watch when ctx is done.
+func (d *Device) SetForecastContext(ctx context.Context, f Forecast) error {
+	b, err := f.Encode(WeatherVersion0)
 	if err != nil {
 		return err
 	}
 
-	if len(f.Days) > 5 {
-		return errors.New("amount of forecast days exceeds maximum of 5")
-	}
-
-	_, err = c.WriteWithoutResponse(f.Bytes())
-	return err
+	return d.writeCharContext(ctx, weatherDataChar, b)
 }
@@ -0,0 +1,84 @@
+package wire
+
+import "strings"
+
+// AlertCategory is the category of an alert sent using the Alert Notification Service
+type AlertCategory uint8
+
+const (
+	AlertCategorySimple AlertCategory = 0
+	AlertCategoryCall   AlertCategory = 3
+)
+
+// alertHeaderLen is the length of the alert header. InfiniTime expects
+// a reserved byte after the standard category and count fields.
+const alertHeaderLen = 3
+
+// Alert is the payload of the New Alert characteristic
+type Alert struct {
+	Category AlertCategory
+	Count    uint8
+	// Title is the title of a regular notification,
+	// or the caller for a call notification.
+	Title string
+	// Body is the body of a regular notification. Call
+	// notifications don't have a body.
+	Body string
+}
+
+// Encode returns the payload for the New Alert characteristic
+func (a Alert) Encode() []byte {
+	b := make([]byte, alertHeaderLen, alertHeaderLen+len(a.Title)+len(a.Body)+1)
+	b[0] = byte(a.Category)
+	b[1] = a.Count
+	b = append(b, a.Title...)
+	if a.Category != AlertCategoryCall {
+		b = append(b, 0)
+		b = append(b, a.Body...)
+	}
+	return b
+}
+
+// DecodeAlert decodes a New Alert characteristic payload
+func DecodeAlert(b []byte) (Alert, error) {
+	if len(b) < alertHeaderLen {
+		return Alert{}, shortError("alert", len(b), alertHeaderLen)
+	}
+
+	a := Alert{
+		Category: AlertCategory(b[0]),
+		Count:    b[1],
+	}
+
+	content := string(b[alertHeaderLen:])
+	if a.Category == AlertCategoryCall {
+		a.Title = content
+	} else {
+		a.Title, a.Body, _ = strings.Cut(content, "\x00")
+	}
+
+	return a, nil
+}
+
+// CallStatus is the payload of the Notification Event characteristic,
+// which the watch sends when a button on a call notification is pressed.
+type CallStatus uint8
+
+const (
+	CallStatusDeclined CallStatus = iota
+	CallStatusAccepted
+	CallStatusMuted
+)
+
+// Encode returns the payload for the Notification Event characteristic
+func (cs CallStatus) Encode() []byte {
+	return []byte{byte(cs)}
+}
+
+// DecodeCallStatus decodes a Notification Event characteristic payload
+func DecodeCallStatus(b []byte) (CallStatus, error) {
+	if len(b) != 1 {
+		return 0, lengthError("call status", len(b), 1)
+	}
+	return CallStatus(b[0]), nil
+}
@@ -0,0 +1,452 @@
+package wire
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var ErrFSOpcode = errors.New("unknown filesystem opcode")
+
+type FSReqOpcode uint8
+
+const (
+	ReadFileHeaderOpcode  FSReqOpcode = 0x10
+	ReadFileOpcode        FSReqOpcode = 0x12
+	WriteFileHeaderOpcode FSReqOpcode = 0x20
+	WriteFileOpcode       FSReqOpcode = 0x22
+	DeleteFileOpcode      FSReqOpcode = 0x30
+	MakeDirectoryOpcode   FSReqOpcode = 0x40
+	ListDirectoryOpcode   FSReqOpcode = 0x50
+	MoveFileOpcode        FSReqOpcode = 0x60
+)
+
+type FSRespOpcode uint8
+
+const (
+	ReadFileResp      FSRespOpcode = 0x11
+	WriteFileResp     FSRespOpcode = 0x21
+	DeleteFileResp    FSRespOpcode = 0x31
+	MakeDirectoryResp FSRespOpcode = 0x41
+	ListDirectoryResp FSRespOpcode = 0x51
+	MoveFileResp      FSRespOpcode = 0x61
+)
+
+// FSRequest is a request sent to the BLE filesystem
+type FSRequest interface {
+	Opcode() FSReqOpcode
+	// Encode returns the request, including its opcode
+	Encode() []byte
+}
+
+// FSResponse is a response sent by the BLE filesystem
+type FSResponse interface {
+	Opcode() FSRespOpcode
+	// Encode returns the response, including its opcode
+	Encode() []byte
+	// StatusCode returns the status of the response, where 0x01 means success
+	// and anything else is an error code.
+	StatusCode() int8
+}
+
+// DecodeFSRequest decodes a request sent to the BLE filesystem.
+// The returned value is one of the request structs in this package.
+func DecodeFSRequest(b []byte) (FSRequest, error) {
+	if len(b) == 0 {
+		return nil, shortError("filesystem request", 0, 1)
+	}
+
+	var (
+		req FSRequest
+		err error
+	)
+	switch op := FSReqOpcode(b[0]); op {
+	case ReadFileHeaderOpcode:
+		req, err = decodePacket[ReadFileHeaderRequest](b[1:], "filesystem request")
+	case ReadFileOpcode:
+		req, err = decodePacket[ReadFileRequest](b[1:], "filesystem request")
+	case WriteFileHeaderOpcode:
+		req, err = decodePacket[WriteFileHeaderRequest](b[1:], "filesystem request")
+	case WriteFileOpcode:
+		req, err = decodePacket[WriteFileRequest](b[1:], "filesystem request")
+	case DeleteFileOpcode:
+		req, err = decodePacket[DeleteFileRequest](b[1:], "filesystem request")
+	case MakeDirectoryOpcode:
+		req, err = decodePacket[MkdirRequest](b[1:], "filesystem request")
+	case ListDirectoryOpcode:
+		req, err = decodePacket[ListDirRequest](b[1:], "filesystem request")
+	case MoveFileOpcode:
+		req, err = decodePacket[MoveFileRequest](b[1:], "filesystem request")
+	default:
+		return nil, fmt.Errorf("%w: %#x", ErrFSOpcode, op)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// DecodeFSResponse decodes a response sent by the BLE filesystem.
+// The returned value is one of the response structs in this package.
+// The response's status isn't checked.
+func DecodeFSResponse(b []byte) (FSResponse, error) {
+	if len(b) == 0 {
+		return nil, shortError("filesystem response", 0, 1)
+	}
+
+	var (
+		resp FSResponse
+		err  error
+	)
+	switch op := FSRespOpcode(b[0]); op {
+	case ReadFileResp:
+		resp, err = decodePacket[ReadFileResponse](b[1:], "filesystem response")
+	case WriteFileResp:
+		resp, err = decodePacket[WriteFileResponse](b[1:], "filesystem response")
+	case DeleteFileResp:
+		resp, err = decodePacket[DeleteFileResponse](b[1:], "filesystem response")
+	case MakeDirectoryResp:
+		resp, err = decodePacket[MkdirResponse](b[1:], "filesystem response")
+	case ListDirectoryResp:
+		resp, err = decodePacket[ListDirResponse](b[1:], "filesystem response")
+	case MoveFileResp:
+		resp, err = decodePacket[MoveFileResponse](b[1:], "filesystem response")
+	default:
+		return nil, fmt.Errorf("%w: %#x", ErrFSOpcode, op)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// decodePacket decodes a filesystem packet without its opcode
+func decodePacket[T any, P interface {
+	*T
+	decode(r *reader)
+}](b []byte, name string) (T, error) {
+	var pkt T
+	r := &reader{b: b, name: name}
+	P(&pkt).decode(r)
+	return pkt, r.err
+}
+
+type ReadFileHeaderRequest struct {
+	Offset  uint32
+	ReadLen uint32
+	Path    string
+}
+
+func (ReadFileHeaderRequest) Opcode() FSReqOpcode { return ReadFileHeaderOpcode }
+
+func (req ReadFileHeaderRequest) Encode() []byte {
+	b := []byte{byte(ReadFileHeaderOpcode), 0}
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(req.Path)))
+	b = binary.LittleEndian.AppendUint32(b, req.Offset)
+	b = binary.LittleEndian.AppendUint32(b, req.ReadLen)
+	return append(b, req.Path...)
+}
+
+func (req *ReadFileHeaderRequest) decode(r *reader) {
+	r.skip(1)
+	pathLen := r.uint16()
+	req.Offset = r.uint32()
+	req.ReadLen = r.uint32()
+	req.Path = string(r.next(int(pathLen)))
+}
+
+type ReadFileRequest struct {
+	Status  uint8
+	Offset  uint32
+	ReadLen uint32
+}
+
+func (ReadFileRequest) Opcode() FSReqOpcode { return ReadFileOpcode }
+
+func (req ReadFileRequest) Encode() []byte {
+	b := []byte{byte(ReadFileOpcode), req.Status, 0, 0}
+	b = binary.LittleEndian.AppendUint32(b, req.Offset)
+	return binary.LittleEndian.AppendUint32(b, req.ReadLen)
+}
+
+func (req *ReadFileRequest) decode(r *reader) {
+	req.Status = r.uint8()
+	r.skip(2)
+	req.Offset = r.uint32()
+	req.ReadLen = r.uint32()
+}
+
+type ReadFileResponse struct {
+	Status   int8
+	Offset   uint32
+	FileSize uint32
+	Data     []byte
+}
+
+func (ReadFileResponse) Opcode() FSRespOpcode  { return ReadFileResp }
+func (resp ReadFileResponse) StatusCode() int8 { return resp.Status }
+
+func (resp ReadFileResponse) Encode() []byte {
+	b := []byte{byte(ReadFileResp), byte(resp.Status), 0, 0}
+	b = binary.LittleEndian.AppendUint32(b, resp.Offset)
+	b = binary.LittleEndian.AppendUint32(b, resp.FileSize)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(resp.Data)))
+	return append(b, resp.Data...)
+}
+
+func (resp *ReadFileResponse) decode(r *reader) {
+	resp.Status = r.int8()
+	r.skip(2)
+	resp.Offset = r.uint32()
+	resp.FileSize = r.uint32()
+	chunkLen := r.uint32()
+	resp.Data = r.bytes(int(min(chunkLen, math.MaxInt32)))
+}
+
+type WriteFileHeaderRequest struct {
+	Offset   uint32
+	ModTime  uint64
+	FileSize uint32
+	Path     string
+}
+
+func (WriteFileHeaderRequest) Opcode() FSReqOpcode { return WriteFileHeaderOpcode }
+
+func (req WriteFileHeaderRequest) Encode() []byte {
+	b := []byte{byte(WriteFileHeaderOpcode), 0}
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(req.Path)))
+	b = binary.LittleEndian.AppendUint32(b, req.Offset)
+	b = binary.LittleEndian.AppendUint64(b, req.ModTime)
+	b = binary.LittleEndian.AppendUint32(b, req.FileSize)
+	return append(b, req.Path...)
+}
+
+func (req *WriteFileHeaderRequest) decode(r *reader) {
+	r.skip(1)
+	pathLen := r.uint16()
+	req.Offset = r.uint32()
+	req.ModTime = r.uint64()
+	req.FileSize = r.uint32()
+	req.Path = string(r.next(int(pathLen)))
+}
+
+type WriteFileRequest struct {
+	Status uint8
+	Offset uint32
+	Data   []byte
+}
+
+func (WriteFileRequest) Opcode() FSReqOpcode { return WriteFileOpcode }
+
+func (req WriteFileRequest) Encode() []byte {
+	b := []byte{byte(WriteFileOpcode), req.Status, 0, 0}
+	b = binary.LittleEndian.AppendUint32(b, req.Offset)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(req.Data)))
+	return append(b, req.Data...)
+}
+
+func (req *WriteFileRequest) decode(r *reader) {
+	req.Status = r.uint8()
+	r.skip(2)
+	req.Offset = r.uint32()
+	chunkLen := r.uint32()
+	req.Data = r.bytes(int(min(chunkLen, math.MaxInt32)))
+}
+
+type WriteFileResponse struct {
+	Status    int8
+	Offset    uint32
+	ModTime   uint64
+	FreeSpace uint32
+}
+
+func (WriteFileResponse) Opcode() FSRespOpcode  { return WriteFileResp }
+func (resp WriteFileResponse) StatusCode() int8 { return resp.Status }
+
+func (resp WriteFileResponse) Encode() []byte {
+	b := []byte{byte(WriteFileResp), byte(resp.Status), 0, 0}
+	b = binary.LittleEndian.AppendUint32(b, resp.Offset)
+	b = binary.LittleEndian.AppendUint64(b, resp.ModTime)
+	return binary.LittleEndian.AppendUint32(b, resp.FreeSpace)
+}
+
+func (resp *WriteFileResponse) decode(r *reader) {
+	resp.Status = r.int8()
+	r.skip(2)
+	resp.Offset = r.uint32()
+	resp.ModTime = r.uint64()
+	resp.FreeSpace = r.uint32()
+}
+
+type DeleteFileRequest struct {
+	Path string
+}
+
+func (DeleteFileRequest) Opcode() FSReqOpcode { return DeleteFileOpcode }
+
+func (req DeleteFileRequest) Encode() []byte {
+	b := []byte{byte(DeleteFileOpcode), 0}
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(req.Path)))
+	return append(b, req.Path...)
+}
+
+func (req *DeleteFileRequest) decode(r *reader) {
+	r.skip(1)
+	pathLen := r.uint16()
+	req.Path = string(r.next(int(pathLen)))
+}
+
+type DeleteFileResponse struct {
+	Status int8
+}
+
+func (DeleteFileResponse) Opcode() FSRespOpcode  { return DeleteFileResp }
+func (resp DeleteFileResponse) StatusCode() int8 { return resp.Status }
+
+func (resp DeleteFileResponse) Encode() []byte {
+	return []byte{byte(DeleteFileResp), byte(resp.Status)}
+}
+
+func (resp *DeleteFileResponse) decode(r *reader) {
+	resp.Status = r.int8()
+}
+
+type MkdirRequest struct {
+	Timestamp uint64
+	Path      string
+}
+
+func (MkdirRequest) Opcode() FSReqOpcode { return MakeDirectoryOpcode }
+
+func (req MkdirRequest) Encode() []byte {
+	b := []byte{byte(MakeDirectoryOpcode), 0}
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(req.Path)))
+	b = append(b, 0, 0, 0, 0)
+	b = binary.LittleEndian.AppendUint64(b, req.Timestamp)
+	return append(b, req.Path...)
+}
+
+func (req *MkdirRequest) decode(r *reader) {
+	r.skip(1)
+	pathLen := r.uint16()
+	r.skip(4)
+	req.Timestamp = r.uint64()
+	req.Path = string(r.next(int(pathLen)))
+}
+
+type MkdirResponse struct {
+	Status  int8
+	ModTime uint64
+}
+
+func (MkdirResponse) Opcode() FSRespOpcode  { return MakeDirectoryResp }
+func (resp MkdirResponse) StatusCode() int8 { return resp.Status }
+
+func (resp MkdirResponse) Encode() []byte {
+	b := []byte{byte(MakeDirectoryResp), byte(resp.Status), 0, 0, 0, 0, 0, 0}
+	return binary.LittleEndian.AppendUint64(b, resp.ModTime)
+}
+
+func (resp *MkdirResponse) decode(r *reader) {
+	resp.Status = r.int8()
+	r.skip(6)
+	resp.ModTime = r.uint64()
+}
+
+type ListDirRequest struct {
+	Path string
+}
+
+func (ListDirRequest) Opcode() FSReqOpcode { return ListDirectoryOpcode }
+
+func (req ListDirRequest) Encode() []byte {
+	b := []byte{byte(ListDirectoryOpcode), 0}
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(req.Path)))
+	return append(b, req.Path...)
+}
+
+func (req *ListDirRequest) decode(r *reader) {
+	r.skip(1)
+	pathLen := r.uint16()
+	req.Path = string(r.next(int(pathLen)))
+}
+
+// ListDirResponse describes a single directory entry. The watch sends one
+// response per entry, followed by a response where EntryNum == TotalEntries.
+type ListDirResponse struct {
+	Status       int8
+	EntryNum     uint32
+	TotalEntries uint32
+	Flags        uint32
+	ModTime      uint64
+	FileSize     uint32
+	Path         string
+}
+
+func (ListDirResponse) Opcode() FSRespOpcode  { return ListDirectoryResp }
+func (resp ListDirResponse) StatusCode() int8 { return resp.Status }
+
+func (resp ListDirResponse) Encode() []byte {
+	b := []byte{byte(ListDirectoryResp), byte(resp.Status)}
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(resp.Path)))
+	b = binary.LittleEndian.AppendUint32(b, resp.EntryNum)
+	b = binary.LittleEndian.AppendUint32(b, resp.TotalEntries)
+	b = binary.LittleEndian.AppendUint32(b, resp.Flags)
+	b = binary.LittleEndian.AppendUint64(b, resp.ModTime)
+	b = binary.LittleEndian.AppendUint32(b, resp.FileSize)
+	return append(b, resp.Path...)
+}
+
+func (resp *ListDirResponse) decode(r *reader) {
+	resp.Status = r.int8()
+	pathLen := r.uint16()
+	resp.EntryNum = r.uint32()
+	resp.TotalEntries = r.uint32()
+	resp.Flags = r.uint32()
+	resp.ModTime = r.uint64()
+	resp.FileSize = r.uint32()
+	resp.Path = string(r.next(int(pathLen)))
+}
+
+type MoveFileRequest struct {
+	OldPath string
+	NewPath string
+}
+
+func (MoveFileRequest) Opcode() FSReqOpcode { return MoveFileOpcode }
+
+func (req MoveFileRequest) Encode() []byte {
+	b := []byte{byte(MoveFileOpcode), 0}
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(req.OldPath)))
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(req.NewPath)))
+	b = append(b, req.OldPath...)
+	b = append(b, 0)
+	return append(b, req.NewPath...)
+}
+
+func (req *MoveFileRequest) decode(r *reader) {
+	r.skip(1)
+	oldPathLen := r.uint16()
+	newPathLen := r.uint16()
+	req.OldPath = string(r.next(int(oldPathLen)))
+	r.skip(1)
+	req.NewPath = string(r.next(int(newPathLen)))
+}
+
+type MoveFileResponse struct {
+	Status int8
+}
+
+func (MoveFileResponse) Opcode() FSRespOpcode  { return MoveFileResp }
+func (resp MoveFileResponse) StatusCode() int8 { return resp.Status }
+
+func (resp MoveFileResponse) Encode() []byte {
+	return []byte{byte(MoveFileResp), byte(resp.Status)}
+}
+
+func (resp *MoveFileResponse) decode(r *reader) {
+	resp.Status = r.int8()
+}
@@ -0,0 +1,137 @@
+package wire
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFSRequests(t *testing.T) {
+	tests := []struct {
+		req  FSRequest
+		want string
+	}{
+		{
+			req:  ReadFileHeaderRequest{Offset: 5, ReadLen: 200, Path: "/ab"},
+			want: "10000300" + "05000000" + "c8000000" + "2f6162",
+		},
+		{
+			req:  ReadFileRequest{Status: 1, Offset: 5, ReadLen: 200},
+			want: "12010000" + "05000000" + "c8000000",
+		},
+		{
+			req:  WriteFileHeaderRequest{Offset: 5, ModTime: 9, FileSize: 77, Path: "/ab"},
+			want: "20000300" + "05000000" + "0900000000000000" + "4d000000" + "2f6162",
+		},
+		{
+			req:  WriteFileRequest{Status: 1, Offset: 5, Data: []byte{1, 2}},
+			want: "22010000" + "05000000" + "02000000" + "0102",
+		},
+		{
+			req:  DeleteFileRequest{Path: "/ab"},
+			want: "30000300" + "2f6162",
+		},
+		{
+			req:  MkdirRequest{Timestamp: 4, Path: "/ab"},
+			want: "40000300" + "00000000" + "0400000000000000" + "2f6162",
+		},
+		{
+			req:  ListDirRequest{Path: "/ab"},
+			want: "50000300" + "2f6162",
+		},
+		{
+			req:  MoveFileRequest{OldPath: "/ab", NewPath: "/c"},
+			want: "600003000200" + "2f6162" + "00" + "2f63",
+		},
+	}
+
+	for _, tt := range tests {
+		b := tt.req.Encode()
+		if got := hex.EncodeToString(b); got != tt.want {
+			t.Errorf("%T: got %s, want %s", tt.req, got, tt.want)
+		}
+
+		decoded, err := DecodeFSRequest(b)
+		if err != nil {
+			t.Errorf("%T: %v", tt.req, err)
+			continue
+		}
+		if !reflect.DeepEqual(decoded, tt.req) {
+			t.Errorf("%T round trip: got %+v, want %+v", tt.req, decoded, tt.req)
+		}
+	}
+}
+
+func TestFSResponses(t *testing.T) {
+	tests := []struct {
+		resp FSResponse
+		want string
+	}{
+		{
+			resp: ReadFileResponse{Status: 1, Offset: 3, FileSize: 10, Data: []byte{5, 6}},
+			want: "11010000" + "03000000" + "0a000000" + "02000000" + "0506",
+		},
+		{
+			resp: WriteFileResponse{Status: 1, Offset: 3, ModTime: 8, FreeSpace: 100},
+			want: "21010000" + "03000000" + "0800000000000000" + "64000000",
+		},
+		{
+			resp: DeleteFileResponse{Status: -2},
+			want: "31fe",
+		},
+		{
+			resp: MkdirResponse{Status: 1, ModTime: 8},
+			want: "4101000000000000" + "0800000000000000",
+		},
+		{
+			resp: ListDirResponse{Status: 1, EntryNum: 1, TotalEntries: 3, Flags: 1, ModTime: 2, FileSize: 4, Path: "abc"},
+			want: "51010300" + "01000000" + "03000000" + "01000000" + "0200000000000000" + "04000000" + "616263",
+		},
+		{
+			resp: MoveFileResponse{Status: 1},
+			want: "6101",
+		},
+	}
+
+	for _, tt := range tests {
+		b := tt.resp.Encode()
+		if got := hex.EncodeToString(b); got != tt.want {
+			t.Errorf("%T: got %s, want %s", tt.resp, got, tt.want)
+		}
+
+		decoded, err := DecodeFSResponse(b)
+		if err != nil {
+			t.Errorf("%T: %v", tt.resp, err)
+			continue
+		}
+		if !reflect.DeepEqual(decoded, tt.resp) {
+			t.Errorf("%T round trip: got %+v, want %+v", tt.resp, decoded, tt.resp)
+		}
+	}
+}
+
+func TestDecodeFSErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		decode func([]byte) (any, error)
+		in     string
+		want   error
+	}{
+		{"empty request", wrap(DecodeFSRequest), "", ErrLength},
+		{"empty response", wrap(DecodeFSResponse), "", ErrLength},
+		{"unknown request", wrap(DecodeFSRequest), "11", ErrFSOpcode},
+		{"unknown response", wrap(DecodeFSResponse), "10", ErrFSOpcode},
+		{"truncated header", wrap(DecodeFSRequest), "100003", ErrLength},
+		{"truncated path", wrap(DecodeFSRequest), "30000300" + "2f61", ErrLength},
+		{"truncated data", wrap(DecodeFSResponse), "11010000" + "03000000" + "0a000000" + "ffffffff" + "0506", ErrLength},
+	}
+
+	for _, tt := range tests {
+		in, _ := hex.DecodeString(tt.in)
+		_, err := tt.decode(in)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
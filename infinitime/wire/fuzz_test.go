@@ -0,0 +1,108 @@
+package wire
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fuzzRoundTrip checks that decode never panics, and that anything it
+// accepts decodes to the same value after being encoded again.
+func fuzzRoundTrip[T any](f *testing.F, decode func([]byte) (T, error), encode func(T) []byte, seeds ...[]byte) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		v, err := decode(b)
+		if err != nil {
+			return
+		}
+
+		again, err := decode(encode(v))
+		if err != nil {
+			t.Fatalf("decoding re-encoded %+v: %v", v, err)
+		}
+		if !reflect.DeepEqual(v, again) {
+			t.Fatalf("round trip mismatch:\ngot  %+v\nwant %+v", again, v)
+		}
+	})
+}
+
+func FuzzCurrentTime(f *testing.F) {
+	decode := func(b []byte) (CurrentTime, error) {
+		ct, err := DecodeCurrentTime(b)
+		// Out of range dates are normalized by time.Date, which can
+		// push the year past what fits in the payload.
+		if err == nil && (ct.Time.Year() < 0 || ct.Time.Year() > math.MaxUint16) {
+			return ct, ErrLength
+		}
+		return ct, err
+	}
+	fuzzRoundTrip(f, decode, CurrentTime.Encode,
+		CurrentTime{Time: time.Date(2024, 3, 3, 14, 5, 9, 0, time.UTC)}.Encode(),
+	)
+}
+
+func FuzzLocalTimeInfo(f *testing.F) {
+	fuzzRoundTrip(f, DecodeLocalTimeInfo, LocalTimeInfo.Encode, []byte{0xec, 4})
+}
+
+func FuzzAlert(f *testing.F) {
+	fuzzRoundTrip(f, DecodeAlert, Alert.Encode,
+		Alert{Title: "Hi", Body: "there"}.Encode(),
+		Alert{Category: AlertCategoryCall, Title: "555"}.Encode(),
+	)
+}
+
+func FuzzHeartRate(f *testing.F) {
+	fuzzRoundTrip(f, DecodeHeartRate, EncodeHeartRate, []byte{0, 72}, []byte{1, 0x2c, 1})
+}
+
+func FuzzMotion(f *testing.F) {
+	fuzzRoundTrip(f, DecodeMotion, MotionValues.Encode, MotionValues{1, -1, 256}.Encode())
+}
+
+func FuzzCurrentWeather(f *testing.F) {
+	encode := func(cw CurrentWeather) []byte {
+		// The version isn't part of the decoded struct, so re-encode
+		// using the version that can hold every decoded field.
+		b, _ := cw.Encode(WeatherVersion1)
+		return b
+	}
+	decode := func(b []byte) (CurrentWeather, error) {
+		cw, _, err := DecodeCurrentWeather(b)
+		return cw, err
+	}
+	cw := CurrentWeather{
+		Time:     time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+		Location: "Paris",
+		Sunrise:  time.Date(2024, 3, 1, 7, 5, 0, 0, time.UTC),
+	}
+	v1, _ := cw.Encode(WeatherVersion1)
+	fuzzRoundTrip(f, decode, encode, cw.Bytes(), v1)
+}
+
+func FuzzForecast(f *testing.F) {
+	decode := func(b []byte) (Forecast, error) {
+		fc, _, err := DecodeForecast(b)
+		return fc, err
+	}
+	fuzzRoundTrip(f, decode, Forecast.Bytes, Forecast{Days: []ForecastDay{{MinTemp: -5.5, MaxTemp: 10}}}.Bytes())
+}
+
+func FuzzFSRequest(f *testing.F) {
+	fuzzRoundTrip(f, DecodeFSRequest, FSRequest.Encode,
+		ReadFileHeaderRequest{Offset: 5, ReadLen: 200, Path: "/ab"}.Encode(),
+		WriteFileRequest{Status: 1, Data: []byte{1, 2}}.Encode(),
+		MoveFileRequest{OldPath: "/ab", NewPath: "/c"}.Encode(),
+	)
+}
+
+func FuzzFSResponse(f *testing.F) {
+	fuzzRoundTrip(f, DecodeFSResponse, FSResponse.Encode,
+		ReadFileResponse{Status: 1, FileSize: 10, Data: []byte{5, 6}}.Encode(),
+		ListDirResponse{Status: 1, TotalEntries: 3, Path: "abc"}.Encode(),
+	)
+}
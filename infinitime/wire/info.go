@@ -0,0 +1,91 @@
+package wire
+
+import "encoding/binary"
+
+const (
+	stepCountLen = 4
+	motionLen    = 6
+)
+
+// hrFlagUint16 is set in the heart rate flags
+// if the value is a uint16 rather than a uint8
+const hrFlagUint16 = 0b1
+
+// EncodeBatteryLevel returns the payload for the Battery Level characteristic
+func EncodeBatteryLevel(lvl uint8) []byte {
+	return []byte{lvl}
+}
+
+// DecodeBatteryLevel decodes a Battery Level characteristic payload
+func DecodeBatteryLevel(b []byte) (uint8, error) {
+	if len(b) != 1 {
+		return 0, lengthError("battery level", len(b), 1)
+	}
+	return b[0], nil
+}
+
+// EncodeStepCount returns the payload for the Step Count characteristic
+func EncodeStepCount(sc uint32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, sc)
+}
+
+// DecodeStepCount decodes a Step Count characteristic payload
+func DecodeStepCount(b []byte) (uint32, error) {
+	if len(b) != stepCountLen {
+		return 0, lengthError("step count", len(b), stepCountLen)
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// EncodeHeartRate returns the payload for the Heart Rate Measurement
+// characteristic, in the 8-bit format that InfiniTime sends.
+func EncodeHeartRate(bpm uint8) []byte {
+	return []byte{0, bpm}
+}
+
+// DecodeHeartRate decodes a Heart Rate Measurement characteristic payload.
+// Any fields after the heart rate are ignored. 16-bit values, which
+// InfiniTime doesn't send, are clamped to the range of a uint8.
+func DecodeHeartRate(b []byte) (uint8, error) {
+	if len(b) < 2 {
+		return 0, shortError("heart rate", len(b), 2)
+	}
+
+	if b[0]&hrFlagUint16 == 0 {
+		return b[1], nil
+	}
+
+	if len(b) < 3 {
+		return 0, shortError("16-bit heart rate", len(b), 3)
+	}
+	return uint8(min(binary.LittleEndian.Uint16(b[1:]), 255)), nil
+}
+
+// MotionValues represents gyroscope coordinates.
+type MotionValues struct {
+	X int16
+	Y int16
+	Z int16
+}
+
+// Encode returns the payload for the Raw Motion characteristic
+func (mv MotionValues) Encode() []byte {
+	b := make([]byte, 0, motionLen)
+	b = binary.LittleEndian.AppendUint16(b, uint16(mv.X))
+	b = binary.LittleEndian.AppendUint16(b, uint16(mv.Y))
+	b = binary.LittleEndian.AppendUint16(b, uint16(mv.Z))
+	return b
+}
+
+// DecodeMotion decodes a Raw Motion characteristic payload
+func DecodeMotion(b []byte) (MotionValues, error) {
+	if len(b) != motionLen {
+		return MotionValues{}, lengthError("motion", len(b), motionLen)
+	}
+	r := &reader{b: b, name: "motion"}
+	return MotionValues{
+		X: int16(r.uint16()),
+		Y: int16(r.uint16()),
+		Z: int16(r.uint16()),
+	}, nil
+}
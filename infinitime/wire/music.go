@@ -0,0 +1,63 @@
+package wire
+
+import "encoding/binary"
+
+// MusicEvent is the payload of the Music Event characteristic,
+// which the watch sends when a button in the music app is pressed.
+type MusicEvent uint8
+
+const (
+	MusicEventOpen    MusicEvent = 0xe0
+	MusicEventPlay    MusicEvent = 0x00
+	MusicEventPause   MusicEvent = 0x01
+	MusicEventNext    MusicEvent = 0x03
+	MusicEventPrev    MusicEvent = 0x04
+	MusicEventVolUp   MusicEvent = 0x05
+	MusicEventVolDown MusicEvent = 0x06
+)
+
+// Encode returns the payload for the Music Event characteristic
+func (me MusicEvent) Encode() []byte {
+	return []byte{byte(me)}
+}
+
+// DecodeMusicEvent decodes a Music Event characteristic payload
+func DecodeMusicEvent(b []byte) (MusicEvent, error) {
+	if len(b) != 1 {
+		return 0, lengthError("music event", len(b), 1)
+	}
+	return MusicEvent(b[0]), nil
+}
+
+// EncodeMusicUint32 returns the payload for one of the numeric music
+// characteristics, such as the position or track number. Unlike most
+// of InfiniTime's services, the music service expects big endian values.
+func EncodeMusicUint32(val uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, val)
+}
+
+// DecodeMusicUint32 decodes a numeric music characteristic payload
+func DecodeMusicUint32(b []byte) (uint32, error) {
+	if len(b) != 4 {
+		return 0, lengthError("music value", len(b), 4)
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+// EncodeBool returns the payload for a boolean characteristic,
+// such as the music status or the repeat and shuffle settings.
+func EncodeBool(val bool) []byte {
+	if val {
+		return []byte{0x1}
+	}
+	return []byte{0x0}
+}
+
+// DecodeBool decodes a boolean characteristic payload.
+// Any nonzero value is true.
+func DecodeBool(b []byte) (bool, error) {
+	if len(b) != 1 {
+		return false, lengthError("boolean", len(b), 1)
+	}
+	return b[0] != 0, nil
+}
@@ -0,0 +1,99 @@
+package wire
+
+import "time"
+
+// AdjustReason is a bitmask explaining why the current time was changed
+type AdjustReason uint8
+
+const (
+	AdjustManual AdjustReason = 1 << iota
+	AdjustExternalReference
+	AdjustTimeZone
+	AdjustDST
+)
+
+const (
+	currentTimeLen   = 10
+	localTimeInfoLen = 2
+)
+
+// CurrentTime is the payload of the Current Time characteristic
+type CurrentTime struct {
+	// Time is the local wall clock time. Sub-second precision
+	// is limited to 1/256 of a second.
+	Time   time.Time
+	Adjust AdjustReason
+}
+
+// Encode returns the payload for the Current Time characteristic
+func (ct CurrentTime) Encode() []byte {
+	t := ct.Time
+	b := make([]byte, 0, currentTimeLen)
+	b = append(b, byte(t.Year()), byte(t.Year()>>8))
+	b = append(b,
+		uint8(t.Month()),
+		uint8(t.Day()),
+		uint8(t.Hour()),
+		uint8(t.Minute()),
+		uint8(t.Second()),
+		encodeWeekday(t.Weekday()),
+		uint8(t.Nanosecond()*256/1e9),
+		uint8(ct.Adjust),
+	)
+	return b
+}
+
+// DecodeCurrentTime decodes a Current Time characteristic payload.
+// The payload doesn't contain a timezone, so the returned time is
+// in UTC, with the same wall clock time that was encoded.
+func DecodeCurrentTime(b []byte) (CurrentTime, error) {
+	if len(b) != currentTimeLen {
+		return CurrentTime{}, lengthError("current time", len(b), currentTimeLen)
+	}
+
+	r := &reader{b: b, name: "current time"}
+	year := int(r.uint16())
+	month := time.Month(r.uint8())
+	day := int(r.uint8())
+	hour := int(r.uint8())
+	minute := int(r.uint8())
+	sec := int(r.uint8())
+	r.skip(1) // The weekday can be derived from the date
+	nsec := int(r.uint8()) * 1e9 / 256
+	adjust := AdjustReason(r.uint8())
+
+	return CurrentTime{
+		Time:   time.Date(year, month, day, hour, minute, sec, nsec, time.UTC),
+		Adjust: adjust,
+	}, nil
+}
+
+// encodeWeekday converts a Go weekday to the Bluetooth day of week,
+// where monday is 1 and sunday is 7.
+func encodeWeekday(wd time.Weekday) uint8 {
+	if wd == time.Sunday {
+		return 7
+	}
+	return uint8(wd)
+}
+
+// LocalTimeInfo is the payload of the Local Time Information characteristic
+type LocalTimeInfo struct {
+	// TimeZone is the offset from UTC in quarters of an hour, not including DST
+	TimeZone int8
+	// DSTOffset is the daylight saving time offset in quarters of an hour
+	DSTOffset uint8
+}
+
+// Encode returns the payload for the Local Time Information characteristic
+func (lti LocalTimeInfo) Encode() []byte {
+	return []byte{byte(lti.TimeZone), lti.DSTOffset}
+}
+
+// DecodeLocalTimeInfo decodes a Local Time Information characteristic payload
+func DecodeLocalTimeInfo(b []byte) (LocalTimeInfo, error) {
+	if len(b) != localTimeInfoLen {
+		return LocalTimeInfo{}, lengthError("local time info", len(b), localTimeInfoLen)
+	}
+	return LocalTimeInfo{TimeZone: int8(b[0]), DSTOffset: b[1]}, nil
+}
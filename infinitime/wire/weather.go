@@ -0,0 +1,285 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// WeatherVersion is a version of the simple weather service wire format
+type WeatherVersion uint8
+
+const (
+	// WeatherVersion0 is the original simple weather format
+	WeatherVersion0 WeatherVersion = 0
+	// WeatherVersion1 adds sunrise and sunset times to the current weather
+	WeatherVersion1 WeatherVersion = 1
+)
+
+const (
+	currentWeatherType  = 0
+	forecastWeatherType = 1
+
+	// currentWeatherV0Len is the length of a version 0 current weather message
+	currentWeatherV0Len = 49
+	// currentWeatherV1Len is the length of a version 1 current weather message
+	currentWeatherV1Len = 53
+	// forecastHeaderLen is the length of a forecast message without any days
+	forecastHeaderLen = 11
+	// forecastDayLen is the length of a single day in a forecast message
+	forecastDayLen = 5
+	// maxForecastDays is the maximum number of days in a forecast
+	maxForecastDays = 5
+	// locationLen is the length of the location field
+	locationLen = 32
+	// unknownSunTime is sent in place of sunrise and sunset times that aren't known
+	unknownSunTime = -1
+)
+
+var (
+	ErrWeatherVersion = errors.New("unsupported weather version")
+	ErrWeatherType    = errors.New("unexpected weather message type")
+	ErrWeatherLength  = errors.New("invalid weather message length")
+	ErrForecastDays   = errors.New("amount of forecast days exceeds maximum of 5")
+)
+
+type WeatherIcon uint8
+
+const (
+	WeatherIconClear WeatherIcon = iota
+	WeatherIconFewClouds
+	WeatherIconClouds
+	WeatherIconHeavyClouds
+	WeatherIconCloudsWithRain
+	WeatherIconRain
+	WeatherIconThunderstorm
+	WeatherIconSnow
+	WeatherIconMist
+)
+
+// CurrentWeather represents the current weather
+type CurrentWeather struct {
+	Time        time.Time
+	CurrentTemp float32
+	MinTemp     float32
+	MaxTemp     float32
+	Location    string
+	Icon        WeatherIcon
+	// Sunrise and Sunset are the times of today's sunrise and sunset.
+	// They're only sent using [WeatherVersion1] or newer, and are
+	// sent as unknown if they're zero.
+	Sunrise time.Time
+	Sunset  time.Time
+}
+
+// Bytes returns the [CurrentWeather] struct encoded using
+// version 0 of the InfiniTime weather wire protocol.
+func (cw CurrentWeather) Bytes() []byte {
+	b, _ := cw.Encode(WeatherVersion0)
+	return b
+}
+
+// Encode returns the [CurrentWeather] struct encoded using the
+// given version of the InfiniTime weather wire protocol.
+func (cw CurrentWeather) Encode(v WeatherVersion) ([]byte, error) {
+	if v > WeatherVersion1 {
+		return nil, fmt.Errorf("%w: %d", ErrWeatherVersion, v)
+	}
+
+	buf := &bytes.Buffer{}
+
+	buf.WriteByte(currentWeatherType)
+	buf.WriteByte(byte(v))
+
+	binary.Write(buf, binary.LittleEndian, localUnix(cw.Time))
+
+	binary.Write(buf, binary.LittleEndian, encodeTemp(cw.CurrentTemp))
+	binary.Write(buf, binary.LittleEndian, encodeTemp(cw.MinTemp))
+	binary.Write(buf, binary.LittleEndian, encodeTemp(cw.MaxTemp))
+
+	location := make([]byte, locationLen)
+	copy(location, cw.Location)
+	buf.Write(location)
+
+	buf.WriteByte(byte(cw.Icon))
+
+	if v >= WeatherVersion1 {
+		binary.Write(buf, binary.LittleEndian, encodeSunTime(cw.Sunrise))
+		binary.Write(buf, binary.LittleEndian, encodeSunTime(cw.Sunset))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeCurrentWeather decodes a current weather message encoded using
+// any version of the InfiniTime weather wire protocol. Since the protocol
+// sends local time without a timezone, the returned times are in UTC,
+// with the same wall clock time that was encoded.
+func DecodeCurrentWeather(b []byte) (CurrentWeather, WeatherVersion, error) {
+	if len(b) < 2 {
+		return CurrentWeather{}, 0, ErrWeatherLength
+	}
+	if b[0] != currentWeatherType {
+		return CurrentWeather{}, 0, fmt.Errorf("%w: %d", ErrWeatherType, b[0])
+	}
+
+	v := WeatherVersion(b[1])
+	switch {
+	case v > WeatherVersion1:
+		return CurrentWeather{}, v, fmt.Errorf("%w: %d", ErrWeatherVersion, v)
+	case v == WeatherVersion0 && len(b) != currentWeatherV0Len,
+		v == WeatherVersion1 && len(b) != currentWeatherV1Len:
+		return CurrentWeather{}, v, ErrWeatherLength
+	}
+
+	cw := CurrentWeather{
+		Time:        time.Unix(int64(binary.LittleEndian.Uint64(b[2:])), 0).UTC(),
+		CurrentTemp: decodeTemp(b[10:]),
+		MinTemp:     decodeTemp(b[12:]),
+		MaxTemp:     decodeTemp(b[14:]),
+		Location:    string(bytes.TrimRight(b[16:16+locationLen], "\x00")),
+		Icon:        WeatherIcon(b[48]),
+	}
+
+	if v >= WeatherVersion1 {
+		cw.Sunrise = decodeSunTime(cw.Time, b[49:])
+		cw.Sunset = decodeSunTime(cw.Time, b[51:])
+	}
+
+	return cw, v, nil
+}
+
+// Forecast represents a weather forecast
+type Forecast struct {
+	Time time.Time
+	Days []ForecastDay
+}
+
+// ForecastDay represents a forecast for a single day
+type ForecastDay struct {
+	MinTemp float32
+	MaxTemp float32
+	Icon    WeatherIcon
+}
+
+// Bytes returns the [Forecast] struct encoded using
+// version 0 of the InfiniTime weather wire protocol.
+// Any days after the maximum of 5 are left out.
+func (f Forecast) Bytes() []byte {
+	if len(f.Days) > maxForecastDays {
+		f.Days = f.Days[:maxForecastDays]
+	}
+	b, _ := f.Encode(WeatherVersion0)
+	return b
+}
+
+// Encode returns the [Forecast] struct encoded using the given version
+// of the InfiniTime weather wire protocol. The forecast format hasn't
+// changed since version 0, so that's the only supported version.
+func (f Forecast) Encode(v WeatherVersion) ([]byte, error) {
+	if v != WeatherVersion0 {
+		return nil, fmt.Errorf("%w: %d", ErrWeatherVersion, v)
+	}
+
+	if len(f.Days) > maxForecastDays {
+		return nil, ErrForecastDays
+	}
+
+	buf := &bytes.Buffer{}
+
+	buf.WriteByte(forecastWeatherType)
+	buf.WriteByte(byte(v))
+
+	binary.Write(buf, binary.LittleEndian, localUnix(f.Time))
+
+	buf.WriteByte(uint8(len(f.Days)))
+
+	for _, day := range f.Days {
+		binary.Write(buf, binary.LittleEndian, encodeTemp(day.MinTemp))
+		binary.Write(buf, binary.LittleEndian, encodeTemp(day.MaxTemp))
+		buf.WriteByte(byte(day.Icon))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeForecast decodes a forecast message encoded using the InfiniTime
+// weather wire protocol. Like [DecodeCurrentWeather], the returned time is
+// in UTC, with the same wall clock time that was encoded.
+func DecodeForecast(b []byte) (Forecast, WeatherVersion, error) {
+	if len(b) < forecastHeaderLen {
+		return Forecast{}, 0, ErrWeatherLength
+	}
+	if b[0] != forecastWeatherType {
+		return Forecast{}, 0, fmt.Errorf("%w: %d", ErrWeatherType, b[0])
+	}
+
+	v := WeatherVersion(b[1])
+	if v != WeatherVersion0 {
+		return Forecast{}, v, fmt.Errorf("%w: %d", ErrWeatherVersion, v)
+	}
+
+	numDays := int(b[10])
+	if numDays > maxForecastDays {
+		return Forecast{}, v, ErrForecastDays
+	}
+	if len(b) != forecastHeaderLen+numDays*forecastDayLen {
+		return Forecast{}, v, ErrWeatherLength
+	}
+
+	f := Forecast{
+		Time: time.Unix(int64(binary.LittleEndian.Uint64(b[2:])), 0).UTC(),
+		Days: make([]ForecastDay, numDays),
+	}
+
+	for i := range f.Days {
+		day := b[forecastHeaderLen+i*forecastDayLen:]
+		f.Days[i] = ForecastDay{
+			MinTemp: decodeTemp(day[0:]),
+			MaxTemp: decodeTemp(day[2:]),
+			Icon:    WeatherIcon(day[4]),
+		}
+	}
+
+	return f, v, nil
+}
+
+// localUnix returns the local wall clock time of t as a unix
+// timestamp, which is what InfiniTime expects.
+func localUnix(t time.Time) int64 {
+	_, offset := t.Zone()
+	return t.Unix() + int64(offset)
+}
+
+// encodeTemp converts a temperature in degrees Celsius to the hundredths
+// of a degree used by InfiniTime, clamping it to the range of an int16.
+func encodeTemp(temp float32) int16 {
+	return int16(max(min(math.Round(float64(temp)*100), math.MaxInt16), math.MinInt16))
+}
+
+func decodeTemp(b []byte) float32 {
+	return float32(int16(binary.LittleEndian.Uint16(b))) / 100
+}
+
+// encodeSunTime converts a sunrise or sunset time to the number of
+// minutes since midnight, in the time's location.
+func encodeSunTime(t time.Time) int16 {
+	if t.IsZero() {
+		return unknownSunTime
+	}
+	return int16(t.Hour()*60 + t.Minute())
+}
+
+// decodeSunTime converts a number of minutes since midnight to a time
+// on the same day as day. Values outside of the day are treated as unknown.
+func decodeSunTime(day time.Time, b []byte) time.Time {
+	minutes := int16(binary.LittleEndian.Uint16(b))
+	if minutes < 0 || minutes >= 24*60 {
+		return time.Time{}
+	}
+	y, m, d := day.Date()
+	return time.Date(y, m, d, 0, int(minutes), 0, 0, day.Location())
+}
@@ -0,0 +1,163 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCurrentWeatherEncode(t *testing.T) {
+	cw := CurrentWeather{
+		Time:        time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+		CurrentTemp: 21.5,
+		MinTemp:     -3.25,
+		MaxTemp:     25,
+		Location:    "Paris",
+		Icon:        WeatherIconClouds,
+		Sunrise:     time.Date(2024, 3, 1, 7, 5, 0, 0, time.UTC),
+		Sunset:      time.Date(2024, 3, 1, 18, 45, 0, 0, time.UTC),
+	}
+
+	header := "0000" + "48cae16500000000" + "6608" + "bbfe" + "c409" +
+		hex.EncodeToString(append([]byte("Paris"), make([]byte, 27)...)) +
+		"02"
+
+	tests := []struct {
+		version WeatherVersion
+		want    string
+	}{
+		{WeatherVersion0, header},
+		{WeatherVersion1, "0001" + header[4:] + "a901" + "6504"},
+	}
+
+	for _, tt := range tests {
+		got, err := cw.Encode(tt.version)
+		if err != nil {
+			t.Fatalf("Encode(%d): %v", tt.version, err)
+		}
+		if hex.EncodeToString(got) != tt.want {
+			t.Errorf("Encode(%d):\ngot  %x\nwant %s", tt.version, got, tt.want)
+		}
+	}
+
+	if !bytes.Equal(cw.Bytes(), mustEncode(t, cw, WeatherVersion0)) {
+		t.Error("Bytes() doesn't match version 0 encoding")
+	}
+
+	if _, err := cw.Encode(2); !errors.Is(err, ErrWeatherVersion) {
+		t.Errorf("Encode(2): expected ErrWeatherVersion, got %v", err)
+	}
+}
+
+func TestCurrentWeatherUnknownSunTimes(t *testing.T) {
+	b := mustEncode(t, CurrentWeather{Time: time.Unix(0, 0).UTC()}, WeatherVersion1)
+	if hex.EncodeToString(b[49:]) != "ffffffff" {
+		t.Errorf("expected unknown sun times, got %x", b[49:])
+	}
+}
+
+func TestCurrentWeatherRoundTrip(t *testing.T) {
+	cw := CurrentWeather{
+		Time:        time.Date(2024, 7, 14, 9, 0, 0, 0, time.UTC),
+		CurrentTemp: 30.12,
+		MinTemp:     -40,
+		MaxTemp:     45.5,
+		Location:    "Montréal",
+		Icon:        WeatherIconThunderstorm,
+		Sunrise:     time.Date(2024, 7, 14, 5, 15, 0, 0, time.UTC),
+		Sunset:      time.Date(2024, 7, 14, 20, 40, 0, 0, time.UTC),
+	}
+
+	for _, v := range []WeatherVersion{WeatherVersion0, WeatherVersion1} {
+		got, gotVer, err := DecodeCurrentWeather(mustEncode(t, cw, v))
+		if err != nil {
+			t.Fatalf("DecodeCurrentWeather(v%d): %v", v, err)
+		}
+		if gotVer != v {
+			t.Errorf("expected version %d, got %d", v, gotVer)
+		}
+
+		want := cw
+		if v == WeatherVersion0 {
+			want.Sunrise, want.Sunset = time.Time{}, time.Time{}
+		}
+		if got != want {
+			t.Errorf("v%d round trip:\ngot  %+v\nwant %+v", v, got, want)
+		}
+	}
+}
+
+func TestDecodeCurrentWeatherErrors(t *testing.T) {
+	v0 := mustEncode(t, CurrentWeather{}, WeatherVersion0)
+
+	tests := []struct {
+		name string
+		in   []byte
+		want error
+	}{
+		{"empty", nil, ErrWeatherLength},
+		{"forecast", []byte{forecastWeatherType, 0}, ErrWeatherType},
+		{"future version", []byte{currentWeatherType, 9}, ErrWeatherVersion},
+		{"truncated", v0[:20], ErrWeatherLength},
+		{"v1 length mismatch", append([]byte{currentWeatherType, 1}, v0[2:]...), ErrWeatherLength},
+	}
+
+	for _, tt := range tests {
+		_, _, err := DecodeCurrentWeather(tt.in)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+}
+
+func TestForecastEncode(t *testing.T) {
+	f := Forecast{
+		Time: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+		Days: []ForecastDay{
+			{MinTemp: -5.5, MaxTemp: 10.25, Icon: WeatherIconSnow},
+			// Temperatures out of range for an int16 are clamped
+			{MinTemp: -1000, MaxTemp: 1000, Icon: WeatherIconClear},
+		},
+	}
+
+	want := "0100" + "48cae16500000000" + "02" +
+		"dafd" + "0104" + "07" +
+		"0080" + "ff7f" + "00"
+
+	got, err := f.Encode(WeatherVersion0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(got) != want {
+		t.Errorf("got  %x\nwant %s", got, want)
+	}
+
+	decoded, _, err := DecodeForecast(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Days[0] != f.Days[0] {
+		t.Errorf("round trip: got %+v, want %+v", decoded.Days[0], f.Days[0])
+	}
+	if !decoded.Time.Equal(f.Time) {
+		t.Errorf("round trip: got time %v, want %v", decoded.Time, f.Time)
+	}
+
+	if _, err := (Forecast{Days: make([]ForecastDay, 6)}).Encode(WeatherVersion0); !errors.Is(err, ErrForecastDays) {
+		t.Errorf("expected ErrForecastDays, got %v", err)
+	}
+	if _, err := f.Encode(WeatherVersion1); !errors.Is(err, ErrWeatherVersion) {
+		t.Errorf("expected ErrWeatherVersion, got %v", err)
+	}
+}
+
+func mustEncode(t *testing.T, cw CurrentWeather, v WeatherVersion) []byte {
+	t.Helper()
+	b, err := cw.Encode(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
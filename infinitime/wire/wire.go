@@ -0,0 +1,75 @@
+// Package wire contains the encoders and decoders for the payloads
+// that InfiniTime sends and receives over BLE. Every payload can be
+// both encoded and decoded, so the same definitions can be used by
+// clients and by anything that needs to act like a watch.
+package wire
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var ErrLength = errors.New("invalid payload length")
+
+// lengthError returns an error for a payload whose length isn't want
+func lengthError(name string, got, want int) error {
+	return fmt.Errorf("%w: %s must be %d bytes, got %d", ErrLength, name, want, got)
+}
+
+// shortError returns an error for a payload shorter than min
+func shortError(name string, got, min int) error {
+	return fmt.Errorf("%w: %s must be at least %d bytes, got %d", ErrLength, name, min, got)
+}
+
+// reader reads little endian values from a payload. Once a read
+// goes past the end of the payload, err is set, and every following
+// read returns a zero value.
+type reader struct {
+	b    []byte
+	name string
+	err  error
+}
+
+func (r *reader) next(n int) []byte {
+	if r.err == nil && (n < 0 || n > len(r.b)) {
+		r.err = fmt.Errorf("%w: %s is truncated", ErrLength, r.name)
+	}
+	if r.err != nil {
+		// Fixed size reads still need enough bytes to decode a zero value
+		var zero [8]byte
+		return zero[:min(max(n, 0), len(zero))]
+	}
+	out := r.b[:n]
+	r.b = r.b[n:]
+	return out
+}
+
+func (r *reader) uint8() uint8 {
+	return r.next(1)[0]
+}
+
+func (r *reader) int8() int8 {
+	return int8(r.uint8())
+}
+
+func (r *reader) uint16() uint16 {
+	return binary.LittleEndian.Uint16(r.next(2))
+}
+
+func (r *reader) uint32() uint32 {
+	return binary.LittleEndian.Uint32(r.next(4))
+}
+
+func (r *reader) uint64() uint64 {
+	return binary.LittleEndian.Uint64(r.next(8))
+}
+
+func (r *reader) skip(n int) {
+	r.next(n)
+}
+
+// bytes returns a copy of the next n bytes
+func (r *reader) bytes(n int) []byte {
+	return append([]byte(nil), r.next(n)...)
+}
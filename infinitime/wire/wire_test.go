@@ -0,0 +1,158 @@
+package wire
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{
+			name: "current time",
+			got: CurrentTime{
+				// 2024-03-03 is a sunday
+				Time:   time.Date(2024, 3, 3, 14, 5, 9, 500_000_000, time.UTC),
+				Adjust: AdjustManual,
+			}.Encode(),
+			want: "e80703030e0509078001",
+		},
+		{
+			name: "local time info",
+			got:  LocalTimeInfo{TimeZone: -20, DSTOffset: 4}.Encode(),
+			want: "ec04",
+		},
+		{
+			name: "alert",
+			got:  Alert{Category: AlertCategorySimple, Count: 1, Title: "Hi", Body: "there"}.Encode(),
+			want: "000100" + hex.EncodeToString([]byte("Hi\x00there")),
+		},
+		{
+			name: "call",
+			got:  Alert{Category: AlertCategoryCall, Count: 1, Title: "555"}.Encode(),
+			want: "030100" + hex.EncodeToString([]byte("555")),
+		},
+		{name: "call status", got: CallStatusMuted.Encode(), want: "02"},
+		{name: "music event", got: MusicEventOpen.Encode(), want: "e0"},
+		{name: "music uint32", got: EncodeMusicUint32(0x01020304), want: "01020304"},
+		{name: "bool", got: EncodeBool(true), want: "01"},
+		{name: "battery level", got: EncodeBatteryLevel(87), want: "57"},
+		{name: "step count", got: EncodeStepCount(0x01020304), want: "04030201"},
+		{name: "heart rate", got: EncodeHeartRate(72), want: "0048"},
+		{name: "motion", got: MotionValues{X: 1, Y: -1, Z: 256}.Encode(), want: "0100ffff0001"},
+	}
+
+	for _, tt := range tests {
+		if got := hex.EncodeToString(tt.got); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		decode func([]byte) (any, error)
+		in     string
+		want   any
+	}{
+		{
+			name:   "current time",
+			decode: wrap(DecodeCurrentTime),
+			in:     "e80703030e0509078001",
+			want: CurrentTime{
+				Time:   time.Date(2024, 3, 3, 14, 5, 9, 500_000_000, time.UTC),
+				Adjust: AdjustManual,
+			},
+		},
+		{
+			name:   "local time info",
+			decode: wrap(DecodeLocalTimeInfo),
+			in:     "ec04",
+			want:   LocalTimeInfo{TimeZone: -20, DSTOffset: 4},
+		},
+		{
+			name:   "alert",
+			decode: wrap(DecodeAlert),
+			in:     "000100" + hex.EncodeToString([]byte("Hi\x00there")),
+			want:   Alert{Category: AlertCategorySimple, Count: 1, Title: "Hi", Body: "there"},
+		},
+		{
+			name:   "alert without body",
+			decode: wrap(DecodeAlert),
+			in:     "000100" + hex.EncodeToString([]byte("Hi")),
+			want:   Alert{Category: AlertCategorySimple, Count: 1, Title: "Hi"},
+		},
+		{
+			name:   "call",
+			decode: wrap(DecodeAlert),
+			in:     "030100" + hex.EncodeToString([]byte("555")),
+			want:   Alert{Category: AlertCategoryCall, Count: 1, Title: "555"},
+		},
+		{name: "call status", decode: wrap(DecodeCallStatus), in: "01", want: CallStatusAccepted},
+		{name: "music event", decode: wrap(DecodeMusicEvent), in: "05", want: MusicEventVolUp},
+		{name: "music uint32", decode: wrap(DecodeMusicUint32), in: "01020304", want: uint32(0x01020304)},
+		{name: "bool", decode: wrap(DecodeBool), in: "02", want: true},
+		{name: "battery level", decode: wrap(DecodeBatteryLevel), in: "57", want: uint8(87)},
+		{name: "step count", decode: wrap(DecodeStepCount), in: "04030201", want: uint32(0x01020304)},
+		{name: "heart rate", decode: wrap(DecodeHeartRate), in: "0048", want: uint8(72)},
+		{name: "heart rate with extra fields", decode: wrap(DecodeHeartRate), in: "00480102", want: uint8(72)},
+		{name: "16-bit heart rate", decode: wrap(DecodeHeartRate), in: "014800", want: uint8(72)},
+		{name: "clamped heart rate", decode: wrap(DecodeHeartRate), in: "012c01", want: uint8(255)},
+		{name: "motion", decode: wrap(DecodeMotion), in: "0100ffff0001", want: MotionValues{X: 1, Y: -1, Z: 256}},
+	}
+
+	for _, tt := range tests {
+		in, _ := hex.DecodeString(tt.in)
+		got, err := tt.decode(in)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeLength(t *testing.T) {
+	tests := []struct {
+		name   string
+		decode func([]byte) (any, error)
+		in     []byte
+	}{
+		{"current time", wrap(DecodeCurrentTime), make([]byte, 9)},
+		{"local time info", wrap(DecodeLocalTimeInfo), make([]byte, 3)},
+		{"alert", wrap(DecodeAlert), make([]byte, 2)},
+		{"call status", wrap(DecodeCallStatus), nil},
+		{"music event", wrap(DecodeMusicEvent), make([]byte, 2)},
+		{"music uint32", wrap(DecodeMusicUint32), make([]byte, 3)},
+		{"bool", wrap(DecodeBool), nil},
+		{"battery level", wrap(DecodeBatteryLevel), nil},
+		{"step count", wrap(DecodeStepCount), make([]byte, 2)},
+		{"heart rate", wrap(DecodeHeartRate), make([]byte, 1)},
+		{"16-bit heart rate", wrap(DecodeHeartRate), []byte{1, 0}},
+		{"motion", wrap(DecodeMotion), make([]byte, 4)},
+	}
+
+	for _, tt := range tests {
+		_, err := tt.decode(tt.in)
+		if !errors.Is(err, ErrLength) {
+			t.Errorf("%s: expected ErrLength, got %v", tt.name, err)
+		}
+	}
+}
+
+// wrap converts a decode function into one that returns any,
+// so that different decoders can be used in the same table.
+func wrap[T any](decode func([]byte) (T, error)) func([]byte) (any, error) {
+	return func(b []byte) (any, error) {
+		return decode(b)
+	}
+}
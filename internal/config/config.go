@@ -48,7 +48,7 @@ func getRuntimeDir() string {
 }
 
 var defaults = Config{
-	Bluetooh: Bluetooh{Adapter: "hci0"},
+	Bluetooh: Bluetooh{Adapter: "hci0", Timeout: 30},
 	Socket:   Socket{Path: filepath.Join(getRuntimeDir(), "itd.sock")},
 	Conn: Conn{
 		Reconnect: true,
@@ -67,8 +67,11 @@ var defaults = Config{
 		},
 	},
 	Music: Music{
-		Vol: Volume{Interval: 5},
+		Vol: Volume{Interval: 5, Backend: "auto"},
 	},
+	Nav:      Nav{UpdateInterval: 1000},
+	TimeSync: TimeSync{Enabled: true, Interval: 360},
+	DFU:      DFU{RebootTimeout: 180},
 	Fuse: Fuse{
 		Enabled:    false,
 		Mountpoint: "/tmp/itd/mnt",
@@ -85,6 +88,10 @@ type Config struct {
 	On       On       `toml:"on"`
 	Fuse     Fuse     `toml:"fuse"`
 	Music    Music    `toml:"music"`
+	Nav      Nav      `toml:"nav"`
+	TimeSync TimeSync `toml:"timeSync"`
+	DFU      DFU      `toml:"dfu"`
+	Update   Update   `toml:"update"`
 	Metrics  Metrics  `toml:"metrics"`
 	Socket   Socket   `toml:"socket"`
 }
@@ -100,6 +107,9 @@ type Logging struct {
 
 type Bluetooh struct {
 	Adapter string `toml:"adapter"`
+	// Timeout is the time in seconds to wait for the watch
+	// before giving up on an operation
+	Timeout uint `toml:"timeout"`
 }
 
 type Socket struct {
@@ -164,9 +174,42 @@ type Music struct {
 }
 
 type Volume struct {
+	Interval    uint   `toml:"interval"`
+	Backend     string `toml:"backend"`
+	UpCommand   string `toml:"upCommand"`
+	DownCommand string `toml:"downCommand"`
+}
+
+type Nav struct {
+	// UpdateInterval is the minimum time in milliseconds
+	// between writes to each navigation field
+	UpdateInterval uint `toml:"updateInterval"`
+}
+
+type TimeSync struct {
+	// Enabled controls whether the time is synced while connected,
+	// in addition to the connect and reconnect hooks
+	Enabled bool `toml:"enabled"`
+	// Interval is the time in minutes between time syncs. The time
+	// is also synced at DST transitions. 0 disables periodic syncs.
 	Interval uint `toml:"interval"`
 }
 
+type DFU struct {
+	// Retries is the amount of times to retry a failed firmware upgrade.
+	// Every retry sends the whole image again, since InfiniTime's DFU
+	// protocol can't resume an interrupted transfer.
+	Retries int `toml:"retries"`
+	// RebootTimeout is the time in seconds to wait for the watch
+	// to reconnect after it reboots into the new firmware
+	RebootTimeout uint `toml:"rebootTimeout"`
+}
+
+type Update struct {
+	// Catalogue is the URL of the InfiniTime release catalogue,
+	// or the path to a local directory that mirrors it
+	Catalogue string `toml:"catalogue"`
+}
 
 func ParseLogLevel(lv string) slog.Level {
 	switch strings.ToLower(lv) {
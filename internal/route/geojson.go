@@ -0,0 +1,139 @@
+package route
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.elara.ws/itd/infinitime"
+)
+
+type geoJSONObject struct {
+	Type        string            `json:"type"`
+	Features    []geoJSONObject   `json:"features"`
+	Geometry    *geoJSONObject    `json:"geometry"`
+	Properties  geoJSONProperties `json:"properties"`
+	Coordinates json.RawMessage   `json:"coordinates"`
+}
+
+type geoJSONProperties struct {
+	Maneuver    string `json:"maneuver"`
+	Narrative   string `json:"narrative"`
+	Instruction string `json:"instruction"`
+}
+
+// ParseGeoJSON parses a route from a GeoJSON file. The route is made up
+// of every LineString and MultiLineString in the file. Point features
+// with a "maneuver" property are added as maneuvers at the nearest point
+// on the route, with the "narrative" or "instruction" property used
+// as the narrative for that maneuver.
+func ParseGeoJSON(r io.Reader) (*Route, error) {
+	var obj geoJSONObject
+	err := json.NewDecoder(r).Decode(&obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []Point
+	var annotations []Point
+	err = collectGeoJSON(obj, geoJSONProperties{}, &points, &annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(points) < 2 {
+		return nil, ErrTooFewPoints
+	}
+
+	for _, a := range annotations {
+		nearest := 0
+		for i := range points {
+			if distance(a, points[i]) < distance(a, points[nearest]) {
+				nearest = i
+			}
+		}
+		points[nearest].Flag = a.Flag
+		points[nearest].Narrative = a.Narrative
+	}
+
+	return New(points)
+}
+
+// collectGeoJSON adds the points in obj to points, and
+// the maneuver annotations in it to annotations
+func collectGeoJSON(obj geoJSONObject, props geoJSONProperties, points, annotations *[]Point) error {
+	switch obj.Type {
+	case "FeatureCollection":
+		for _, feature := range obj.Features {
+			err := collectGeoJSON(feature, feature.Properties, points, annotations)
+			if err != nil {
+				return err
+			}
+		}
+	case "Feature":
+		if obj.Geometry == nil {
+			return nil
+		}
+		return collectGeoJSON(*obj.Geometry, obj.Properties, points, annotations)
+	case "LineString":
+		var coords [][]float64
+		err := json.Unmarshal(obj.Coordinates, &coords)
+		if err != nil {
+			return err
+		}
+		return appendCoords(points, coords)
+	case "MultiLineString":
+		var lines [][][]float64
+		err := json.Unmarshal(obj.Coordinates, &lines)
+		if err != nil {
+			return err
+		}
+		for _, coords := range lines {
+			err = appendCoords(points, coords)
+			if err != nil {
+				return err
+			}
+		}
+	case "Point":
+		if props.Maneuver == "" {
+			return nil
+		}
+
+		var coord []float64
+		err := json.Unmarshal(obj.Coordinates, &coord)
+		if err != nil {
+			return err
+		}
+		if len(coord) < 2 {
+			return errors.New("geojson point must have at least two coordinates")
+		}
+
+		narrative := props.Narrative
+		if narrative == "" {
+			narrative = props.Instruction
+		}
+
+		*annotations = append(*annotations, Point{
+			Lat:       coord[1],
+			Lon:       coord[0],
+			Flag:      infinitime.ParseNavFlag(props.Maneuver),
+			Narrative: narrative,
+		})
+	case "":
+		return fmt.Errorf("%w: missing geojson type", ErrUnknownFormat)
+	}
+	return nil
+}
+
+// appendCoords appends GeoJSON coordinates, which are
+// in [longitude, latitude] order, to points
+func appendCoords(points *[]Point, coords [][]float64) error {
+	for _, coord := range coords {
+		if len(coord) < 2 {
+			return errors.New("geojson position must have at least two coordinates")
+		}
+		*points = append(*points, Point{Lat: coord[1], Lon: coord[0]})
+	}
+	return nil
+}
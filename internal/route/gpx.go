@@ -0,0 +1,67 @@
+package route
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+
+	"go.elara.ws/itd/infinitime"
+)
+
+type gpxFile struct {
+	Routes []struct {
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+	Tracks []struct {
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+type gpxPoint struct {
+	Lat  float64   `xml:"lat,attr"`
+	Lon  float64   `xml:"lon,attr"`
+	Time time.Time `xml:"time"`
+	Name string    `xml:"name"`
+	Desc string    `xml:"desc"`
+	Type string    `xml:"type"`
+}
+
+// ParseGPX parses a route from a GPX file. Route points (rtept) are used if
+// there are any, otherwise track points (trkpt) are used. A point's type
+// element is used as its maneuver, and its desc or name element is
+// used as the narrative for that maneuver.
+func ParseGPX(r io.Reader) (*Route, error) {
+	var gpx gpxFile
+	err := xml.NewDecoder(r).Decode(&gpx)
+	if err != nil {
+		return nil, err
+	}
+
+	var gpxPoints []gpxPoint
+	for _, rte := range gpx.Routes {
+		gpxPoints = append(gpxPoints, rte.Points...)
+	}
+	if len(gpxPoints) == 0 {
+		for _, trk := range gpx.Tracks {
+			for _, seg := range trk.Segments {
+				gpxPoints = append(gpxPoints, seg.Points...)
+			}
+		}
+	}
+
+	points := make([]Point, len(gpxPoints))
+	for i, gp := range gpxPoints {
+		points[i] = Point{Lat: gp.Lat, Lon: gp.Lon, Time: gp.Time}
+		if gp.Type != "" {
+			points[i].Flag = infinitime.ParseNavFlag(gp.Type)
+			points[i].Narrative = gp.Desc
+			if points[i].Narrative == "" {
+				points[i].Narrative = gp.Name
+			}
+		}
+	}
+
+	return New(points)
+}
@@ -0,0 +1,410 @@
+// Package route loads GPX and GeoJSON routes and replays them
+// as turn-by-turn instructions for InfiniTime's navigation app.
+package route
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.elara.ws/itd/infinitime"
+)
+
+var (
+	ErrTooFewPoints  = errors.New("route must contain at least two points")
+	ErrUnknownFormat = errors.New("unknown route format")
+)
+
+// earthRadius is the mean radius of the earth in meters
+const earthRadius = 6371008.8
+
+const (
+	// lookaround is the distance in meters before and after a point
+	// that's used to calculate the direction of travel at that point.
+	// This stops GPS noise in recorded tracks from being seen as turns.
+	lookaround = 20
+	// minTurnAngle is the smallest change in direction, in
+	// degrees, that's considered a maneuver
+	minTurnAngle = 30
+)
+
+// Point is a single point on a route
+type Point struct {
+	Lat, Lon float64
+	// Time is the time at which the point was recorded,
+	// or the zero time if it's not known
+	Time time.Time
+	// Flag is the maneuver at this point, if the route
+	// was annotated with one
+	Flag infinitime.NavFlag
+	// Narrative is a description of the maneuver at this point
+	Narrative string
+}
+
+// Maneuver is an instruction at a certain distance along a route
+type Maneuver struct {
+	// Distance is the distance from the start of the route in meters
+	Distance  float64
+	Flag      infinitime.NavFlag
+	Narrative string
+}
+
+// Route is a list of points that make up a route
+type Route struct {
+	Points []Point
+	// dists contains the distance in meters from the
+	// start of the route to each point
+	dists []float64
+}
+
+// New creates a route from the given points
+func New(points []Point) (*Route, error) {
+	if len(points) < 2 {
+		return nil, ErrTooFewPoints
+	}
+
+	r := &Route{Points: points, dists: make([]float64, len(points))}
+	for i := 1; i < len(points); i++ {
+		r.dists[i] = r.dists[i-1] + distance(points[i-1], points[i])
+	}
+	return r, nil
+}
+
+// Load loads a route from a GPX or GeoJSON file,
+// detecting the format from the file extension.
+func Load(path string) (*Route, error) {
+	fl, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fl.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gpx":
+		return ParseGPX(fl)
+	case ".geojson", ".json":
+		return ParseGeoJSON(fl)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, filepath.Ext(path))
+	}
+}
+
+// Length returns the length of the route in meters
+func (r *Route) Length() float64 {
+	return r.dists[len(r.dists)-1]
+}
+
+// Maneuvers returns the maneuvers along the route. If any points are
+// annotated with maneuvers, only those are used. Otherwise, maneuvers are
+// calculated from the changes in direction along the route. The last
+// maneuver is always an arrival at the end of the route.
+func (r *Route) Maneuvers() []Maneuver {
+	var out []Maneuver
+	for i, p := range r.Points {
+		if p.Flag != "" {
+			out = append(out, Maneuver{r.dists[i], p.Flag, p.Narrative})
+		}
+	}
+
+	if len(out) == 0 {
+		out = r.calcManeuvers()
+	}
+
+	if len(out) == 0 || out[len(out)-1].Distance < r.Length() {
+		out = append(out, Maneuver{Distance: r.Length(), Flag: infinitime.NavFlagArrive})
+	}
+
+	for i := range out {
+		if out[i].Narrative == "" {
+			out[i].Narrative = narrative(out[i].Flag)
+		}
+	}
+
+	return out
+}
+
+// calcManeuvers calculates maneuvers from the changes in direction along the route
+func (r *Route) calcManeuvers() []Maneuver {
+	var out []Maneuver
+	// The largest turn seen so far that hasn't been added yet
+	var pending *Maneuver
+	var pendingAngle float64
+
+	for i := 1; i < len(r.Points)-1; i++ {
+		d := r.dists[i]
+		if d < lookaround || d > r.Length()-lookaround {
+			continue
+		}
+
+		inBearing := bearing(r.pointAt(d-lookaround), r.Points[i])
+		outBearing := bearing(r.Points[i], r.pointAt(d+lookaround))
+		angle := normalizeAngle(outBearing - inBearing)
+
+		if math.Abs(angle) < minTurnAngle {
+			continue
+		}
+
+		// Points that are close together are part of the same turn,
+		// so only keep the sharpest one
+		if pending != nil && d-pending.Distance < 2*lookaround {
+			if math.Abs(angle) > math.Abs(pendingAngle) {
+				pending.Distance, pendingAngle = d, angle
+				pending.Flag = turnFlag(angle)
+			}
+			continue
+		}
+
+		if pending != nil {
+			out = append(out, *pending)
+		}
+		pending = &Maneuver{Distance: d, Flag: turnFlag(angle)}
+		pendingAngle = angle
+	}
+
+	if pending != nil {
+		out = append(out, *pending)
+	}
+
+	return out
+}
+
+// pointAt returns the point at the given distance along the route
+func (r *Route) pointAt(d float64) Point {
+	if d <= 0 {
+		return r.Points[0]
+	} else if d >= r.Length() {
+		return r.Points[len(r.Points)-1]
+	}
+
+	i := r.segmentAt(d)
+	p1, p2 := r.Points[i], r.Points[i+1]
+	segLen := r.dists[i+1] - r.dists[i]
+	if segLen == 0 {
+		return p1
+	}
+
+	frac := (d - r.dists[i]) / segLen
+	return Point{
+		Lat: p1.Lat + (p2.Lat-p1.Lat)*frac,
+		Lon: p1.Lon + (p2.Lon-p1.Lon)*frac,
+	}
+}
+
+// segmentAt returns the index of the first point of
+// the segment at the given distance along the route
+func (r *Route) segmentAt(d float64) int {
+	for i := 1; i < len(r.dists); i++ {
+		if r.dists[i] >= d {
+			return i - 1
+		}
+	}
+	return len(r.dists) - 2
+}
+
+// ReplayOptions contains options for replaying a route
+type ReplayOptions struct {
+	// Speed is the rate at which the route is replayed,
+	// relative to real time. The default is 1.
+	Speed float64
+	// BaseSpeed is the travel speed in meters per second that's used
+	// if the route has no timestamps. The default is 50 km/h.
+	BaseSpeed float64
+	// Interval is how often navigation updates are sent.
+	// The default is one second.
+	Interval time.Duration
+}
+
+// Replay plays the route back in real time, calling fn with the navigation
+// state at every interval, until the end of the route is reached.
+func (r *Route) Replay(ctx context.Context, opts ReplayOptions, fn func(infinitime.NavState) error) error {
+	if opts.Speed <= 0 {
+		opts.Speed = 1
+	}
+	if opts.BaseSpeed <= 0 {
+		opts.BaseSpeed = 50 / 3.6
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+
+	maneuvers := r.Maneuvers()
+	offsets := r.timeOffsets(opts.BaseSpeed)
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	start := time.Now()
+	for {
+		elapsed := time.Duration(float64(time.Since(start)) * opts.Speed)
+		d := r.distanceAt(offsets, elapsed)
+
+		err := fn(r.StateAt(d, maneuvers))
+		if err != nil {
+			return err
+		}
+
+		if d >= r.Length() {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// StateAt returns the navigation state at the given distance along the route
+func (r *Route) StateAt(d float64, maneuvers []Maneuver) infinitime.NavState {
+	next := maneuvers[len(maneuvers)-1]
+	for _, m := range maneuvers {
+		if m.Distance >= d {
+			next = m
+			break
+		}
+	}
+
+	progress := 100.0
+	if r.Length() > 0 {
+		progress = min(d/r.Length()*100, 100)
+	}
+
+	return infinitime.NavState{
+		Flag:      next.Flag,
+		Narrative: next.Narrative,
+		ManDist:   formatDistance(max(next.Distance-d, 0)),
+		Progress:  uint8(progress),
+	}
+}
+
+// timeOffsets returns the time at which each point is reached, relative to
+// the start of the route. If every point has a timestamp, those are used.
+// Otherwise, the times are calculated using the given speed.
+func (r *Route) timeOffsets(speed float64) []time.Duration {
+	out := make([]time.Duration, len(r.Points))
+
+	useTimes := true
+	for i, p := range r.Points {
+		if p.Time.IsZero() || (i > 0 && p.Time.Before(r.Points[i-1].Time)) {
+			useTimes = false
+			break
+		}
+	}
+
+	for i, p := range r.Points {
+		if useTimes {
+			out[i] = p.Time.Sub(r.Points[0].Time)
+		} else {
+			out[i] = time.Duration(r.dists[i] / speed * float64(time.Second))
+		}
+	}
+
+	return out
+}
+
+// distanceAt returns the distance along the route at the given time offset
+func (r *Route) distanceAt(offsets []time.Duration, t time.Duration) float64 {
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i] < t {
+			continue
+		}
+
+		span := offsets[i] - offsets[i-1]
+		if span == 0 {
+			return r.dists[i]
+		}
+		frac := float64(t-offsets[i-1]) / float64(span)
+		return r.dists[i-1] + (r.dists[i]-r.dists[i-1])*frac
+	}
+	return r.Length()
+}
+
+// turnFlag returns the navigation flag for a change in direction of
+// the given angle. Positive angles are clockwise, i.e. to the right.
+func turnFlag(angle float64) infinitime.NavFlag {
+	right := angle > 0
+	switch abs := math.Abs(angle); {
+	case abs > 170:
+		return infinitime.NavFlagUTurn
+	case abs > 135:
+		if right {
+			return infinitime.NavFlagTurnSharpRight
+		}
+		return infinitime.NavFlagTurnSharpLeft
+	case abs > 60:
+		if right {
+			return infinitime.NavFlagTurnRight
+		}
+		return infinitime.NavFlagTurnLeft
+	default:
+		if right {
+			return infinitime.NavFlagTurnSlightRight
+		}
+		return infinitime.NavFlagTurnSlightLeft
+	}
+}
+
+// narrative generates a description of a maneuver from its flag,
+// such as "Turn slight left" for turn-slight-left.
+func narrative(flag infinitime.NavFlag) string {
+	if flag == "" {
+		return ""
+	}
+	s := strings.ReplaceAll(string(flag), "-", " ")
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// formatDistance formats a distance in meters the way
+// routing apps usually display it
+func formatDistance(d float64) string {
+	if d < 1000 {
+		return fmt.Sprintf("%d m", int(math.Round(d/10)*10))
+	}
+	return fmt.Sprintf("%.1f km", d/1000)
+}
+
+// distance returns the great-circle distance between two points in meters
+func distance(p1, p2 Point) float64 {
+	lat1, lat2 := radians(p1.Lat), radians(p2.Lat)
+	dLat := lat2 - lat1
+	dLon := radians(p2.Lon - p1.Lon)
+
+	a := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// bearing returns the initial bearing from p1 to p2 in degrees
+func bearing(p1, p2 Point) float64 {
+	lat1, lat2 := radians(p1.Lat), radians(p2.Lat)
+	dLon := radians(p2.Lon - p1.Lon)
+
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	return math.Mod(degrees(math.Atan2(y, x))+360, 360)
+}
+
+// normalizeAngle normalizes an angle in degrees to the range (-180, 180]
+func normalizeAngle(a float64) float64 {
+	a = math.Mod(a, 360)
+	if a > 180 {
+		a -= 360
+	} else if a <= -180 {
+		a += 360
+	}
+	return a
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
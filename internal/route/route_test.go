@@ -0,0 +1,84 @@
+package route
+
+import (
+	"strings"
+	"testing"
+
+	"go.elara.ws/itd/infinitime"
+)
+
+const testGPX = `<?xml version="1.0"?>
+<gpx version="1.1" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk><trkseg>
+    <trkpt lat="0" lon="0"></trkpt>
+    <trkpt lat="0.005" lon="0"></trkpt>
+    <trkpt lat="0.01" lon="0"></trkpt>
+    <trkpt lat="0.01" lon="0.005"></trkpt>
+    <trkpt lat="0.01" lon="0.01"></trkpt>
+    <trkpt lat="0.015" lon="0.01"></trkpt>
+  </trkseg></trk>
+</gpx>`
+
+func TestGPXManeuvers(t *testing.T) {
+	r, err := ParseGPX(strings.NewReader(testGPX))
+	if err != nil {
+		t.Fatalf("Error parsing gpx: %s", err)
+	}
+
+	expected := []infinitime.NavFlag{
+		infinitime.NavFlagTurnRight,
+		infinitime.NavFlagTurnLeft,
+		infinitime.NavFlagArrive,
+	}
+
+	maneuvers := r.Maneuvers()
+	if len(maneuvers) != len(expected) {
+		t.Fatalf("Expected %d maneuvers, got %d: %v", len(expected), len(maneuvers), maneuvers)
+	}
+
+	for i, m := range maneuvers {
+		if m.Flag != expected[i] {
+			t.Errorf("Maneuver %d: expected %s, got %s", i, expected[i], m.Flag)
+		}
+	}
+
+	state := r.StateAt(1000, maneuvers)
+	if state.Flag != infinitime.NavFlagTurnRight || state.ManDist != "110 m" {
+		t.Errorf("Unexpected state at 1000m: %+v", state)
+	}
+}
+
+const testGeoJSON = `{
+  "type": "FeatureCollection",
+  "features": [
+    {
+      "type": "Feature",
+      "geometry": {"type": "LineString", "coordinates": [[0, 0], [0, 0.01], [0.01, 0.01]]}
+    },
+    {
+      "type": "Feature",
+      "properties": {"maneuver": "turn-right", "narrative": "Turn right onto Main St"},
+      "geometry": {"type": "Point", "coordinates": [0.0001, 0.0099]}
+    }
+  ]
+}`
+
+func TestGeoJSONAnnotations(t *testing.T) {
+	r, err := ParseGeoJSON(strings.NewReader(testGeoJSON))
+	if err != nil {
+		t.Fatalf("Error parsing geojson: %s", err)
+	}
+
+	maneuvers := r.Maneuvers()
+	if len(maneuvers) != 2 {
+		t.Fatalf("Expected 2 maneuvers, got %d: %v", len(maneuvers), maneuvers)
+	}
+
+	if maneuvers[0].Flag != infinitime.NavFlagTurnRight || maneuvers[0].Narrative != "Turn right onto Main St" {
+		t.Errorf("Unexpected first maneuver: %+v", maneuvers[0])
+	}
+
+	if maneuvers[1].Flag != infinitime.NavFlagArrive || maneuvers[1].Narrative != "Arrive" {
+		t.Errorf("Unexpected last maneuver: %+v", maneuvers[1])
+	}
+}
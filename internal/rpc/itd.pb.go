@@ -63,7 +63,117 @@ func (x FirmwareUpgradeRequest_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use FirmwareUpgradeRequest_Type.Descriptor instead.
 func (FirmwareUpgradeRequest_Type) EnumDescriptor() ([]byte, []int) {
-	return file_itd_proto_rawDescGZIP(), []int{6, 0}
+	return file_itd_proto_rawDescGZIP(), []int{7, 0}
+}
+
+type DFUProgress_Stage int32
+
+const (
+	DFUProgress_Transfer DFUProgress_Stage = 0
+	DFUProgress_Reboot   DFUProgress_Stage = 1
+	DFUProgress_Done     DFUProgress_Stage = 2
+)
+
+// Enum value maps for DFUProgress_Stage.
+var (
+	DFUProgress_Stage_name = map[int32]string{
+		0: "Transfer",
+		1: "Reboot",
+		2: "Done",
+	}
+	DFUProgress_Stage_value = map[string]int32{
+		"Transfer": 0,
+		"Reboot":   1,
+		"Done":     2,
+	}
+)
+
+func (x DFUProgress_Stage) Enum() *DFUProgress_Stage {
+	p := new(DFUProgress_Stage)
+	*p = x
+	return p
+}
+
+func (x DFUProgress_Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DFUProgress_Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_itd_proto_enumTypes[1].Descriptor()
+}
+
+func (DFUProgress_Stage) Type() protoreflect.EnumType {
+	return &file_itd_proto_enumTypes[1]
+}
+
+func (x DFUProgress_Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DFUProgress_Stage.Descriptor instead.
+func (DFUProgress_Stage) EnumDescriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{8, 0}
+}
+
+type FirmwareUpdateProgress_Stage int32
+
+const (
+	FirmwareUpdateProgress_Check     FirmwareUpdateProgress_Stage = 0
+	FirmwareUpdateProgress_UpToDate  FirmwareUpdateProgress_Stage = 1
+	FirmwareUpdateProgress_Download  FirmwareUpdateProgress_Stage = 2
+	FirmwareUpdateProgress_Resources FirmwareUpdateProgress_Stage = 3
+	FirmwareUpdateProgress_Firmware  FirmwareUpdateProgress_Stage = 4
+	FirmwareUpdateProgress_Done      FirmwareUpdateProgress_Stage = 5
+	FirmwareUpdateProgress_Reboot    FirmwareUpdateProgress_Stage = 6
+)
+
+// Enum value maps for FirmwareUpdateProgress_Stage.
+var (
+	FirmwareUpdateProgress_Stage_name = map[int32]string{
+		0: "Check",
+		1: "UpToDate",
+		2: "Download",
+		3: "Resources",
+		4: "Firmware",
+		5: "Done",
+		6: "Reboot",
+	}
+	FirmwareUpdateProgress_Stage_value = map[string]int32{
+		"Check":     0,
+		"UpToDate":  1,
+		"Download":  2,
+		"Resources": 3,
+		"Firmware":  4,
+		"Done":      5,
+		"Reboot":    6,
+	}
+)
+
+func (x FirmwareUpdateProgress_Stage) Enum() *FirmwareUpdateProgress_Stage {
+	p := new(FirmwareUpdateProgress_Stage)
+	*p = x
+	return p
+}
+
+func (x FirmwareUpdateProgress_Stage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FirmwareUpdateProgress_Stage) Descriptor() protoreflect.EnumDescriptor {
+	return file_itd_proto_enumTypes[2].Descriptor()
+}
+
+func (FirmwareUpdateProgress_Stage) Type() protoreflect.EnumType {
+	return &file_itd_proto_enumTypes[2]
+}
+
+func (x FirmwareUpdateProgress_Stage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FirmwareUpdateProgress_Stage.Descriptor instead.
+func (FirmwareUpdateProgress_Stage) EnumDescriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{10, 0}
 }
 
 type ResourceLoadProgress_Operation int32
@@ -71,6 +181,7 @@ type ResourceLoadProgress_Operation int32
 const (
 	ResourceLoadProgress_Upload         ResourceLoadProgress_Operation = 0
 	ResourceLoadProgress_RemoveObsolete ResourceLoadProgress_Operation = 1
+	ResourceLoadProgress_Skip           ResourceLoadProgress_Operation = 2
 )
 
 // Enum value maps for ResourceLoadProgress_Operation.
@@ -78,10 +189,12 @@ var (
 	ResourceLoadProgress_Operation_name = map[int32]string{
 		0: "Upload",
 		1: "RemoveObsolete",
+		2: "Skip",
 	}
 	ResourceLoadProgress_Operation_value = map[string]int32{
 		"Upload":         0,
 		"RemoveObsolete": 1,
+		"Skip":           2,
 	}
 )
 
@@ -96,11 +209,11 @@ func (x ResourceLoadProgress_Operation) String() string {
 }
 
 func (ResourceLoadProgress_Operation) Descriptor() protoreflect.EnumDescriptor {
-	return file_itd_proto_enumTypes[1].Descriptor()
+	return file_itd_proto_enumTypes[3].Descriptor()
 }
 
 func (ResourceLoadProgress_Operation) Type() protoreflect.EnumType {
-	return &file_itd_proto_enumTypes[1]
+	return &file_itd_proto_enumTypes[3]
 }
 
 func (x ResourceLoadProgress_Operation) Number() protoreflect.EnumNumber {
@@ -109,7 +222,59 @@ func (x ResourceLoadProgress_Operation) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ResourceLoadProgress_Operation.Descriptor instead.
 func (ResourceLoadProgress_Operation) EnumDescriptor() ([]byte, []int) {
-	return file_itd_proto_rawDescGZIP(), []int{15, 0}
+	return file_itd_proto_rawDescGZIP(), []int{21, 0}
+}
+
+type JobInfo_State int32
+
+const (
+	JobInfo_Running   JobInfo_State = 0
+	JobInfo_Done      JobInfo_State = 1
+	JobInfo_Failed    JobInfo_State = 2
+	JobInfo_Cancelled JobInfo_State = 3
+)
+
+// Enum value maps for JobInfo_State.
+var (
+	JobInfo_State_name = map[int32]string{
+		0: "Running",
+		1: "Done",
+		2: "Failed",
+		3: "Cancelled",
+	}
+	JobInfo_State_value = map[string]int32{
+		"Running":   0,
+		"Done":      1,
+		"Failed":    2,
+		"Cancelled": 3,
+	}
+)
+
+func (x JobInfo_State) Enum() *JobInfo_State {
+	p := new(JobInfo_State)
+	*p = x
+	return p
+}
+
+func (x JobInfo_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobInfo_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_itd_proto_enumTypes[4].Descriptor()
+}
+
+func (JobInfo_State) Type() protoreflect.EnumType {
+	return &file_itd_proto_enumTypes[4]
+}
+
+func (x JobInfo_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobInfo_State.Descriptor instead.
+func (JobInfo_State) EnumDescriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{27, 0}
 }
 
 type Empty struct {
@@ -409,17 +574,17 @@ func (x *SetTimeRequest) GetUnixNano() int64 {
 	return 0
 }
 
-type FirmwareUpgradeRequest struct {
+type TimeResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type  FirmwareUpgradeRequest_Type `protobuf:"varint,1,opt,name=type,proto3,enum=rpc.FirmwareUpgradeRequest_Type" json:"type,omitempty"`
-	Files []string                    `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	UnixNano  int64 `protobuf:"varint,1,opt,name=unix_nano,json=unixNano,proto3" json:"unix_nano,omitempty"`
+	DriftNano int64 `protobuf:"varint,2,opt,name=drift_nano,json=driftNano,proto3" json:"drift_nano,omitempty"`
 }
 
-func (x *FirmwareUpgradeRequest) Reset() {
-	*x = FirmwareUpgradeRequest{}
+func (x *TimeResponse) Reset() {
+	*x = TimeResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_itd_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -427,13 +592,13 @@ func (x *FirmwareUpgradeRequest) Reset() {
 	}
 }
 
-func (x *FirmwareUpgradeRequest) String() string {
+func (x *TimeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FirmwareUpgradeRequest) ProtoMessage() {}
+func (*TimeResponse) ProtoMessage() {}
 
-func (x *FirmwareUpgradeRequest) ProtoReflect() protoreflect.Message {
+func (x *TimeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_itd_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -445,37 +610,37 @@ func (x *FirmwareUpgradeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FirmwareUpgradeRequest.ProtoReflect.Descriptor instead.
-func (*FirmwareUpgradeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TimeResponse.ProtoReflect.Descriptor instead.
+func (*TimeResponse) Descriptor() ([]byte, []int) {
 	return file_itd_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *FirmwareUpgradeRequest) GetType() FirmwareUpgradeRequest_Type {
+func (x *TimeResponse) GetUnixNano() int64 {
 	if x != nil {
-		return x.Type
+		return x.UnixNano
 	}
-	return FirmwareUpgradeRequest_Archive
+	return 0
 }
 
-func (x *FirmwareUpgradeRequest) GetFiles() []string {
+func (x *TimeResponse) GetDriftNano() int64 {
 	if x != nil {
-		return x.Files
+		return x.DriftNano
 	}
-	return nil
+	return 0
 }
 
-type DFUProgress struct {
+type FirmwareUpgradeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sent     int64 `protobuf:"varint,1,opt,name=sent,proto3" json:"sent,omitempty"`
-	Recieved int64 `protobuf:"varint,2,opt,name=recieved,proto3" json:"recieved,omitempty"`
-	Total    int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Type   FirmwareUpgradeRequest_Type `protobuf:"varint,1,opt,name=type,proto3,enum=rpc.FirmwareUpgradeRequest_Type" json:"type,omitempty"`
+	Files  []string                    `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	Detach bool                        `protobuf:"varint,3,opt,name=detach,proto3" json:"detach,omitempty"`
 }
 
-func (x *DFUProgress) Reset() {
-	*x = DFUProgress{}
+func (x *FirmwareUpgradeRequest) Reset() {
+	*x = FirmwareUpgradeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_itd_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -483,13 +648,13 @@ func (x *DFUProgress) Reset() {
 	}
 }
 
-func (x *DFUProgress) String() string {
+func (x *FirmwareUpgradeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DFUProgress) ProtoMessage() {}
+func (*FirmwareUpgradeRequest) ProtoMessage() {}
 
-func (x *DFUProgress) ProtoReflect() protoreflect.Message {
+func (x *FirmwareUpgradeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_itd_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -501,42 +666,48 @@ func (x *DFUProgress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DFUProgress.ProtoReflect.Descriptor instead.
-func (*DFUProgress) Descriptor() ([]byte, []int) {
+// Deprecated: Use FirmwareUpgradeRequest.ProtoReflect.Descriptor instead.
+func (*FirmwareUpgradeRequest) Descriptor() ([]byte, []int) {
 	return file_itd_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *DFUProgress) GetSent() int64 {
+func (x *FirmwareUpgradeRequest) GetType() FirmwareUpgradeRequest_Type {
 	if x != nil {
-		return x.Sent
+		return x.Type
 	}
-	return 0
+	return FirmwareUpgradeRequest_Archive
 }
 
-func (x *DFUProgress) GetRecieved() int64 {
+func (x *FirmwareUpgradeRequest) GetFiles() []string {
 	if x != nil {
-		return x.Recieved
+		return x.Files
 	}
-	return 0
+	return nil
 }
 
-func (x *DFUProgress) GetTotal() int64 {
+func (x *FirmwareUpgradeRequest) GetDetach() bool {
 	if x != nil {
-		return x.Total
+		return x.Detach
 	}
-	return 0
+	return false
 }
 
-type PathRequest struct {
+type DFUProgress struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Sent            int64             `protobuf:"varint,1,opt,name=sent,proto3" json:"sent,omitempty"`
+	Recieved        int64             `protobuf:"varint,2,opt,name=recieved,proto3" json:"recieved,omitempty"`
+	Total           int64             `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Stage           DFUProgress_Stage `protobuf:"varint,4,opt,name=stage,proto3,enum=rpc.DFUProgress_Stage" json:"stage,omitempty"`
+	PreviousVersion string            `protobuf:"bytes,5,opt,name=previous_version,json=previousVersion,proto3" json:"previous_version,omitempty"`
+	Version         string            `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	JobId           uint64            `protobuf:"varint,7,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 }
 
-func (x *PathRequest) Reset() {
-	*x = PathRequest{}
+func (x *DFUProgress) Reset() {
+	*x = DFUProgress{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_itd_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -544,13 +715,13 @@ func (x *PathRequest) Reset() {
 	}
 }
 
-func (x *PathRequest) String() string {
+func (x *DFUProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PathRequest) ProtoMessage() {}
+func (*DFUProgress) ProtoMessage() {}
 
-func (x *PathRequest) ProtoReflect() protoreflect.Message {
+func (x *DFUProgress) ProtoReflect() protoreflect.Message {
 	mi := &file_itd_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -562,91 +733,90 @@ func (x *PathRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PathRequest.ProtoReflect.Descriptor instead.
-func (*PathRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DFUProgress.ProtoReflect.Descriptor instead.
+func (*DFUProgress) Descriptor() ([]byte, []int) {
 	return file_itd_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *PathRequest) GetPath() string {
+func (x *DFUProgress) GetSent() int64 {
 	if x != nil {
-		return x.Path
+		return x.Sent
 	}
-	return ""
+	return 0
 }
 
-type PathsRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Paths []string `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+func (x *DFUProgress) GetRecieved() int64 {
+	if x != nil {
+		return x.Recieved
+	}
+	return 0
 }
 
-func (x *PathsRequest) Reset() {
-	*x = PathsRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_itd_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *DFUProgress) GetTotal() int64 {
+	if x != nil {
+		return x.Total
 	}
+	return 0
 }
 
-func (x *PathsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *DFUProgress) GetStage() DFUProgress_Stage {
+	if x != nil {
+		return x.Stage
+	}
+	return DFUProgress_Transfer
 }
 
-func (*PathsRequest) ProtoMessage() {}
-
-func (x *PathsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_itd_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *DFUProgress) GetPreviousVersion() string {
+	if x != nil {
+		return x.PreviousVersion
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use PathsRequest.ProtoReflect.Descriptor instead.
-func (*PathsRequest) Descriptor() ([]byte, []int) {
-	return file_itd_proto_rawDescGZIP(), []int{9}
+func (x *DFUProgress) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
 }
 
-func (x *PathsRequest) GetPaths() []string {
+func (x *DFUProgress) GetJobId() uint64 {
 	if x != nil {
-		return x.Paths
+		return x.JobId
 	}
-	return nil
+	return 0
 }
 
-type RenameRequest struct {
+type FirmwareUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Catalogue     string `protobuf:"bytes,1,opt,name=catalogue,proto3" json:"catalogue,omitempty"`
+	Version       string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Force         bool   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	SkipResources bool   `protobuf:"varint,4,opt,name=skip_resources,json=skipResources,proto3" json:"skip_resources,omitempty"`
+	Check         bool   `protobuf:"varint,5,opt,name=check,proto3" json:"check,omitempty"`
+	Detach        bool   `protobuf:"varint,6,opt,name=detach,proto3" json:"detach,omitempty"`
 }
 
-func (x *RenameRequest) Reset() {
-	*x = RenameRequest{}
+func (x *FirmwareUpdateRequest) Reset() {
+	*x = FirmwareUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itd_proto_msgTypes[10]
+		mi := &file_itd_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RenameRequest) String() string {
+func (x *FirmwareUpdateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RenameRequest) ProtoMessage() {}
+func (*FirmwareUpdateRequest) ProtoMessage() {}
 
-func (x *RenameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_itd_proto_msgTypes[10]
+func (x *FirmwareUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -657,51 +827,84 @@ func (x *RenameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RenameRequest.ProtoReflect.Descriptor instead.
-func (*RenameRequest) Descriptor() ([]byte, []int) {
-	return file_itd_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use FirmwareUpdateRequest.ProtoReflect.Descriptor instead.
+func (*FirmwareUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *RenameRequest) GetFrom() string {
+func (x *FirmwareUpdateRequest) GetCatalogue() string {
 	if x != nil {
-		return x.From
+		return x.Catalogue
 	}
 	return ""
 }
 
-func (x *RenameRequest) GetTo() string {
+func (x *FirmwareUpdateRequest) GetVersion() string {
 	if x != nil {
-		return x.To
+		return x.Version
 	}
 	return ""
 }
 
-type TransferRequest struct {
+func (x *FirmwareUpdateRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *FirmwareUpdateRequest) GetSkipResources() bool {
+	if x != nil {
+		return x.SkipResources
+	}
+	return false
+}
+
+func (x *FirmwareUpdateRequest) GetCheck() bool {
+	if x != nil {
+		return x.Check
+	}
+	return false
+}
+
+func (x *FirmwareUpdateRequest) GetDetach() bool {
+	if x != nil {
+		return x.Detach
+	}
+	return false
+}
+
+type FirmwareUpdateProgress struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Source      string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
-	Destination string `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Stage          FirmwareUpdateProgress_Stage `protobuf:"varint,1,opt,name=stage,proto3,enum=rpc.FirmwareUpdateProgress_Stage" json:"stage,omitempty"`
+	CurrentVersion string                       `protobuf:"bytes,2,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"`
+	TargetVersion  string                       `protobuf:"bytes,3,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
+	Name           string                       `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Sent           int64                        `protobuf:"varint,5,opt,name=sent,proto3" json:"sent,omitempty"`
+	Total          int64                        `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	JobId          uint64                       `protobuf:"varint,7,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
 }
 
-func (x *TransferRequest) Reset() {
-	*x = TransferRequest{}
+func (x *FirmwareUpdateProgress) Reset() {
+	*x = FirmwareUpdateProgress{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itd_proto_msgTypes[11]
+		mi := &file_itd_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TransferRequest) String() string {
+func (x *FirmwareUpdateProgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TransferRequest) ProtoMessage() {}
+func (*FirmwareUpdateProgress) ProtoMessage() {}
 
-func (x *TransferRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_itd_proto_msgTypes[11]
+func (x *FirmwareUpdateProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -712,37 +915,129 @@ func (x *TransferRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TransferRequest.ProtoReflect.Descriptor instead.
-func (*TransferRequest) Descriptor() ([]byte, []int) {
-	return file_itd_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use FirmwareUpdateProgress.ProtoReflect.Descriptor instead.
+func (*FirmwareUpdateProgress) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *TransferRequest) GetSource() string {
+func (x *FirmwareUpdateProgress) GetStage() FirmwareUpdateProgress_Stage {
 	if x != nil {
-		return x.Source
+		return x.Stage
 	}
-	return ""
+	return FirmwareUpdateProgress_Check
 }
 
-func (x *TransferRequest) GetDestination() string {
+func (x *FirmwareUpdateProgress) GetCurrentVersion() string {
 	if x != nil {
-		return x.Destination
+		return x.CurrentVersion
 	}
 	return ""
 }
 
-type FileInfo struct {
+func (x *FirmwareUpdateProgress) GetTargetVersion() string {
+	if x != nil {
+		return x.TargetVersion
+	}
+	return ""
+}
+
+func (x *FirmwareUpdateProgress) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FirmwareUpdateProgress) GetSent() int64 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *FirmwareUpdateProgress) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *FirmwareUpdateProgress) GetJobId() uint64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+type FirmwareValidateReminderResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Size  int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
-	IsDir bool   `protobuf:"varint,3,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *FileInfo) Reset() {
-	*x = FileInfo{}
+func (x *FirmwareValidateReminderResponse) Reset() {
+	*x = FirmwareValidateReminderResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FirmwareValidateReminderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FirmwareValidateReminderResponse) ProtoMessage() {}
+
+func (x *FirmwareValidateReminderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FirmwareValidateReminderResponse.ProtoReflect.Descriptor instead.
+func (*FirmwareValidateReminderResponse) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FirmwareValidateReminderResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type CapabilitiesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Notifications bool `protobuf:"varint,1,opt,name=notifications,proto3" json:"notifications,omitempty"`
+	CallControl   bool `protobuf:"varint,2,opt,name=call_control,json=callControl,proto3" json:"call_control,omitempty"`
+	Time          bool `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	LocalTime     bool `protobuf:"varint,4,opt,name=local_time,json=localTime,proto3" json:"local_time,omitempty"`
+	Battery       bool `protobuf:"varint,5,opt,name=battery,proto3" json:"battery,omitempty"`
+	HeartRate     bool `protobuf:"varint,6,opt,name=heart_rate,json=heartRate,proto3" json:"heart_rate,omitempty"`
+	StepCount     bool `protobuf:"varint,7,opt,name=step_count,json=stepCount,proto3" json:"step_count,omitempty"`
+	Motion        bool `protobuf:"varint,8,opt,name=motion,proto3" json:"motion,omitempty"`
+	Music         bool `protobuf:"varint,9,opt,name=music,proto3" json:"music,omitempty"`
+	Navigation    bool `protobuf:"varint,10,opt,name=navigation,proto3" json:"navigation,omitempty"`
+	Weather       bool `protobuf:"varint,11,opt,name=weather,proto3" json:"weather,omitempty"`
+	Fs            bool `protobuf:"varint,12,opt,name=fs,proto3" json:"fs,omitempty"`
+	Dfu           bool `protobuf:"varint,13,opt,name=dfu,proto3" json:"dfu,omitempty"`
+}
+
+func (x *CapabilitiesResponse) Reset() {
+	*x = CapabilitiesResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_itd_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -750,13 +1045,13 @@ func (x *FileInfo) Reset() {
 	}
 }
 
-func (x *FileInfo) String() string {
+func (x *CapabilitiesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileInfo) ProtoMessage() {}
+func (*CapabilitiesResponse) ProtoMessage() {}
 
-func (x *FileInfo) ProtoReflect() protoreflect.Message {
+func (x *CapabilitiesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_itd_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -768,42 +1063,112 @@ func (x *FileInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
-func (*FileInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use CapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*CapabilitiesResponse) Descriptor() ([]byte, []int) {
 	return file_itd_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *FileInfo) GetName() string {
+func (x *CapabilitiesResponse) GetNotifications() bool {
 	if x != nil {
-		return x.Name
+		return x.Notifications
 	}
-	return ""
+	return false
 }
 
-func (x *FileInfo) GetSize() int64 {
+func (x *CapabilitiesResponse) GetCallControl() bool {
 	if x != nil {
-		return x.Size
+		return x.CallControl
 	}
-	return 0
+	return false
 }
 
-func (x *FileInfo) GetIsDir() bool {
+func (x *CapabilitiesResponse) GetTime() bool {
 	if x != nil {
-		return x.IsDir
+		return x.Time
 	}
 	return false
 }
 
-type DirResponse struct {
+func (x *CapabilitiesResponse) GetLocalTime() bool {
+	if x != nil {
+		return x.LocalTime
+	}
+	return false
+}
+
+func (x *CapabilitiesResponse) GetBattery() bool {
+	if x != nil {
+		return x.Battery
+	}
+	return false
+}
+
+func (x *CapabilitiesResponse) GetHeartRate() bool {
+	if x != nil {
+		return x.HeartRate
+	}
+	return false
+}
+
+func (x *CapabilitiesResponse) GetStepCount() bool {
+	if x != nil {
+		return x.StepCount
+	}
+	return false
+}
+
+func (x *CapabilitiesResponse) GetMotion() bool {
+	if x != nil {
+		return x.Motion
+	}
+	return false
+}
+
+func (x *CapabilitiesResponse) GetMusic() bool {
+	if x != nil {
+		return x.Music
+	}
+	return false
+}
+
+func (x *CapabilitiesResponse) GetNavigation() bool {
+	if x != nil {
+		return x.Navigation
+	}
+	return false
+}
+
+func (x *CapabilitiesResponse) GetWeather() bool {
+	if x != nil {
+		return x.Weather
+	}
+	return false
+}
+
+func (x *CapabilitiesResponse) GetFs() bool {
+	if x != nil {
+		return x.Fs
+	}
+	return false
+}
+
+func (x *CapabilitiesResponse) GetDfu() bool {
+	if x != nil {
+		return x.Dfu
+	}
+	return false
+}
+
+type PathRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Entries []*FileInfo `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
 }
 
-func (x *DirResponse) Reset() {
-	*x = DirResponse{}
+func (x *PathRequest) Reset() {
+	*x = PathRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_itd_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -811,13 +1176,13 @@ func (x *DirResponse) Reset() {
 	}
 }
 
-func (x *DirResponse) String() string {
+func (x *PathRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DirResponse) ProtoMessage() {}
+func (*PathRequest) ProtoMessage() {}
 
-func (x *DirResponse) ProtoReflect() protoreflect.Message {
+func (x *PathRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_itd_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -826,47 +1191,1060 @@ func (x *DirResponse) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathRequest.ProtoReflect.Descriptor instead.
+func (*PathRequest) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PathRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type PathsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Paths []string `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+}
+
+func (x *PathsRequest) Reset() {
+	*x = PathsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PathsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathsRequest) ProtoMessage() {}
+
+func (x *PathsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathsRequest.ProtoReflect.Descriptor instead.
+func (*PathsRequest) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PathsRequest) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+type RenameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *RenameRequest) Reset() {
+	*x = RenameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameRequest) ProtoMessage() {}
+
+func (x *RenameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameRequest.ProtoReflect.Descriptor instead.
+func (*RenameRequest) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RenameRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *RenameRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type TransferRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source      string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Destination string `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	Detach      bool   `protobuf:"varint,3,opt,name=detach,proto3" json:"detach,omitempty"`
+}
+
+func (x *TransferRequest) Reset() {
+	*x = TransferRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferRequest) ProtoMessage() {}
+
+func (x *TransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferRequest.ProtoReflect.Descriptor instead.
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TransferRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetDetach() bool {
+	if x != nil {
+		return x.Detach
+	}
+	return false
+}
+
+type FileInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Size  int64  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	IsDir bool   `protobuf:"varint,3,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`
+}
+
+func (x *FileInfo) Reset() {
+	*x = FileInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfo) ProtoMessage() {}
+
+func (x *FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *FileInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FileInfo) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileInfo) GetIsDir() bool {
+	if x != nil {
+		return x.IsDir
+	}
+	return false
+}
+
+type DirResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Entries []*FileInfo `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+}
+
+func (x *DirResponse) Reset() {
+	*x = DirResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DirResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DirResponse) ProtoMessage() {}
+
+func (x *DirResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DirResponse.ProtoReflect.Descriptor instead.
+func (*DirResponse) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DirResponse) GetEntries() []*FileInfo {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type TransferProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sent  uint32 `protobuf:"varint,1,opt,name=sent,proto3" json:"sent,omitempty"`
+	Total uint32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	JobId uint64 `protobuf:"varint,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+}
+
+func (x *TransferProgress) Reset() {
+	*x = TransferProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransferProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferProgress) ProtoMessage() {}
+
+func (x *TransferProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferProgress.ProtoReflect.Descriptor instead.
+func (*TransferProgress) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TransferProgress) GetSent() uint32 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *TransferProgress) GetTotal() uint32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *TransferProgress) GetJobId() uint64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+type LoadResourcesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path   string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	DryRun bool   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Force  bool   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	Detach bool   `protobuf:"varint,4,opt,name=detach,proto3" json:"detach,omitempty"`
+}
+
+func (x *LoadResourcesRequest) Reset() {
+	*x = LoadResourcesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadResourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadResourcesRequest) ProtoMessage() {}
+
+func (x *LoadResourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadResourcesRequest.ProtoReflect.Descriptor instead.
+func (*LoadResourcesRequest) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LoadResourcesRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *LoadResourcesRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *LoadResourcesRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *LoadResourcesRequest) GetDetach() bool {
+	if x != nil {
+		return x.Detach
+	}
+	return false
+}
+
+type ResourceLoadProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string                         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Total     int64                          `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Sent      int64                          `protobuf:"varint,3,opt,name=sent,proto3" json:"sent,omitempty"`
+	Operation ResourceLoadProgress_Operation `protobuf:"varint,4,opt,name=operation,proto3,enum=rpc.ResourceLoadProgress_Operation" json:"operation,omitempty"`
+	JobId     uint64                         `protobuf:"varint,5,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+}
+
+func (x *ResourceLoadProgress) Reset() {
+	*x = ResourceLoadProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceLoadProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceLoadProgress) ProtoMessage() {}
+
+func (x *ResourceLoadProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceLoadProgress.ProtoReflect.Descriptor instead.
+func (*ResourceLoadProgress) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResourceLoadProgress) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ResourceLoadProgress) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ResourceLoadProgress) GetSent() int64 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+func (x *ResourceLoadProgress) GetOperation() ResourceLoadProgress_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return ResourceLoadProgress_Upload
+}
+
+func (x *ResourceLoadProgress) GetJobId() uint64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+type MusicMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Artist     *string `protobuf:"bytes,1,opt,name=artist,proto3,oneof" json:"artist,omitempty"`
+	Track      *string `protobuf:"bytes,2,opt,name=track,proto3,oneof" json:"track,omitempty"`
+	Album      *string `protobuf:"bytes,3,opt,name=album,proto3,oneof" json:"album,omitempty"`
+	LengthNano *int64  `protobuf:"varint,4,opt,name=length_nano,json=lengthNano,proto3,oneof" json:"length_nano,omitempty"`
+}
+
+func (x *MusicMetadata) Reset() {
+	*x = MusicMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MusicMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MusicMetadata) ProtoMessage() {}
+
+func (x *MusicMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MusicMetadata.ProtoReflect.Descriptor instead.
+func (*MusicMetadata) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MusicMetadata) GetArtist() string {
+	if x != nil && x.Artist != nil {
+		return *x.Artist
+	}
+	return ""
+}
+
+func (x *MusicMetadata) GetTrack() string {
+	if x != nil && x.Track != nil {
+		return *x.Track
+	}
+	return ""
+}
+
+func (x *MusicMetadata) GetAlbum() string {
+	if x != nil && x.Album != nil {
+		return *x.Album
+	}
+	return ""
+}
+
+func (x *MusicMetadata) GetLengthNano() int64 {
+	if x != nil && x.LengthNano != nil {
+		return *x.LengthNano
+	}
+	return 0
+}
+
+type MusicStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Playing      *bool    `protobuf:"varint,1,opt,name=playing,proto3,oneof" json:"playing,omitempty"`
+	PositionNano *int64   `protobuf:"varint,2,opt,name=position_nano,json=positionNano,proto3,oneof" json:"position_nano,omitempty"`
+	Rate         *float64 `protobuf:"fixed64,3,opt,name=rate,proto3,oneof" json:"rate,omitempty"`
+	Shuffle      *bool    `protobuf:"varint,4,opt,name=shuffle,proto3,oneof" json:"shuffle,omitempty"`
+	Repeat       *bool    `protobuf:"varint,5,opt,name=repeat,proto3,oneof" json:"repeat,omitempty"`
+}
+
+func (x *MusicStatus) Reset() {
+	*x = MusicStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MusicStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MusicStatus) ProtoMessage() {}
+
+func (x *MusicStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MusicStatus.ProtoReflect.Descriptor instead.
+func (*MusicStatus) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *MusicStatus) GetPlaying() bool {
+	if x != nil && x.Playing != nil {
+		return *x.Playing
+	}
+	return false
+}
+
+func (x *MusicStatus) GetPositionNano() int64 {
+	if x != nil && x.PositionNano != nil {
+		return *x.PositionNano
+	}
+	return 0
+}
+
+func (x *MusicStatus) GetRate() float64 {
+	if x != nil && x.Rate != nil {
+		return *x.Rate
+	}
+	return 0
+}
+
+func (x *MusicStatus) GetShuffle() bool {
+	if x != nil && x.Shuffle != nil {
+		return *x.Shuffle
+	}
+	return false
+}
+
+func (x *MusicStatus) GetRepeat() bool {
+	if x != nil && x.Repeat != nil {
+		return *x.Repeat
+	}
+	return false
+}
+
+type MusicState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *MusicMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Status   *MusicStatus   `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *MusicState) Reset() {
+	*x = MusicState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MusicState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MusicState) ProtoMessage() {}
+
+func (x *MusicState) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MusicState.ProtoReflect.Descriptor instead.
+func (*MusicState) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MusicState) GetMetadata() *MusicMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *MusicState) GetStatus() *MusicStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type MusicEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event uint32 `protobuf:"varint,1,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *MusicEvent) Reset() {
+	*x = MusicEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MusicEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MusicEvent) ProtoMessage() {}
+
+func (x *MusicEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MusicEvent.ProtoReflect.Descriptor instead.
+func (*MusicEvent) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MusicEvent) GetEvent() uint32 {
+	if x != nil {
+		return x.Event
+	}
+	return 0
+}
+
+type NavigationState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flag      string `protobuf:"bytes,1,opt,name=flag,proto3" json:"flag,omitempty"`
+	Narrative string `protobuf:"bytes,2,opt,name=narrative,proto3" json:"narrative,omitempty"`
+	ManDist   string `protobuf:"bytes,3,opt,name=man_dist,json=manDist,proto3" json:"man_dist,omitempty"`
+	Progress  uint32 `protobuf:"varint,4,opt,name=progress,proto3" json:"progress,omitempty"`
+}
+
+func (x *NavigationState) Reset() {
+	*x = NavigationState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NavigationState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NavigationState) ProtoMessage() {}
+
+func (x *NavigationState) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NavigationState.ProtoReflect.Descriptor instead.
+func (*NavigationState) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *NavigationState) GetFlag() string {
+	if x != nil {
+		return x.Flag
+	}
+	return ""
+}
+
+func (x *NavigationState) GetNarrative() string {
+	if x != nil {
+		return x.Narrative
+	}
+	return ""
+}
+
+func (x *NavigationState) GetManDist() string {
+	if x != nil {
+		return x.ManDist
+	}
+	return ""
+}
+
+func (x *NavigationState) GetProgress() uint32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+type JobInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              uint64        `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind            string        `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Description     string        `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	StartedUnixNano int64         `protobuf:"varint,4,opt,name=started_unix_nano,json=startedUnixNano,proto3" json:"started_unix_nano,omitempty"`
+	State           JobInfo_State `protobuf:"varint,5,opt,name=state,proto3,enum=rpc.JobInfo_State" json:"state,omitempty"`
+	Error           string        `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *JobInfo) Reset() {
+	*x = JobInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobInfo) ProtoMessage() {}
+
+func (x *JobInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobInfo.ProtoReflect.Descriptor instead.
+func (*JobInfo) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *JobInfo) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *JobInfo) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *JobInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *JobInfo) GetStartedUnixNano() int64 {
+	if x != nil {
+		return x.StartedUnixNano
+	}
+	return 0
+}
+
+func (x *JobInfo) GetState() JobInfo_State {
+	if x != nil {
+		return x.State
+	}
+	return JobInfo_Running
+}
+
+func (x *JobInfo) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type JobEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Info *JobInfo `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	// Types that are assignable to Progress:
+	//	*JobEvent_Dfu
+	//	*JobEvent_FirmwareUpdate
+	//	*JobEvent_Transfer
+	//	*JobEvent_Resources
+	Progress isJobEvent_Progress `protobuf_oneof:"progress"`
+}
+
+func (x *JobEvent) Reset() {
+	*x = JobEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itd_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobEvent) ProtoMessage() {}
+
+func (x *JobEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobEvent.ProtoReflect.Descriptor instead.
+func (*JobEvent) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *JobEvent) GetInfo() *JobInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (m *JobEvent) GetProgress() isJobEvent_Progress {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
+}
+
+func (x *JobEvent) GetDfu() *DFUProgress {
+	if x, ok := x.GetProgress().(*JobEvent_Dfu); ok {
+		return x.Dfu
+	}
+	return nil
+}
+
+func (x *JobEvent) GetFirmwareUpdate() *FirmwareUpdateProgress {
+	if x, ok := x.GetProgress().(*JobEvent_FirmwareUpdate); ok {
+		return x.FirmwareUpdate
+	}
+	return nil
 }
 
-// Deprecated: Use DirResponse.ProtoReflect.Descriptor instead.
-func (*DirResponse) Descriptor() ([]byte, []int) {
-	return file_itd_proto_rawDescGZIP(), []int{13}
+func (x *JobEvent) GetTransfer() *TransferProgress {
+	if x, ok := x.GetProgress().(*JobEvent_Transfer); ok {
+		return x.Transfer
+	}
+	return nil
 }
 
-func (x *DirResponse) GetEntries() []*FileInfo {
-	if x != nil {
-		return x.Entries
+func (x *JobEvent) GetResources() *ResourceLoadProgress {
+	if x, ok := x.GetProgress().(*JobEvent_Resources); ok {
+		return x.Resources
 	}
 	return nil
 }
 
-type TransferProgress struct {
+type isJobEvent_Progress interface {
+	isJobEvent_Progress()
+}
+
+type JobEvent_Dfu struct {
+	Dfu *DFUProgress `protobuf:"bytes,2,opt,name=dfu,proto3,oneof"`
+}
+
+type JobEvent_FirmwareUpdate struct {
+	FirmwareUpdate *FirmwareUpdateProgress `protobuf:"bytes,3,opt,name=firmware_update,json=firmwareUpdate,proto3,oneof"`
+}
+
+type JobEvent_Transfer struct {
+	Transfer *TransferProgress `protobuf:"bytes,4,opt,name=transfer,proto3,oneof"`
+}
+
+type JobEvent_Resources struct {
+	Resources *ResourceLoadProgress `protobuf:"bytes,5,opt,name=resources,proto3,oneof"`
+}
+
+func (*JobEvent_Dfu) isJobEvent_Progress() {}
+
+func (*JobEvent_FirmwareUpdate) isJobEvent_Progress() {}
+
+func (*JobEvent_Transfer) isJobEvent_Progress() {}
+
+func (*JobEvent_Resources) isJobEvent_Progress() {}
+
+type JobRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sent  uint32 `protobuf:"varint,1,opt,name=sent,proto3" json:"sent,omitempty"`
-	Total uint32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *TransferProgress) Reset() {
-	*x = TransferProgress{}
+func (x *JobRequest) Reset() {
+	*x = JobRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itd_proto_msgTypes[14]
+		mi := &file_itd_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TransferProgress) String() string {
+func (x *JobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TransferProgress) ProtoMessage() {}
+func (*JobRequest) ProtoMessage() {}
 
-func (x *TransferProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_itd_proto_msgTypes[14]
+func (x *JobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -877,53 +2255,43 @@ func (x *TransferProgress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TransferProgress.ProtoReflect.Descriptor instead.
-func (*TransferProgress) Descriptor() ([]byte, []int) {
-	return file_itd_proto_rawDescGZIP(), []int{14}
-}
-
-func (x *TransferProgress) GetSent() uint32 {
-	if x != nil {
-		return x.Sent
-	}
-	return 0
+// Deprecated: Use JobRequest.ProtoReflect.Descriptor instead.
+func (*JobRequest) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *TransferProgress) GetTotal() uint32 {
+func (x *JobRequest) GetId() uint64 {
 	if x != nil {
-		return x.Total
+		return x.Id
 	}
 	return 0
 }
 
-type ResourceLoadProgress struct {
+type JobList struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name      string                         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Total     int64                          `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Sent      int64                          `protobuf:"varint,3,opt,name=sent,proto3" json:"sent,omitempty"`
-	Operation ResourceLoadProgress_Operation `protobuf:"varint,4,opt,name=operation,proto3,enum=rpc.ResourceLoadProgress_Operation" json:"operation,omitempty"`
+	Jobs []*JobInfo `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
 }
 
-func (x *ResourceLoadProgress) Reset() {
-	*x = ResourceLoadProgress{}
+func (x *JobList) Reset() {
+	*x = JobList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itd_proto_msgTypes[15]
+		mi := &file_itd_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ResourceLoadProgress) String() string {
+func (x *JobList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResourceLoadProgress) ProtoMessage() {}
+func (*JobList) ProtoMessage() {}
 
-func (x *ResourceLoadProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_itd_proto_msgTypes[15]
+func (x *JobList) ProtoReflect() protoreflect.Message {
+	mi := &file_itd_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -934,37 +2302,16 @@ func (x *ResourceLoadProgress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResourceLoadProgress.ProtoReflect.Descriptor instead.
-func (*ResourceLoadProgress) Descriptor() ([]byte, []int) {
-	return file_itd_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *ResourceLoadProgress) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *ResourceLoadProgress) GetTotal() int64 {
-	if x != nil {
-		return x.Total
-	}
-	return 0
-}
-
-func (x *ResourceLoadProgress) GetSent() int64 {
-	if x != nil {
-		return x.Sent
-	}
-	return 0
+// Deprecated: Use JobList.ProtoReflect.Descriptor instead.
+func (*JobList) Descriptor() ([]byte, []int) {
+	return file_itd_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *ResourceLoadProgress) GetOperation() ResourceLoadProgress_Operation {
+func (x *JobList) GetJobs() []*JobInfo {
 	if x != nil {
-		return x.Operation
+		return x.Jobs
 	}
-	return ResourceLoadProgress_Upload
+	return nil
 }
 
 var File_itd_proto protoreflect.FileDescriptor
@@ -986,130 +2333,345 @@ var file_itd_proto_rawDesc = []byte{
 	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x2d, 0x0a,
 	0x0e, 0x53, 0x65, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x1b, 0x0a, 0x09, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x08, 0x75, 0x6e, 0x69, 0x78, 0x4e, 0x61, 0x6e, 0x6f, 0x22, 0x84, 0x01, 0x0a,
-	0x16, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x72, 0x6d,
-	0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69,
-	0x6c, 0x65, 0x73, 0x22, 0x1e, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x41,
-	0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x69, 0x6c, 0x65,
-	0x73, 0x10, 0x01, 0x22, 0x53, 0x0a, 0x0b, 0x44, 0x46, 0x55, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
-	0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x69, 0x65, 0x76,
-	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x63, 0x69, 0x65, 0x76,
-	0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x21, 0x0a, 0x0b, 0x50, 0x61, 0x74, 0x68,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x24, 0x0a, 0x0c, 0x50,
-	0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70,
-	0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68,
-	0x73, 0x22, 0x33, 0x0a, 0x0d, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x4b, 0x0a, 0x0f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x22, 0x49, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x64, 0x69,
-	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x44, 0x69, 0x72, 0x22, 0x36,
-	0x0a, 0x0b, 0x44, 0x69, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a,
-	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x65,
-	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x3c, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
-	0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65,
-	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x14,
-	0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x74,
-	0x6f, 0x74, 0x61, 0x6c, 0x22, 0xc4, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x4c, 0x6f, 0x61, 0x64, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x41, 0x0a, 0x09, 0x6f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x6f, 0x61,
-	0x64, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2b,
-	0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x0a, 0x06, 0x55,
-	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
-	0x65, 0x4f, 0x62, 0x73, 0x6f, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x01, 0x32, 0x9a, 0x05, 0x0a, 0x03,
-	0x49, 0x54, 0x44, 0x12, 0x29, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x52, 0x61, 0x74, 0x65,
-	0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x49, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30,
-	0x0a, 0x0e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x72, 0x74, 0x52, 0x61, 0x74, 0x65,
-	0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x49, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
-	0x12, 0x2c, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x4c, 0x65, 0x76, 0x65, 0x6c,
-	0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x49, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33,
-	0x0a, 0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x4c, 0x65,
-	0x76, 0x65, 0x6c, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x30, 0x01, 0x12, 0x29, 0x0a, 0x06, 0x4d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x4d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30,
-	0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x4d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
-	0x12, 0x29, 0x0a, 0x09, 0x53, 0x74, 0x65, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0a, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x49, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x0e, 0x57,
-	0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x65, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0a, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e,
-	0x49, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2a, 0x0a,
-	0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e,
-	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x1a, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x06, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x12,
-	0x12, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12,
-	0x2a, 0x0a, 0x07, 0x53, 0x65, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x13, 0x2e, 0x72, 0x70, 0x63,
-	0x2e, 0x53, 0x65, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x27, 0x0a, 0x0d, 0x57,
-	0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x0a, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x12, 0x42, 0x0a, 0x0f, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65,
-	0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x12, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69,
-	0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x46, 0x55, 0x50, 0x72,
-	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x30, 0x01, 0x32, 0xb9, 0x03, 0x0a, 0x02, 0x46, 0x53, 0x12,
-	0x2a, 0x0a, 0x09, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x6c, 0x6c, 0x12, 0x11, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x52,
-	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x61, 0x74, 0x68,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x12, 0x28, 0x0a, 0x06, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x29,
-	0x0a, 0x08, 0x4d, 0x6b, 0x64, 0x69, 0x72, 0x41, 0x6c, 0x6c, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63,
+	0x28, 0x03, 0x52, 0x08, 0x75, 0x6e, 0x69, 0x78, 0x4e, 0x61, 0x6e, 0x6f, 0x22, 0x4a, 0x0a, 0x0c,
+	0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x75, 0x6e, 0x69, 0x78, 0x4e, 0x61, 0x6e, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x72, 0x69,
+	0x66, 0x74, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64,
+	0x72, 0x69, 0x66, 0x74, 0x4e, 0x61, 0x6e, 0x6f, 0x22, 0x9c, 0x01, 0x0a, 0x16, 0x46, 0x69, 0x72,
+	0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x20, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65,
+	0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x22, 0x1e, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x0b, 0x0a, 0x07, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x10, 0x01, 0x22, 0x8a, 0x02, 0x0a, 0x0b, 0x44, 0x46, 0x55, 0x50,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x63, 0x69, 0x65, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72,
+	0x65, 0x63, 0x69, 0x65, 0x76, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2c, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x44, 0x46, 0x55, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x53,
+	0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x22, 0x2b, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x67, 0x65,
+	0x12, 0x0c, 0x0a, 0x08, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x10, 0x00, 0x12, 0x0a,
+	0x0a, 0x06, 0x52, 0x65, 0x62, 0x6f, 0x6f, 0x74, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x6f,
+	0x6e, 0x65, 0x10, 0x02, 0x22, 0xba, 0x01, 0x0a, 0x15, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72,
+	0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x63, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e,
+	0x73, 0x6b, 0x69, 0x70, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74,
+	0x61, 0x63, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x63,
+	0x68, 0x22, 0xd9, 0x02, 0x0a, 0x16, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x37, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25,
+	0x0a, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6e,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x22, 0x61, 0x0a, 0x05, 0x53, 0x74,
+	0x61, 0x67, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x10, 0x00, 0x12, 0x0c,
+	0x0a, 0x08, 0x55, 0x70, 0x54, 0x6f, 0x44, 0x61, 0x74, 0x65, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08,
+	0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x69, 0x72,
+	0x6d, 0x77, 0x61, 0x72, 0x65, 0x10, 0x04, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x6f, 0x6e, 0x65, 0x10,
+	0x05, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x65, 0x62, 0x6f, 0x6f, 0x74, 0x10, 0x06, 0x22, 0x3c, 0x0a,
+	0x20, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xf4, 0x02, 0x0a, 0x14,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x6e, 0x6f, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x61,
+	0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x65,
+	0x61, 0x72, 0x74, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x68, 0x65, 0x61, 0x72, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x65,
+	0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73,
+	0x74, 0x65, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x6f, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x75, 0x73, 0x69, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x6d, 0x75, 0x73, 0x69, 0x63, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x61, 0x76, 0x69, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6e, 0x61, 0x76, 0x69,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65,
+	0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x77, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72,
+	0x12, 0x0e, 0x0a, 0x02, 0x66, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x66, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x64, 0x66, 0x75, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x64,
+	0x66, 0x75, 0x22, 0x21, 0x0a, 0x0b, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x24, 0x0a, 0x0c, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0x33, 0x0a, 0x0d, 0x52,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f,
+	0x22, 0x63, 0x0a, 0x0f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64,
+	0x65, 0x74, 0x61, 0x63, 0x68, 0x22, 0x49, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f,
+	0x64, 0x69, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x44, 0x69, 0x72,
+	0x22, 0x36, 0x0a, 0x0b, 0x44, 0x69, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x27, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x53, 0x0a, 0x10, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x22, 0x71, 0x0a,
+	0x14, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79,
+	0x5f, 0x72, 0x75, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52,
+	0x75, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61,
+	0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x63, 0x68,
+	0x22, 0xe5, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x6f, 0x61,
+	0x64, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x41, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x6f, 0x61, 0x64, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f,
+	0x62, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49,
+	0x64, 0x22, 0x35, 0x0a, 0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0a,
+	0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x4f, 0x62, 0x73, 0x6f, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x01, 0x12, 0x08,
+	0x0a, 0x04, 0x53, 0x6b, 0x69, 0x70, 0x10, 0x02, 0x22, 0xb7, 0x01, 0x0a, 0x0d, 0x4d, 0x75, 0x73,
+	0x69, 0x63, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x06, 0x61, 0x72,
+	0x74, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x61, 0x72,
+	0x74, 0x69, 0x73, 0x74, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x05, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x88,
+	0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x02, 0x52, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a,
+	0x0b, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x48, 0x03, 0x52, 0x0a, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x4e, 0x61, 0x6e, 0x6f,
+	0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x42, 0x08,
+	0x0a, 0x06, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x61, 0x6c, 0x62,
+	0x75, 0x6d, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f, 0x6e, 0x61,
+	0x6e, 0x6f, 0x22, 0xe9, 0x01, 0x0a, 0x0b, 0x4d, 0x75, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x69, 0x6e, 0x67, 0x88, 0x01,
+	0x01, 0x12, 0x28, 0x0a, 0x0d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61,
+	0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x0c, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6e, 0x6f, 0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x04, 0x72,
+	0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x02, 0x52, 0x04, 0x72, 0x61, 0x74,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x73, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x03, 0x52, 0x07, 0x73, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65,
+	0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x04, 0x52, 0x06, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x88, 0x01, 0x01,
+	0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x69, 0x6e, 0x67, 0x42, 0x10, 0x0a, 0x0e,
+	0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x42, 0x07,
+	0x0a, 0x05, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x73, 0x68, 0x75, 0x66,
+	0x66, 0x6c, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x22, 0x66,
+	0x0a, 0x0a, 0x4d, 0x75, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2e, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x75, 0x73, 0x69, 0x63, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x4d, 0x75, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x22, 0x0a, 0x0a, 0x4d, 0x75, 0x73, 0x69, 0x63, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x7a, 0x0a, 0x0f, 0x4e, 0x61,
+	0x76, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x6c, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x6c, 0x61,
+	0x67, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x72, 0x72, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x72, 0x72, 0x61, 0x74, 0x69, 0x76, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x6d, 0x61, 0x6e, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x61, 0x6e, 0x44, 0x69, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x72,
+	0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0xf6, 0x01, 0x0a, 0x07, 0x4a, 0x6f, 0x62, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x65, 0x64, 0x5f, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x6e, 0x61, 0x6e, 0x6f, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x55, 0x6e, 0x69, 0x78,
+	0x4e, 0x61, 0x6e, 0x6f, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x66,
+	0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x22, 0x39, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a,
+	0x07, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x6f,
+	0x6e, 0x65, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x02,
+	0x12, 0x0d, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x10, 0x03, 0x22,
+	0x96, 0x02, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x04,
+	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x24,
+	0x0a, 0x03, 0x64, 0x66, 0x75, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x44, 0x46, 0x55, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52,
+	0x03, 0x64, 0x66, 0x75, 0x12, 0x46, 0x0a, 0x0f, 0x66, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65,
+	0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x0e, 0x66, 0x69,
+	0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x33, 0x0a, 0x08,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x08, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x12, 0x39, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x4c, 0x6f, 0x61, 0x64, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x48,
+	0x00, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08,
+	0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x22, 0x1c, 0x0a, 0x0a, 0x4a, 0x6f, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2b, 0x0a, 0x07, 0x4a, 0x6f, 0x62, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x20, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x6a,
+	0x6f, 0x62, 0x73, 0x32, 0x97, 0x07, 0x0a, 0x03, 0x49, 0x54, 0x44, 0x12, 0x29, 0x0a, 0x09, 0x48,
+	0x65, 0x61, 0x72, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x0e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x48,
+	0x65, 0x61, 0x72, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2c, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x79, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x42,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x0a, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x29, 0x0a, 0x06, 0x4d,
+	0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d,
+	0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x29, 0x0a, 0x09, 0x53, 0x74, 0x65, 0x70,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x0e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x65, 0x70,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x2a, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2a, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x0a, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a,
+	0x0c, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x0a, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28,
+	0x0a, 0x06, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x12, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4e,
+	0x6f, 0x74, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2a, 0x0a, 0x07, 0x53, 0x65, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x27, 0x0a, 0x0d, 0x57, 0x65, 0x61, 0x74, 0x68, 0x65, 0x72, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x42, 0x0a,
+	0x0f, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x12, 0x1b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55,
+	0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x44, 0x46, 0x55, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x30,
+	0x01, 0x12, 0x4b, 0x0a, 0x0e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61,
+	0x72, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x30, 0x01, 0x12, 0x4d,
+	0x0a, 0x18, 0x46, 0x69, 0x72, 0x6d, 0x77, 0x61, 0x72, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x6d, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x25, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x46, 0x69, 0x72,
+	0x6d, 0x77, 0x61, 0x72, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6d,
+	0x69, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xc2, 0x03,
+	0x0a, 0x02, 0x46, 0x53, 0x12, 0x2a, 0x0a, 0x09, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x41, 0x6c,
+	0x6c, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x12, 0x27, 0x0a, 0x06, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63,
 	0x2e, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x26, 0x0a, 0x05, 0x4d, 0x6b, 0x64,
-	0x69, 0x72, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x12, 0x2d, 0x0a, 0x07, 0x52, 0x65, 0x61, 0x64, 0x44, 0x69, 0x72, 0x12, 0x10, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x69, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x37, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x14, 0x2e, 0x72, 0x70, 0x63,
+	0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x28, 0x0a, 0x06, 0x52, 0x65, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x08, 0x4d, 0x6b, 0x64, 0x69, 0x72, 0x41, 0x6c, 0x6c, 0x12,
+	0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x26,
+	0x0a, 0x05, 0x4d, 0x6b, 0x64, 0x69, 0x72, 0x12, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x61,
+	0x74, 0x68, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2d, 0x0a, 0x07, 0x52, 0x65, 0x61, 0x64, 0x44, 0x69,
+	0x72, 0x12, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x69, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x14, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x30, 0x01, 0x12, 0x39,
+	0x0a, 0x08, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x14, 0x2e, 0x72, 0x70, 0x63,
 	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x1a, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x50,
-	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x08, 0x44, 0x6f, 0x77,
-	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e,
-	0x73, 0x66, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x72, 0x70,
-	0x63, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
-	0x73, 0x73, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x0d, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x61, 0x74, 0x68,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x6f, 0x61, 0x64, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
-	0x73, 0x73, 0x30, 0x01, 0x42, 0x20, 0x5a, 0x1e, 0x67, 0x6f, 0x2e, 0x61, 0x72, 0x73, 0x65, 0x6e,
-	0x6d, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x69, 0x74, 0x64, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x30, 0x01, 0x12, 0x47, 0x0a, 0x0d, 0x4c, 0x6f, 0x61,
+	0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x4c, 0x6f, 0x61, 0x64, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x30, 0x01, 0x32, 0xbd, 0x01, 0x0a, 0x05, 0x4d, 0x75, 0x73, 0x69, 0x63, 0x12, 0x2d, 0x0a, 0x0b,
+	0x53, 0x65, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x4d, 0x75, 0x73, 0x69, 0x63, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x1a,
+	0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x09, 0x53,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d,
+	0x75, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x27, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0f,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x75, 0x73, 0x69, 0x63, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x31, 0x0a, 0x10, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x75, 0x73, 0x69, 0x63, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x0f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x75, 0x73, 0x69, 0x63, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x30, 0x01, 0x32, 0x5c, 0x0a, 0x0a, 0x4e, 0x61, 0x76, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x2a, 0x0a, 0x06, 0x53, 0x65, 0x74, 0x4e, 0x61, 0x76, 0x12, 0x14, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x4e, 0x61, 0x76, 0x69, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x22, 0x0a, 0x08,
+	0x43, 0x6c, 0x65, 0x61, 0x72, 0x4e, 0x61, 0x76, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x32, 0x84, 0x01, 0x0a, 0x04, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x24, 0x0a, 0x08, 0x4c, 0x69, 0x73,
+	0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x28, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x12, 0x0f, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0a, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2c, 0x0a, 0x08, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x4a, 0x6f, 0x62, 0x12, 0x0f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x6f, 0x62,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x20, 0x5a, 0x1e, 0x67, 0x6f, 0x2e, 0x61, 0x72,
+	0x73, 0x65, 0x6e, 0x6d, 0x2e, 0x64, 0x65, 0x76, 0x2f, 0x69, 0x74, 0x64, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -1124,83 +2686,138 @@ func file_itd_proto_rawDescGZIP() []byte {
 	return file_itd_proto_rawDescData
 }
 
-var file_itd_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_itd_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_itd_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_itd_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_itd_proto_goTypes = []interface{}{
-	(FirmwareUpgradeRequest_Type)(0),    // 0: rpc.FirmwareUpgradeRequest.Type
-	(ResourceLoadProgress_Operation)(0), // 1: rpc.ResourceLoadProgress.Operation
-	(*Empty)(nil),                       // 2: rpc.Empty
-	(*IntResponse)(nil),                 // 3: rpc.IntResponse
-	(*StringResponse)(nil),              // 4: rpc.StringResponse
-	(*MotionResponse)(nil),              // 5: rpc.MotionResponse
-	(*NotifyRequest)(nil),               // 6: rpc.NotifyRequest
-	(*SetTimeRequest)(nil),              // 7: rpc.SetTimeRequest
-	(*FirmwareUpgradeRequest)(nil),      // 8: rpc.FirmwareUpgradeRequest
-	(*DFUProgress)(nil),                 // 9: rpc.DFUProgress
-	(*PathRequest)(nil),                 // 10: rpc.PathRequest
-	(*PathsRequest)(nil),                // 11: rpc.PathsRequest
-	(*RenameRequest)(nil),               // 12: rpc.RenameRequest
-	(*TransferRequest)(nil),             // 13: rpc.TransferRequest
-	(*FileInfo)(nil),                    // 14: rpc.FileInfo
-	(*DirResponse)(nil),                 // 15: rpc.DirResponse
-	(*TransferProgress)(nil),            // 16: rpc.TransferProgress
-	(*ResourceLoadProgress)(nil),        // 17: rpc.ResourceLoadProgress
+	(FirmwareUpgradeRequest_Type)(0),         // 0: rpc.FirmwareUpgradeRequest.Type
+	(DFUProgress_Stage)(0),                   // 1: rpc.DFUProgress.Stage
+	(FirmwareUpdateProgress_Stage)(0),        // 2: rpc.FirmwareUpdateProgress.Stage
+	(ResourceLoadProgress_Operation)(0),      // 3: rpc.ResourceLoadProgress.Operation
+	(JobInfo_State)(0),                       // 4: rpc.JobInfo.State
+	(*Empty)(nil),                            // 5: rpc.Empty
+	(*IntResponse)(nil),                      // 6: rpc.IntResponse
+	(*StringResponse)(nil),                   // 7: rpc.StringResponse
+	(*MotionResponse)(nil),                   // 8: rpc.MotionResponse
+	(*NotifyRequest)(nil),                    // 9: rpc.NotifyRequest
+	(*SetTimeRequest)(nil),                   // 10: rpc.SetTimeRequest
+	(*TimeResponse)(nil),                     // 11: rpc.TimeResponse
+	(*FirmwareUpgradeRequest)(nil),           // 12: rpc.FirmwareUpgradeRequest
+	(*DFUProgress)(nil),                      // 13: rpc.DFUProgress
+	(*FirmwareUpdateRequest)(nil),            // 14: rpc.FirmwareUpdateRequest
+	(*FirmwareUpdateProgress)(nil),           // 15: rpc.FirmwareUpdateProgress
+	(*FirmwareValidateReminderResponse)(nil), // 16: rpc.FirmwareValidateReminderResponse
+	(*CapabilitiesResponse)(nil),             // 17: rpc.CapabilitiesResponse
+	(*PathRequest)(nil),                      // 18: rpc.PathRequest
+	(*PathsRequest)(nil),                     // 19: rpc.PathsRequest
+	(*RenameRequest)(nil),                    // 20: rpc.RenameRequest
+	(*TransferRequest)(nil),                  // 21: rpc.TransferRequest
+	(*FileInfo)(nil),                         // 22: rpc.FileInfo
+	(*DirResponse)(nil),                      // 23: rpc.DirResponse
+	(*TransferProgress)(nil),                 // 24: rpc.TransferProgress
+	(*LoadResourcesRequest)(nil),             // 25: rpc.LoadResourcesRequest
+	(*ResourceLoadProgress)(nil),             // 26: rpc.ResourceLoadProgress
+	(*MusicMetadata)(nil),                    // 27: rpc.MusicMetadata
+	(*MusicStatus)(nil),                      // 28: rpc.MusicStatus
+	(*MusicState)(nil),                       // 29: rpc.MusicState
+	(*MusicEvent)(nil),                       // 30: rpc.MusicEvent
+	(*NavigationState)(nil),                  // 31: rpc.NavigationState
+	(*JobInfo)(nil),                          // 32: rpc.JobInfo
+	(*JobEvent)(nil),                         // 33: rpc.JobEvent
+	(*JobRequest)(nil),                       // 34: rpc.JobRequest
+	(*JobList)(nil),                          // 35: rpc.JobList
 }
 var file_itd_proto_depIdxs = []int32{
 	0,  // 0: rpc.FirmwareUpgradeRequest.type:type_name -> rpc.FirmwareUpgradeRequest.Type
-	14, // 1: rpc.DirResponse.entries:type_name -> rpc.FileInfo
-	1,  // 2: rpc.ResourceLoadProgress.operation:type_name -> rpc.ResourceLoadProgress.Operation
-	2,  // 3: rpc.ITD.HeartRate:input_type -> rpc.Empty
-	2,  // 4: rpc.ITD.WatchHeartRate:input_type -> rpc.Empty
-	2,  // 5: rpc.ITD.BatteryLevel:input_type -> rpc.Empty
-	2,  // 6: rpc.ITD.WatchBatteryLevel:input_type -> rpc.Empty
-	2,  // 7: rpc.ITD.Motion:input_type -> rpc.Empty
-	2,  // 8: rpc.ITD.WatchMotion:input_type -> rpc.Empty
-	2,  // 9: rpc.ITD.StepCount:input_type -> rpc.Empty
-	2,  // 10: rpc.ITD.WatchStepCount:input_type -> rpc.Empty
-	2,  // 11: rpc.ITD.Version:input_type -> rpc.Empty
-	2,  // 12: rpc.ITD.Address:input_type -> rpc.Empty
-	6,  // 13: rpc.ITD.Notify:input_type -> rpc.NotifyRequest
-	7,  // 14: rpc.ITD.SetTime:input_type -> rpc.SetTimeRequest
-	2,  // 15: rpc.ITD.WeatherUpdate:input_type -> rpc.Empty
-	8,  // 16: rpc.ITD.FirmwareUpgrade:input_type -> rpc.FirmwareUpgradeRequest
-	11, // 17: rpc.FS.RemoveAll:input_type -> rpc.PathsRequest
-	11, // 18: rpc.FS.Remove:input_type -> rpc.PathsRequest
-	12, // 19: rpc.FS.Rename:input_type -> rpc.RenameRequest
-	11, // 20: rpc.FS.MkdirAll:input_type -> rpc.PathsRequest
-	11, // 21: rpc.FS.Mkdir:input_type -> rpc.PathsRequest
-	10, // 22: rpc.FS.ReadDir:input_type -> rpc.PathRequest
-	13, // 23: rpc.FS.Upload:input_type -> rpc.TransferRequest
-	13, // 24: rpc.FS.Download:input_type -> rpc.TransferRequest
-	10, // 25: rpc.FS.LoadResources:input_type -> rpc.PathRequest
-	3,  // 26: rpc.ITD.HeartRate:output_type -> rpc.IntResponse
-	3,  // 27: rpc.ITD.WatchHeartRate:output_type -> rpc.IntResponse
-	3,  // 28: rpc.ITD.BatteryLevel:output_type -> rpc.IntResponse
-	3,  // 29: rpc.ITD.WatchBatteryLevel:output_type -> rpc.IntResponse
-	5,  // 30: rpc.ITD.Motion:output_type -> rpc.MotionResponse
-	5,  // 31: rpc.ITD.WatchMotion:output_type -> rpc.MotionResponse
-	3,  // 32: rpc.ITD.StepCount:output_type -> rpc.IntResponse
-	3,  // 33: rpc.ITD.WatchStepCount:output_type -> rpc.IntResponse
-	4,  // 34: rpc.ITD.Version:output_type -> rpc.StringResponse
-	4,  // 35: rpc.ITD.Address:output_type -> rpc.StringResponse
-	2,  // 36: rpc.ITD.Notify:output_type -> rpc.Empty
-	2,  // 37: rpc.ITD.SetTime:output_type -> rpc.Empty
-	2,  // 38: rpc.ITD.WeatherUpdate:output_type -> rpc.Empty
-	9,  // 39: rpc.ITD.FirmwareUpgrade:output_type -> rpc.DFUProgress
-	2,  // 40: rpc.FS.RemoveAll:output_type -> rpc.Empty
-	2,  // 41: rpc.FS.Remove:output_type -> rpc.Empty
-	2,  // 42: rpc.FS.Rename:output_type -> rpc.Empty
-	2,  // 43: rpc.FS.MkdirAll:output_type -> rpc.Empty
-	2,  // 44: rpc.FS.Mkdir:output_type -> rpc.Empty
-	15, // 45: rpc.FS.ReadDir:output_type -> rpc.DirResponse
-	16, // 46: rpc.FS.Upload:output_type -> rpc.TransferProgress
-	16, // 47: rpc.FS.Download:output_type -> rpc.TransferProgress
-	17, // 48: rpc.FS.LoadResources:output_type -> rpc.ResourceLoadProgress
-	26, // [26:49] is the sub-list for method output_type
-	3,  // [3:26] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	1,  // 1: rpc.DFUProgress.stage:type_name -> rpc.DFUProgress.Stage
+	2,  // 2: rpc.FirmwareUpdateProgress.stage:type_name -> rpc.FirmwareUpdateProgress.Stage
+	22, // 3: rpc.DirResponse.entries:type_name -> rpc.FileInfo
+	3,  // 4: rpc.ResourceLoadProgress.operation:type_name -> rpc.ResourceLoadProgress.Operation
+	27, // 5: rpc.MusicState.metadata:type_name -> rpc.MusicMetadata
+	28, // 6: rpc.MusicState.status:type_name -> rpc.MusicStatus
+	4,  // 7: rpc.JobInfo.state:type_name -> rpc.JobInfo.State
+	32, // 8: rpc.JobEvent.info:type_name -> rpc.JobInfo
+	13, // 9: rpc.JobEvent.dfu:type_name -> rpc.DFUProgress
+	15, // 10: rpc.JobEvent.firmware_update:type_name -> rpc.FirmwareUpdateProgress
+	24, // 11: rpc.JobEvent.transfer:type_name -> rpc.TransferProgress
+	26, // 12: rpc.JobEvent.resources:type_name -> rpc.ResourceLoadProgress
+	32, // 13: rpc.JobList.jobs:type_name -> rpc.JobInfo
+	5,  // 14: rpc.ITD.HeartRate:input_type -> rpc.Empty
+	5,  // 15: rpc.ITD.WatchHeartRate:input_type -> rpc.Empty
+	5,  // 16: rpc.ITD.BatteryLevel:input_type -> rpc.Empty
+	5,  // 17: rpc.ITD.WatchBatteryLevel:input_type -> rpc.Empty
+	5,  // 18: rpc.ITD.Motion:input_type -> rpc.Empty
+	5,  // 19: rpc.ITD.WatchMotion:input_type -> rpc.Empty
+	5,  // 20: rpc.ITD.StepCount:input_type -> rpc.Empty
+	5,  // 21: rpc.ITD.WatchStepCount:input_type -> rpc.Empty
+	5,  // 22: rpc.ITD.Version:input_type -> rpc.Empty
+	5,  // 23: rpc.ITD.Address:input_type -> rpc.Empty
+	5,  // 24: rpc.ITD.Capabilities:input_type -> rpc.Empty
+	5,  // 25: rpc.ITD.GetTime:input_type -> rpc.Empty
+	9,  // 26: rpc.ITD.Notify:input_type -> rpc.NotifyRequest
+	10, // 27: rpc.ITD.SetTime:input_type -> rpc.SetTimeRequest
+	5,  // 28: rpc.ITD.WeatherUpdate:input_type -> rpc.Empty
+	12, // 29: rpc.ITD.FirmwareUpgrade:input_type -> rpc.FirmwareUpgradeRequest
+	14, // 30: rpc.ITD.FirmwareUpdate:input_type -> rpc.FirmwareUpdateRequest
+	5,  // 31: rpc.ITD.FirmwareValidateReminder:input_type -> rpc.Empty
+	19, // 32: rpc.FS.RemoveAll:input_type -> rpc.PathsRequest
+	19, // 33: rpc.FS.Remove:input_type -> rpc.PathsRequest
+	20, // 34: rpc.FS.Rename:input_type -> rpc.RenameRequest
+	19, // 35: rpc.FS.MkdirAll:input_type -> rpc.PathsRequest
+	19, // 36: rpc.FS.Mkdir:input_type -> rpc.PathsRequest
+	18, // 37: rpc.FS.ReadDir:input_type -> rpc.PathRequest
+	21, // 38: rpc.FS.Upload:input_type -> rpc.TransferRequest
+	21, // 39: rpc.FS.Download:input_type -> rpc.TransferRequest
+	25, // 40: rpc.FS.LoadResources:input_type -> rpc.LoadResourcesRequest
+	27, // 41: rpc.Music.SetMetadata:input_type -> rpc.MusicMetadata
+	28, // 42: rpc.Music.SetStatus:input_type -> rpc.MusicStatus
+	5,  // 43: rpc.Music.GetState:input_type -> rpc.Empty
+	5,  // 44: rpc.Music.WatchMusicEvents:input_type -> rpc.Empty
+	31, // 45: rpc.Navigation.SetNav:input_type -> rpc.NavigationState
+	5,  // 46: rpc.Navigation.ClearNav:input_type -> rpc.Empty
+	5,  // 47: rpc.Jobs.ListJobs:input_type -> rpc.Empty
+	34, // 48: rpc.Jobs.CancelJob:input_type -> rpc.JobRequest
+	34, // 49: rpc.Jobs.WatchJob:input_type -> rpc.JobRequest
+	6,  // 50: rpc.ITD.HeartRate:output_type -> rpc.IntResponse
+	6,  // 51: rpc.ITD.WatchHeartRate:output_type -> rpc.IntResponse
+	6,  // 52: rpc.ITD.BatteryLevel:output_type -> rpc.IntResponse
+	6,  // 53: rpc.ITD.WatchBatteryLevel:output_type -> rpc.IntResponse
+	8,  // 54: rpc.ITD.Motion:output_type -> rpc.MotionResponse
+	8,  // 55: rpc.ITD.WatchMotion:output_type -> rpc.MotionResponse
+	6,  // 56: rpc.ITD.StepCount:output_type -> rpc.IntResponse
+	6,  // 57: rpc.ITD.WatchStepCount:output_type -> rpc.IntResponse
+	7,  // 58: rpc.ITD.Version:output_type -> rpc.StringResponse
+	7,  // 59: rpc.ITD.Address:output_type -> rpc.StringResponse
+	17, // 60: rpc.ITD.Capabilities:output_type -> rpc.CapabilitiesResponse
+	11, // 61: rpc.ITD.GetTime:output_type -> rpc.TimeResponse
+	5,  // 62: rpc.ITD.Notify:output_type -> rpc.Empty
+	5,  // 63: rpc.ITD.SetTime:output_type -> rpc.Empty
+	5,  // 64: rpc.ITD.WeatherUpdate:output_type -> rpc.Empty
+	13, // 65: rpc.ITD.FirmwareUpgrade:output_type -> rpc.DFUProgress
+	15, // 66: rpc.ITD.FirmwareUpdate:output_type -> rpc.FirmwareUpdateProgress
+	16, // 67: rpc.ITD.FirmwareValidateReminder:output_type -> rpc.FirmwareValidateReminderResponse
+	5,  // 68: rpc.FS.RemoveAll:output_type -> rpc.Empty
+	5,  // 69: rpc.FS.Remove:output_type -> rpc.Empty
+	5,  // 70: rpc.FS.Rename:output_type -> rpc.Empty
+	5,  // 71: rpc.FS.MkdirAll:output_type -> rpc.Empty
+	5,  // 72: rpc.FS.Mkdir:output_type -> rpc.Empty
+	23, // 73: rpc.FS.ReadDir:output_type -> rpc.DirResponse
+	24, // 74: rpc.FS.Upload:output_type -> rpc.TransferProgress
+	24, // 75: rpc.FS.Download:output_type -> rpc.TransferProgress
+	26, // 76: rpc.FS.LoadResources:output_type -> rpc.ResourceLoadProgress
+	5,  // 77: rpc.Music.SetMetadata:output_type -> rpc.Empty
+	5,  // 78: rpc.Music.SetStatus:output_type -> rpc.Empty
+	29, // 79: rpc.Music.GetState:output_type -> rpc.MusicState
+	30, // 80: rpc.Music.WatchMusicEvents:output_type -> rpc.MusicEvent
+	5,  // 81: rpc.Navigation.SetNav:output_type -> rpc.Empty
+	5,  // 82: rpc.Navigation.ClearNav:output_type -> rpc.Empty
+	35, // 83: rpc.Jobs.ListJobs:output_type -> rpc.JobList
+	5,  // 84: rpc.Jobs.CancelJob:output_type -> rpc.Empty
+	33, // 85: rpc.Jobs.WatchJob:output_type -> rpc.JobEvent
+	50, // [50:86] is the sub-list for method output_type
+	14, // [14:50] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_itd_proto_init() }
@@ -1282,7 +2899,7 @@ func file_itd_proto_init() {
 			}
 		}
 		file_itd_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FirmwareUpgradeRequest); i {
+			switch v := v.(*TimeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1294,7 +2911,7 @@ func file_itd_proto_init() {
 			}
 		}
 		file_itd_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DFUProgress); i {
+			switch v := v.(*FirmwareUpgradeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1306,7 +2923,7 @@ func file_itd_proto_init() {
 			}
 		}
 		file_itd_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PathRequest); i {
+			switch v := v.(*DFUProgress); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1318,7 +2935,7 @@ func file_itd_proto_init() {
 			}
 		}
 		file_itd_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PathsRequest); i {
+			switch v := v.(*FirmwareUpdateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1330,7 +2947,7 @@ func file_itd_proto_init() {
 			}
 		}
 		file_itd_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RenameRequest); i {
+			switch v := v.(*FirmwareUpdateProgress); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1342,7 +2959,7 @@ func file_itd_proto_init() {
 			}
 		}
 		file_itd_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransferRequest); i {
+			switch v := v.(*FirmwareValidateReminderResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1354,7 +2971,7 @@ func file_itd_proto_init() {
 			}
 		}
 		file_itd_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileInfo); i {
+			switch v := v.(*CapabilitiesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1366,7 +2983,7 @@ func file_itd_proto_init() {
 			}
 		}
 		file_itd_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DirResponse); i {
+			switch v := v.(*PathRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1378,7 +2995,7 @@ func file_itd_proto_init() {
 			}
 		}
 		file_itd_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransferProgress); i {
+			switch v := v.(*PathsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1390,6 +3007,78 @@ func file_itd_proto_init() {
 			}
 		}
 		file_itd_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RenameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FileInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DirResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransferProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadResourcesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResourceLoadProgress); i {
 			case 0:
 				return &v.state
@@ -1401,16 +3090,132 @@ func file_itd_proto_init() {
 				return nil
 			}
 		}
+		file_itd_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MusicMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MusicStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MusicState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MusicEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NavigationState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itd_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JobList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_itd_proto_msgTypes[22].OneofWrappers = []interface{}{}
+	file_itd_proto_msgTypes[23].OneofWrappers = []interface{}{}
+	file_itd_proto_msgTypes[28].OneofWrappers = []interface{}{
+		(*JobEvent_Dfu)(nil),
+		(*JobEvent_FirmwareUpdate)(nil),
+		(*JobEvent_Transfer)(nil),
+		(*JobEvent_Resources)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_itd_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   16,
+			NumEnums:      5,
+			NumMessages:   31,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   5,
 		},
 		GoTypes:           file_itd_proto_goTypes,
 		DependencyIndexes: file_itd_proto_depIdxs,
@@ -48,10 +48,14 @@ type DRPCITDClient interface {
 	WatchStepCount(ctx context.Context, in *Empty) (DRPCITD_WatchStepCountClient, error)
 	Version(ctx context.Context, in *Empty) (*StringResponse, error)
 	Address(ctx context.Context, in *Empty) (*StringResponse, error)
+	Capabilities(ctx context.Context, in *Empty) (*CapabilitiesResponse, error)
+	GetTime(ctx context.Context, in *Empty) (*TimeResponse, error)
 	Notify(ctx context.Context, in *NotifyRequest) (*Empty, error)
 	SetTime(ctx context.Context, in *SetTimeRequest) (*Empty, error)
 	WeatherUpdate(ctx context.Context, in *Empty) (*Empty, error)
 	FirmwareUpgrade(ctx context.Context, in *FirmwareUpgradeRequest) (DRPCITD_FirmwareUpgradeClient, error)
+	FirmwareUpdate(ctx context.Context, in *FirmwareUpdateRequest) (DRPCITD_FirmwareUpdateClient, error)
+	FirmwareValidateReminder(ctx context.Context, in *Empty) (*FirmwareValidateReminderResponse, error)
 }
 
 type drpcITDClient struct {
@@ -262,6 +266,24 @@ func (c *drpcITDClient) Address(ctx context.Context, in *Empty) (*StringResponse
 	return out, nil
 }
 
+func (c *drpcITDClient) Capabilities(ctx context.Context, in *Empty) (*CapabilitiesResponse, error) {
+	out := new(CapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/rpc.ITD/Capabilities", drpcEncoding_File_itd_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcITDClient) GetTime(ctx context.Context, in *Empty) (*TimeResponse, error) {
+	out := new(TimeResponse)
+	err := c.cc.Invoke(ctx, "/rpc.ITD/GetTime", drpcEncoding_File_itd_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *drpcITDClient) Notify(ctx context.Context, in *NotifyRequest) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, "/rpc.ITD/Notify", drpcEncoding_File_itd_proto{}, in, out)
@@ -325,6 +347,51 @@ func (x *drpcITD_FirmwareUpgradeClient) RecvMsg(m *DFUProgress) error {
 	return x.MsgRecv(m, drpcEncoding_File_itd_proto{})
 }
 
+func (c *drpcITDClient) FirmwareUpdate(ctx context.Context, in *FirmwareUpdateRequest) (DRPCITD_FirmwareUpdateClient, error) {
+	stream, err := c.cc.NewStream(ctx, "/rpc.ITD/FirmwareUpdate", drpcEncoding_File_itd_proto{})
+	if err != nil {
+		return nil, err
+	}
+	x := &drpcITD_FirmwareUpdateClient{stream}
+	if err := x.MsgSend(in, drpcEncoding_File_itd_proto{}); err != nil {
+		return nil, err
+	}
+	if err := x.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DRPCITD_FirmwareUpdateClient interface {
+	drpc.Stream
+	Recv() (*FirmwareUpdateProgress, error)
+}
+
+type drpcITD_FirmwareUpdateClient struct {
+	drpc.Stream
+}
+
+func (x *drpcITD_FirmwareUpdateClient) Recv() (*FirmwareUpdateProgress, error) {
+	m := new(FirmwareUpdateProgress)
+	if err := x.MsgRecv(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (x *drpcITD_FirmwareUpdateClient) RecvMsg(m *FirmwareUpdateProgress) error {
+	return x.MsgRecv(m, drpcEncoding_File_itd_proto{})
+}
+
+func (c *drpcITDClient) FirmwareValidateReminder(ctx context.Context, in *Empty) (*FirmwareValidateReminderResponse, error) {
+	out := new(FirmwareValidateReminderResponse)
+	err := c.cc.Invoke(ctx, "/rpc.ITD/FirmwareValidateReminder", drpcEncoding_File_itd_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 type DRPCITDServer interface {
 	HeartRate(context.Context, *Empty) (*IntResponse, error)
 	WatchHeartRate(*Empty, DRPCITD_WatchHeartRateStream) error
@@ -336,10 +403,14 @@ type DRPCITDServer interface {
 	WatchStepCount(*Empty, DRPCITD_WatchStepCountStream) error
 	Version(context.Context, *Empty) (*StringResponse, error)
 	Address(context.Context, *Empty) (*StringResponse, error)
+	Capabilities(context.Context, *Empty) (*CapabilitiesResponse, error)
+	GetTime(context.Context, *Empty) (*TimeResponse, error)
 	Notify(context.Context, *NotifyRequest) (*Empty, error)
 	SetTime(context.Context, *SetTimeRequest) (*Empty, error)
 	WeatherUpdate(context.Context, *Empty) (*Empty, error)
 	FirmwareUpgrade(*FirmwareUpgradeRequest, DRPCITD_FirmwareUpgradeStream) error
+	FirmwareUpdate(*FirmwareUpdateRequest, DRPCITD_FirmwareUpdateStream) error
+	FirmwareValidateReminder(context.Context, *Empty) (*FirmwareValidateReminderResponse, error)
 }
 
 type DRPCITDUnimplementedServer struct{}
@@ -384,6 +455,14 @@ func (s *DRPCITDUnimplementedServer) Address(context.Context, *Empty) (*StringRe
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
 }
 
+func (s *DRPCITDUnimplementedServer) Capabilities(context.Context, *Empty) (*CapabilitiesResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCITDUnimplementedServer) GetTime(context.Context, *Empty) (*TimeResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
 func (s *DRPCITDUnimplementedServer) Notify(context.Context, *NotifyRequest) (*Empty, error) {
 	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
 }
@@ -400,9 +479,17 @@ func (s *DRPCITDUnimplementedServer) FirmwareUpgrade(*FirmwareUpgradeRequest, DR
 	return drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
 }
 
+func (s *DRPCITDUnimplementedServer) FirmwareUpdate(*FirmwareUpdateRequest, DRPCITD_FirmwareUpdateStream) error {
+	return drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCITDUnimplementedServer) FirmwareValidateReminder(context.Context, *Empty) (*FirmwareValidateReminderResponse, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
 type DRPCITDDescription struct{}
 
-func (DRPCITDDescription) NumMethods() int { return 14 }
+func (DRPCITDDescription) NumMethods() int { return 18 }
 
 func (DRPCITDDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
 	switch n {
@@ -497,6 +584,24 @@ func (DRPCITDDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, i
 					)
 			}, DRPCITDServer.Address, true
 	case 10:
+		return "/rpc.ITD/Capabilities", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCITDServer).
+					Capabilities(
+						ctx,
+						in1.(*Empty),
+					)
+			}, DRPCITDServer.Capabilities, true
+	case 11:
+		return "/rpc.ITD/GetTime", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCITDServer).
+					GetTime(
+						ctx,
+						in1.(*Empty),
+					)
+			}, DRPCITDServer.GetTime, true
+	case 12:
 		return "/rpc.ITD/Notify", drpcEncoding_File_itd_proto{},
 			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
 				return srv.(DRPCITDServer).
@@ -505,7 +610,7 @@ func (DRPCITDDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, i
 						in1.(*NotifyRequest),
 					)
 			}, DRPCITDServer.Notify, true
-	case 11:
+	case 13:
 		return "/rpc.ITD/SetTime", drpcEncoding_File_itd_proto{},
 			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
 				return srv.(DRPCITDServer).
@@ -514,7 +619,7 @@ func (DRPCITDDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, i
 						in1.(*SetTimeRequest),
 					)
 			}, DRPCITDServer.SetTime, true
-	case 12:
+	case 14:
 		return "/rpc.ITD/WeatherUpdate", drpcEncoding_File_itd_proto{},
 			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
 				return srv.(DRPCITDServer).
@@ -523,7 +628,7 @@ func (DRPCITDDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, i
 						in1.(*Empty),
 					)
 			}, DRPCITDServer.WeatherUpdate, true
-	case 13:
+	case 15:
 		return "/rpc.ITD/FirmwareUpgrade", drpcEncoding_File_itd_proto{},
 			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
 				return nil, srv.(DRPCITDServer).
@@ -532,6 +637,24 @@ func (DRPCITDDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, i
 						&drpcITD_FirmwareUpgradeStream{in2.(drpc.Stream)},
 					)
 			}, DRPCITDServer.FirmwareUpgrade, true
+	case 16:
+		return "/rpc.ITD/FirmwareUpdate", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return nil, srv.(DRPCITDServer).
+					FirmwareUpdate(
+						in1.(*FirmwareUpdateRequest),
+						&drpcITD_FirmwareUpdateStream{in2.(drpc.Stream)},
+					)
+			}, DRPCITDServer.FirmwareUpdate, true
+	case 17:
+		return "/rpc.ITD/FirmwareValidateReminder", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCITDServer).
+					FirmwareValidateReminder(
+						ctx,
+						in1.(*Empty),
+					)
+			}, DRPCITDServer.FirmwareValidateReminder, true
 	default:
 		return "", nil, nil, nil, false
 	}
@@ -689,6 +812,38 @@ func (x *drpcITD_AddressStream) SendAndClose(m *StringResponse) error {
 	return x.CloseSend()
 }
 
+type DRPCITD_CapabilitiesStream interface {
+	drpc.Stream
+	SendAndClose(*CapabilitiesResponse) error
+}
+
+type drpcITD_CapabilitiesStream struct {
+	drpc.Stream
+}
+
+func (x *drpcITD_CapabilitiesStream) SendAndClose(m *CapabilitiesResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCITD_GetTimeStream interface {
+	drpc.Stream
+	SendAndClose(*TimeResponse) error
+}
+
+type drpcITD_GetTimeStream struct {
+	drpc.Stream
+}
+
+func (x *drpcITD_GetTimeStream) SendAndClose(m *TimeResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
 type DRPCITD_NotifyStream interface {
 	drpc.Stream
 	SendAndClose(*Empty) error
@@ -750,6 +905,35 @@ func (x *drpcITD_FirmwareUpgradeStream) Send(m *DFUProgress) error {
 	return x.MsgSend(m, drpcEncoding_File_itd_proto{})
 }
 
+type DRPCITD_FirmwareUpdateStream interface {
+	drpc.Stream
+	Send(*FirmwareUpdateProgress) error
+}
+
+type drpcITD_FirmwareUpdateStream struct {
+	drpc.Stream
+}
+
+func (x *drpcITD_FirmwareUpdateStream) Send(m *FirmwareUpdateProgress) error {
+	return x.MsgSend(m, drpcEncoding_File_itd_proto{})
+}
+
+type DRPCITD_FirmwareValidateReminderStream interface {
+	drpc.Stream
+	SendAndClose(*FirmwareValidateReminderResponse) error
+}
+
+type drpcITD_FirmwareValidateReminderStream struct {
+	drpc.Stream
+}
+
+func (x *drpcITD_FirmwareValidateReminderStream) SendAndClose(m *FirmwareValidateReminderResponse) error {
+	if err := x.MsgSend(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
 type DRPCFSClient interface {
 	DRPCConn() drpc.Conn
 
@@ -761,7 +945,7 @@ type DRPCFSClient interface {
 	ReadDir(ctx context.Context, in *PathRequest) (*DirResponse, error)
 	Upload(ctx context.Context, in *TransferRequest) (DRPCFS_UploadClient, error)
 	Download(ctx context.Context, in *TransferRequest) (DRPCFS_DownloadClient, error)
-	LoadResources(ctx context.Context, in *PathRequest) (DRPCFS_LoadResourcesClient, error)
+	LoadResources(ctx context.Context, in *LoadResourcesRequest) (DRPCFS_LoadResourcesClient, error)
 }
 
 type drpcFSClient struct {
@@ -900,7 +1084,7 @@ func (x *drpcFS_DownloadClient) RecvMsg(m *TransferProgress) error {
 	return x.MsgRecv(m, drpcEncoding_File_itd_proto{})
 }
 
-func (c *drpcFSClient) LoadResources(ctx context.Context, in *PathRequest) (DRPCFS_LoadResourcesClient, error) {
+func (c *drpcFSClient) LoadResources(ctx context.Context, in *LoadResourcesRequest) (DRPCFS_LoadResourcesClient, error) {
 	stream, err := c.cc.NewStream(ctx, "/rpc.FS/LoadResources", drpcEncoding_File_itd_proto{})
 	if err != nil {
 		return nil, err
@@ -945,7 +1129,7 @@ type DRPCFSServer interface {
 	ReadDir(context.Context, *PathRequest) (*DirResponse, error)
 	Upload(*TransferRequest, DRPCFS_UploadStream) error
 	Download(*TransferRequest, DRPCFS_DownloadStream) error
-	LoadResources(*PathRequest, DRPCFS_LoadResourcesStream) error
+	LoadResources(*LoadResourcesRequest, DRPCFS_LoadResourcesStream) error
 }
 
 type DRPCFSUnimplementedServer struct{}
@@ -982,7 +1166,7 @@ func (s *DRPCFSUnimplementedServer) Download(*TransferRequest, DRPCFS_DownloadSt
 	return drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
 }
 
-func (s *DRPCFSUnimplementedServer) LoadResources(*PathRequest, DRPCFS_LoadResourcesStream) error {
+func (s *DRPCFSUnimplementedServer) LoadResources(*LoadResourcesRequest, DRPCFS_LoadResourcesStream) error {
 	return drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
 }
 
@@ -1069,7 +1253,7 @@ func (DRPCFSDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, in
 			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
 				return nil, srv.(DRPCFSServer).
 					LoadResources(
-						in1.(*PathRequest),
+						in1.(*LoadResourcesRequest),
 						&drpcFS_LoadResourcesStream{in2.(drpc.Stream)},
 					)
 			}, DRPCFSServer.LoadResources, true
@@ -1216,3 +1400,516 @@ type drpcFS_LoadResourcesStream struct {
 func (x *drpcFS_LoadResourcesStream) Send(m *ResourceLoadProgress) error {
 	return x.MsgSend(m, drpcEncoding_File_itd_proto{})
 }
+
+type DRPCMusicClient interface {
+	DRPCConn() drpc.Conn
+
+	SetMetadata(ctx context.Context, in *MusicMetadata) (*Empty, error)
+	SetStatus(ctx context.Context, in *MusicStatus) (*Empty, error)
+	GetState(ctx context.Context, in *Empty) (*MusicState, error)
+	WatchMusicEvents(ctx context.Context, in *Empty) (DRPCMusic_WatchMusicEventsClient, error)
+}
+
+type drpcMusicClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCMusicClient(cc drpc.Conn) DRPCMusicClient {
+	return &drpcMusicClient{cc}
+}
+
+func (c *drpcMusicClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcMusicClient) SetMetadata(ctx context.Context, in *MusicMetadata) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/rpc.Music/SetMetadata", drpcEncoding_File_itd_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMusicClient) SetStatus(ctx context.Context, in *MusicStatus) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/rpc.Music/SetStatus", drpcEncoding_File_itd_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMusicClient) GetState(ctx context.Context, in *Empty) (*MusicState, error) {
+	out := new(MusicState)
+	err := c.cc.Invoke(ctx, "/rpc.Music/GetState", drpcEncoding_File_itd_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcMusicClient) WatchMusicEvents(ctx context.Context, in *Empty) (DRPCMusic_WatchMusicEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, "/rpc.Music/WatchMusicEvents", drpcEncoding_File_itd_proto{})
+	if err != nil {
+		return nil, err
+	}
+	x := &drpcMusic_WatchMusicEventsClient{stream}
+	if err := x.MsgSend(in, drpcEncoding_File_itd_proto{}); err != nil {
+		return nil, err
+	}
+	if err := x.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DRPCMusic_WatchMusicEventsClient interface {
+	drpc.Stream
+	Recv() (*MusicEvent, error)
+}
+
+type drpcMusic_WatchMusicEventsClient struct {
+	drpc.Stream
+}
+
+func (x *drpcMusic_WatchMusicEventsClient) Recv() (*MusicEvent, error) {
+	m := new(MusicEvent)
+	if err := x.MsgRecv(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (x *drpcMusic_WatchMusicEventsClient) RecvMsg(m *MusicEvent) error {
+	return x.MsgRecv(m, drpcEncoding_File_itd_proto{})
+}
+
+type DRPCMusicServer interface {
+	SetMetadata(context.Context, *MusicMetadata) (*Empty, error)
+	SetStatus(context.Context, *MusicStatus) (*Empty, error)
+	GetState(context.Context, *Empty) (*MusicState, error)
+	WatchMusicEvents(*Empty, DRPCMusic_WatchMusicEventsStream) error
+}
+
+type DRPCMusicUnimplementedServer struct{}
+
+func (s *DRPCMusicUnimplementedServer) SetMetadata(context.Context, *MusicMetadata) (*Empty, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCMusicUnimplementedServer) SetStatus(context.Context, *MusicStatus) (*Empty, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCMusicUnimplementedServer) GetState(context.Context, *Empty) (*MusicState, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCMusicUnimplementedServer) WatchMusicEvents(*Empty, DRPCMusic_WatchMusicEventsStream) error {
+	return drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCMusicDescription struct{}
+
+func (DRPCMusicDescription) NumMethods() int { return 4 }
+
+func (DRPCMusicDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/rpc.Music/SetMetadata", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMusicServer).
+					SetMetadata(
+						ctx,
+						in1.(*MusicMetadata),
+					)
+			}, DRPCMusicServer.SetMetadata, true
+	case 1:
+		return "/rpc.Music/SetStatus", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMusicServer).
+					SetStatus(
+						ctx,
+						in1.(*MusicStatus),
+					)
+			}, DRPCMusicServer.SetStatus, true
+	case 2:
+		return "/rpc.Music/GetState", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCMusicServer).
+					GetState(
+						ctx,
+						in1.(*Empty),
+					)
+			}, DRPCMusicServer.GetState, true
+	case 3:
+		return "/rpc.Music/WatchMusicEvents", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return nil, srv.(DRPCMusicServer).
+					WatchMusicEvents(
+						in1.(*Empty),
+						&drpcMusic_WatchMusicEventsStream{in2.(drpc.Stream)},
+					)
+			}, DRPCMusicServer.WatchMusicEvents, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func DRPCRegisterMusic(mux drpc.Mux, impl DRPCMusicServer) error {
+	return mux.Register(impl, DRPCMusicDescription{})
+}
+
+type DRPCMusic_SetMetadataStream interface {
+	drpc.Stream
+	SendAndClose(*Empty) error
+}
+
+type drpcMusic_SetMetadataStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMusic_SetMetadataStream) SendAndClose(m *Empty) error {
+	if err := x.MsgSend(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMusic_SetStatusStream interface {
+	drpc.Stream
+	SendAndClose(*Empty) error
+}
+
+type drpcMusic_SetStatusStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMusic_SetStatusStream) SendAndClose(m *Empty) error {
+	if err := x.MsgSend(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMusic_GetStateStream interface {
+	drpc.Stream
+	SendAndClose(*MusicState) error
+}
+
+type drpcMusic_GetStateStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMusic_GetStateStream) SendAndClose(m *MusicState) error {
+	if err := x.MsgSend(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCMusic_WatchMusicEventsStream interface {
+	drpc.Stream
+	Send(*MusicEvent) error
+}
+
+type drpcMusic_WatchMusicEventsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcMusic_WatchMusicEventsStream) Send(m *MusicEvent) error {
+	return x.MsgSend(m, drpcEncoding_File_itd_proto{})
+}
+
+type DRPCNavigationClient interface {
+	DRPCConn() drpc.Conn
+
+	SetNav(ctx context.Context, in *NavigationState) (*Empty, error)
+	ClearNav(ctx context.Context, in *Empty) (*Empty, error)
+}
+
+type drpcNavigationClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCNavigationClient(cc drpc.Conn) DRPCNavigationClient {
+	return &drpcNavigationClient{cc}
+}
+
+func (c *drpcNavigationClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcNavigationClient) SetNav(ctx context.Context, in *NavigationState) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/rpc.Navigation/SetNav", drpcEncoding_File_itd_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcNavigationClient) ClearNav(ctx context.Context, in *Empty) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/rpc.Navigation/ClearNav", drpcEncoding_File_itd_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type DRPCNavigationServer interface {
+	SetNav(context.Context, *NavigationState) (*Empty, error)
+	ClearNav(context.Context, *Empty) (*Empty, error)
+}
+
+type DRPCNavigationUnimplementedServer struct{}
+
+func (s *DRPCNavigationUnimplementedServer) SetNav(context.Context, *NavigationState) (*Empty, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCNavigationUnimplementedServer) ClearNav(context.Context, *Empty) (*Empty, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCNavigationDescription struct{}
+
+func (DRPCNavigationDescription) NumMethods() int { return 2 }
+
+func (DRPCNavigationDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/rpc.Navigation/SetNav", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCNavigationServer).
+					SetNav(
+						ctx,
+						in1.(*NavigationState),
+					)
+			}, DRPCNavigationServer.SetNav, true
+	case 1:
+		return "/rpc.Navigation/ClearNav", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCNavigationServer).
+					ClearNav(
+						ctx,
+						in1.(*Empty),
+					)
+			}, DRPCNavigationServer.ClearNav, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func DRPCRegisterNavigation(mux drpc.Mux, impl DRPCNavigationServer) error {
+	return mux.Register(impl, DRPCNavigationDescription{})
+}
+
+type DRPCNavigation_SetNavStream interface {
+	drpc.Stream
+	SendAndClose(*Empty) error
+}
+
+type drpcNavigation_SetNavStream struct {
+	drpc.Stream
+}
+
+func (x *drpcNavigation_SetNavStream) SendAndClose(m *Empty) error {
+	if err := x.MsgSend(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCNavigation_ClearNavStream interface {
+	drpc.Stream
+	SendAndClose(*Empty) error
+}
+
+type drpcNavigation_ClearNavStream struct {
+	drpc.Stream
+}
+
+func (x *drpcNavigation_ClearNavStream) SendAndClose(m *Empty) error {
+	if err := x.MsgSend(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCJobsClient interface {
+	DRPCConn() drpc.Conn
+
+	ListJobs(ctx context.Context, in *Empty) (*JobList, error)
+	CancelJob(ctx context.Context, in *JobRequest) (*Empty, error)
+	WatchJob(ctx context.Context, in *JobRequest) (DRPCJobs_WatchJobClient, error)
+}
+
+type drpcJobsClient struct {
+	cc drpc.Conn
+}
+
+func NewDRPCJobsClient(cc drpc.Conn) DRPCJobsClient {
+	return &drpcJobsClient{cc}
+}
+
+func (c *drpcJobsClient) DRPCConn() drpc.Conn { return c.cc }
+
+func (c *drpcJobsClient) ListJobs(ctx context.Context, in *Empty) (*JobList, error) {
+	out := new(JobList)
+	err := c.cc.Invoke(ctx, "/rpc.Jobs/ListJobs", drpcEncoding_File_itd_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcJobsClient) CancelJob(ctx context.Context, in *JobRequest) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/rpc.Jobs/CancelJob", drpcEncoding_File_itd_proto{}, in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *drpcJobsClient) WatchJob(ctx context.Context, in *JobRequest) (DRPCJobs_WatchJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, "/rpc.Jobs/WatchJob", drpcEncoding_File_itd_proto{})
+	if err != nil {
+		return nil, err
+	}
+	x := &drpcJobs_WatchJobClient{stream}
+	if err := x.MsgSend(in, drpcEncoding_File_itd_proto{}); err != nil {
+		return nil, err
+	}
+	if err := x.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DRPCJobs_WatchJobClient interface {
+	drpc.Stream
+	Recv() (*JobEvent, error)
+}
+
+type drpcJobs_WatchJobClient struct {
+	drpc.Stream
+}
+
+func (x *drpcJobs_WatchJobClient) Recv() (*JobEvent, error) {
+	m := new(JobEvent)
+	if err := x.MsgRecv(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (x *drpcJobs_WatchJobClient) RecvMsg(m *JobEvent) error {
+	return x.MsgRecv(m, drpcEncoding_File_itd_proto{})
+}
+
+type DRPCJobsServer interface {
+	ListJobs(context.Context, *Empty) (*JobList, error)
+	CancelJob(context.Context, *JobRequest) (*Empty, error)
+	WatchJob(*JobRequest, DRPCJobs_WatchJobStream) error
+}
+
+type DRPCJobsUnimplementedServer struct{}
+
+func (s *DRPCJobsUnimplementedServer) ListJobs(context.Context, *Empty) (*JobList, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCJobsUnimplementedServer) CancelJob(context.Context, *JobRequest) (*Empty, error) {
+	return nil, drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+func (s *DRPCJobsUnimplementedServer) WatchJob(*JobRequest, DRPCJobs_WatchJobStream) error {
+	return drpcerr.WithCode(errors.New("Unimplemented"), drpcerr.Unimplemented)
+}
+
+type DRPCJobsDescription struct{}
+
+func (DRPCJobsDescription) NumMethods() int { return 3 }
+
+func (DRPCJobsDescription) Method(n int) (string, drpc.Encoding, drpc.Receiver, interface{}, bool) {
+	switch n {
+	case 0:
+		return "/rpc.Jobs/ListJobs", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCJobsServer).
+					ListJobs(
+						ctx,
+						in1.(*Empty),
+					)
+			}, DRPCJobsServer.ListJobs, true
+	case 1:
+		return "/rpc.Jobs/CancelJob", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return srv.(DRPCJobsServer).
+					CancelJob(
+						ctx,
+						in1.(*JobRequest),
+					)
+			}, DRPCJobsServer.CancelJob, true
+	case 2:
+		return "/rpc.Jobs/WatchJob", drpcEncoding_File_itd_proto{},
+			func(srv interface{}, ctx context.Context, in1, in2 interface{}) (drpc.Message, error) {
+				return nil, srv.(DRPCJobsServer).
+					WatchJob(
+						in1.(*JobRequest),
+						&drpcJobs_WatchJobStream{in2.(drpc.Stream)},
+					)
+			}, DRPCJobsServer.WatchJob, true
+	default:
+		return "", nil, nil, nil, false
+	}
+}
+
+func DRPCRegisterJobs(mux drpc.Mux, impl DRPCJobsServer) error {
+	return mux.Register(impl, DRPCJobsDescription{})
+}
+
+type DRPCJobs_ListJobsStream interface {
+	drpc.Stream
+	SendAndClose(*JobList) error
+}
+
+type drpcJobs_ListJobsStream struct {
+	drpc.Stream
+}
+
+func (x *drpcJobs_ListJobsStream) SendAndClose(m *JobList) error {
+	if err := x.MsgSend(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCJobs_CancelJobStream interface {
+	drpc.Stream
+	SendAndClose(*Empty) error
+}
+
+type drpcJobs_CancelJobStream struct {
+	drpc.Stream
+}
+
+func (x *drpcJobs_CancelJobStream) SendAndClose(m *Empty) error {
+	if err := x.MsgSend(m, drpcEncoding_File_itd_proto{}); err != nil {
+		return err
+	}
+	return x.CloseSend()
+}
+
+type DRPCJobs_WatchJobStream interface {
+	drpc.Stream
+	Send(*JobEvent) error
+}
+
+type drpcJobs_WatchJobStream struct {
+	drpc.Stream
+}
+
+func (x *drpcJobs_WatchJobStream) Send(m *JobEvent) error {
+	return x.MsgSend(m, drpcEncoding_File_itd_proto{})
+}
@@ -0,0 +1,325 @@
+// Package updater finds InfiniTime releases in a release catalogue
+// and downloads their files, checking them against their checksums.
+//
+// A catalogue is a JSON file listing the available releases:
+//
+//	{
+//	  "releases": [
+//	    {
+//	      "version": "1.14.0",
+//	      "firmware": {"url": "pinetime-mcuboot-app-dfu-1.14.0.zip", "sha256": "..."},
+//	      "resources": {"url": "infinitime-resources-1.14.0.zip", "sha256": "..."}
+//	    }
+//	  ]
+//	}
+//
+// File URLs may be relative to the catalogue. The catalogue can be loaded from
+// an HTTP(S) URL or from a local directory mirror containing catalogue.json.
+package updater
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go.elara.ws/itd/infinitime"
+)
+
+var (
+	ErrNoCatalogue      = errors.New("no release catalogue configured")
+	ErrNoRelease        = errors.New("release not found in catalogue")
+	ErrNoChecksum       = errors.New("catalogue doesn't contain a checksum for file")
+	ErrChecksumMismatch = errors.New("file doesn't match its checksum")
+)
+
+// CatalogueName is the name of the catalogue file in a local directory mirror
+const CatalogueName = "catalogue.json"
+
+// Catalogue is a list of InfiniTime releases
+type Catalogue struct {
+	Releases []Release `json:"releases"`
+	// base is the location of the catalogue, which
+	// relative file URLs are resolved against
+	base *url.URL
+}
+
+// Release is a single InfiniTime release
+type Release struct {
+	Version  string `json:"version"`
+	Firmware File   `json:"firmware"`
+	// Resources is nil if the release doesn't have external resources
+	Resources *File `json:"resources,omitempty"`
+}
+
+// File is a file that belongs to a release
+type File struct {
+	// URL is the location of the file, which may be relative to the catalogue
+	URL string `json:"url"`
+	// SHA256 is the hex-encoded SHA-256 checksum of the file
+	SHA256 string `json:"sha256"`
+	// Size is the size of the file in bytes, if known
+	Size int64 `json:"size,omitempty"`
+}
+
+// Name returns the name of the file, taken from its URL
+func (f File) Name() string {
+	u, err := url.Parse(f.URL)
+	if err != nil {
+		return path.Base(f.URL)
+	}
+	return path.Base(u.Path)
+}
+
+// ParsedVersion parses the release's version
+func (r Release) ParsedVersion() (infinitime.Version, error) {
+	return infinitime.ParseVersion(r.Version)
+}
+
+// Load loads the catalogue at location, which can be an HTTP(S) URL,
+// a file URL, a path to a catalogue file, or a path to a directory
+// containing catalogue.json.
+func Load(ctx context.Context, location string) (*Catalogue, error) {
+	if location == "" {
+		return nil, ErrNoCatalogue
+	}
+
+	base, err := catalogueURL(location)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := open(ctx, base)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	c := &Catalogue{base: base}
+	err = json.NewDecoder(r).Decode(c)
+	if err != nil {
+		return nil, fmt.Errorf("invalid catalogue: %w", err)
+	}
+	return c, nil
+}
+
+// catalogueURL converts a catalogue location to a URL
+func catalogueURL(location string) (*url.URL, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "file://") {
+		return url.Parse(location)
+	}
+
+	p, err := filepath.Abs(location)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		p = filepath.Join(p, CatalogueName)
+	}
+
+	return &url.URL{Scheme: "file", Path: filepath.ToSlash(p)}, nil
+}
+
+// Latest returns the newest release in the catalogue.
+// Releases with invalid versions are ignored.
+func (c *Catalogue) Latest() (Release, error) {
+	var (
+		latest    Release
+		latestVer infinitime.Version
+		found     bool
+	)
+
+	for _, r := range c.Releases {
+		v, err := r.ParsedVersion()
+		if err != nil {
+			continue
+		}
+
+		if !found || v.Compare(latestVer) > 0 {
+			latest, latestVer, found = r, v, true
+		}
+	}
+
+	if !found {
+		return Release{}, ErrNoRelease
+	}
+	return latest, nil
+}
+
+// Find returns the release with the given version
+func (c *Catalogue) Find(version string) (Release, error) {
+	want, err := infinitime.ParseVersion(version)
+	if err != nil {
+		return Release{}, err
+	}
+
+	for _, r := range c.Releases {
+		v, err := r.ParsedVersion()
+		if err == nil && v == want {
+			return r, nil
+		}
+	}
+
+	return Release{}, fmt.Errorf("%w: %s", ErrNoRelease, want)
+}
+
+// Fetch makes f available locally and returns its path. Remote files are
+// downloaded into dir, unless a file with a matching checksum is already
+// there. Local files are used in place. Either way, the file is checked
+// against its checksum. progress is called with the amount of bytes
+// downloaded and the total size, which is -1 if it's not known.
+func (c *Catalogue) Fetch(ctx context.Context, f File, dir string, progress func(done, total int64)) (string, error) {
+	if f.SHA256 == "" {
+		return "", fmt.Errorf("%w %s", ErrNoChecksum, f.Name())
+	}
+
+	ref, err := url.Parse(f.URL)
+	if err != nil {
+		return "", err
+	}
+	u := c.base.ResolveReference(ref)
+
+	if u.Scheme == "file" {
+		p := filepath.FromSlash(u.Path)
+		return p, verifyFile(p, f.SHA256)
+	}
+
+	dst := filepath.Join(dir, f.Name())
+	if verifyFile(dst, f.SHA256) == nil {
+		return dst, nil
+	}
+
+	err = os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	return dst, download(ctx, u, f, dst, progress)
+}
+
+// download downloads the file at u to dst, checking its checksum
+// before moving it into place.
+func download(ctx context.Context, u *url.URL, f File, dst string, progress func(done, total int64)) error {
+	r, err := open(ctx, u)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	total := f.Size
+	if total <= 0 {
+		total = -1
+		if sr, ok := r.(sizedReader); ok && sr.size > 0 {
+			total = sr.size
+		}
+	}
+
+	h := sha256.New()
+	pw := &progressWriter{total: total, fn: progress}
+	_, err = io.Copy(io.MultiWriter(tmp, h, pw), r)
+	if err != nil {
+		return err
+	}
+
+	if err := checkSum(h.Sum(nil), f.SHA256, f.Name()); err != nil {
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), dst)
+}
+
+// sizedReader is a reader that knows the size of its contents
+type sizedReader struct {
+	io.ReadCloser
+	size int64
+}
+
+// open opens the file at u for reading
+func open(ctx context.Context, u *url.URL) (io.ReadCloser, error) {
+	switch u.Scheme {
+	case "file":
+		return os.Open(filepath.FromSlash(u.Path))
+	case "http", "https":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			res.Body.Close()
+			return nil, fmt.Errorf("fetching %s: %s", u, res.Status)
+		}
+		return sizedReader{res.Body, res.ContentLength}, nil
+	default:
+		return nil, fmt.Errorf("unsupported catalogue scheme: %q", u.Scheme)
+	}
+}
+
+// verifyFile checks the file at path against the hex-encoded SHA-256 checksum
+func verifyFile(path, sum string) error {
+	fl, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fl.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, fl)
+	if err != nil {
+		return err
+	}
+
+	return checkSum(h.Sum(nil), sum, filepath.Base(path))
+}
+
+func checkSum(got []byte, want, name string) error {
+	if !strings.EqualFold(hex.EncodeToString(got), want) {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, name)
+	}
+	return nil
+}
+
+// progressWriter calls fn with the amount of bytes written to it
+type progressWriter struct {
+	done, total int64
+	fn          func(done, total int64)
+}
+
+func (pw *progressWriter) Write(b []byte) (int, error) {
+	pw.done += int64(len(b))
+	if pw.fn != nil {
+		pw.fn(pw.done, pw.total)
+	}
+	return len(b), nil
+}
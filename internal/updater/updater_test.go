@@ -0,0 +1,158 @@
+package updater
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var testFirmware = []byte("firmware image")
+
+func sum(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func testCatalogue(firmwareSum string) string {
+	return fmt.Sprintf(`{"releases": [
+		{"version": "1.13.0", "firmware": {"url": "fw-1.13.0.zip", "sha256": "00"}},
+		{"version": "1.14.0", "firmware": {"url": "fw-1.14.0.zip", "sha256": %q}},
+		{"version": "custom", "firmware": {"url": "fw-custom.zip", "sha256": "00"}}
+	]}`, firmwareSum)
+}
+
+func TestLatest(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, CatalogueName), []byte(testCatalogue("00")), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Error loading catalogue: %s", err)
+	}
+
+	r, err := c.Latest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Version != "1.14.0" {
+		t.Errorf("Expected latest release 1.14.0, got %s", r.Version)
+	}
+
+	r, err = c.Find("v1.13")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Version != "1.13.0" {
+		t.Errorf("Expected release 1.13.0, got %s", r.Version)
+	}
+
+	_, err = c.Find("1.15.0")
+	if !errors.Is(err, ErrNoRelease) {
+		t.Errorf("Expected %v, got %v", ErrNoRelease, err)
+	}
+}
+
+func TestFetchLocal(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, CatalogueName), []byte(testCatalogue(sum(testFirmware))), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "fw-1.14.0.zip"), testFirmware, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Error loading catalogue: %s", err)
+	}
+
+	r, _ := c.Latest()
+	p, err := c.Fetch(context.Background(), r.Firmware, t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("Error fetching firmware: %s", err)
+	}
+	if p != filepath.Join(dir, "fw-1.14.0.zip") {
+		t.Errorf("Expected local file to be used in place, got %s", p)
+	}
+
+	err = os.WriteFile(p, []byte("corrupted"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Fetch(context.Background(), r.Firmware, t.TempDir(), nil)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Expected %v, got %v", ErrChecksumMismatch, err)
+	}
+}
+
+func TestFetchHTTP(t *testing.T) {
+	catalogue := testCatalogue(sum(testFirmware))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/releases/catalogue.json":
+			w.Write([]byte(catalogue))
+		case "/releases/fw-1.14.0.zip":
+			w.Write(testFirmware)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := Load(context.Background(), srv.URL+"/releases/catalogue.json")
+	if err != nil {
+		t.Fatalf("Error loading catalogue: %s", err)
+	}
+
+	r, _ := c.Latest()
+	dir := t.TempDir()
+
+	var done, total int64
+	p, err := c.Fetch(context.Background(), r.Firmware, dir, func(d, t int64) {
+		done, total = d, t
+	})
+	if err != nil {
+		t.Fatalf("Error fetching firmware: %s", err)
+	}
+
+	data, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(testFirmware) {
+		t.Errorf("Downloaded file doesn't match: %q", data)
+	}
+	if done != int64(len(testFirmware)) || total != int64(len(testFirmware)) {
+		t.Errorf("Expected progress %[1]d/%[1]d, got %d/%d", len(testFirmware), done, total)
+	}
+
+	// A corrupted download must not replace the file
+	catalogue = testCatalogue(sum([]byte("other")))
+	c, err = Load(context.Background(), srv.URL+"/releases/catalogue.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, _ = c.Latest()
+	_, err = c.Fetch(context.Background(), r.Firmware, dir, nil)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Expected %v, got %v", ErrChecksumMismatch, err)
+	}
+
+	data, _ = os.ReadFile(p)
+	if string(data) != string(testFirmware) {
+		t.Errorf("Corrupted download replaced the existing file")
+	}
+}
@@ -39,3 +39,20 @@ func NewSessionBusConn(ctx context.Context) (*dbus.Conn, error) {
 	}
 	return conn, nil
 }
+
+func NewBusConn(ctx context.Context, address string) (*dbus.Conn, error) {
+	// Connect to dbus bus at the given address
+	conn, err := dbus.Dial(address, dbus.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	err = conn.Auth(nil)
+	if err != nil {
+		return nil, err
+	}
+	err = conn.Hello()
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
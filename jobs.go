@@ -0,0 +1,323 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"go.elara.ws/itd/internal/rpc"
+)
+
+var (
+	errJobNotFound = errors.New("job not found")
+	errJobFinished = errors.New("job already finished")
+)
+
+const (
+	// jobRetention is how long finished jobs are kept, so that
+	// clients that reattach can still find out how they ended.
+	jobRetention = 10 * time.Minute
+	// jobBuffer is the amount of events buffered for each watcher.
+	// Events are dropped for watchers that fall further behind, but
+	// one more slot is kept so that the last event is always delivered.
+	jobBuffer = 64
+)
+
+// jobs keeps track of long operations, such as transfers and firmware
+// upgrades. It's replaced with one that uses itd's context when the
+// socket starts.
+var jobs = newJobManager(context.Background())
+
+type jobManager struct {
+	// ctx is the parent of every job's context, so that the jobs are
+	// cancelled when itd shuts down rather than when their client leaves
+	ctx context.Context
+
+	mtx    sync.Mutex
+	nextID uint64
+	jobs   map[uint64]*job
+}
+
+// job is a long operation that runs in the background, independently
+// of the client that started it. Clients can watch its progress,
+// detach from it, and reattach to it later using its ID.
+type job struct {
+	id      uint64
+	kind    string
+	desc    string
+	started time.Time
+	cancel  context.CancelFunc
+
+	mtx   sync.Mutex
+	state rpc.JobInfo_State
+	err   error
+	// last is the latest progress event, which is
+	// sent to clients as soon as they start watching
+	last     *rpc.JobEvent
+	watchers map[*jobWatcher]struct{}
+}
+
+// jobWatcher is a client watching a job
+type jobWatcher struct {
+	ch chan *rpc.JobEvent
+	// delivered is the latest event sent to ch
+	delivered *rpc.JobEvent
+}
+
+// newJobManager returns a job manager whose jobs are cancelled when ctx is done
+func newJobManager(ctx context.Context) *jobManager {
+	return &jobManager{ctx: ctx, jobs: map[uint64]*job{}}
+}
+
+// start runs fn in the background as a new job. The context passed to fn
+// is cancelled when the job is cancelled, or when the manager's context is done.
+func (m *jobManager) start(kind, desc string, fn func(ctx context.Context, j *job) error) *job {
+	ctx, cancel := context.WithCancel(m.ctx)
+
+	m.mtx.Lock()
+	m.nextID++
+	j := &job{
+		id:       m.nextID,
+		kind:     kind,
+		desc:     desc,
+		started:  time.Now(),
+		cancel:   cancel,
+		watchers: map[*jobWatcher]struct{}{},
+	}
+	m.jobs[j.id] = j
+	m.mtx.Unlock()
+
+	log.Info("Starting job", slog.Uint64("id", j.id), slog.String("kind", kind), slog.String("desc", desc))
+
+	go func() {
+		err := fn(ctx, j)
+		j.finish(ctx, err)
+		cancel()
+
+		time.AfterFunc(jobRetention, func() {
+			m.mtx.Lock()
+			delete(m.jobs, j.id)
+			m.mtx.Unlock()
+		})
+	}()
+
+	return j
+}
+
+// get returns the job with the given ID
+func (m *jobManager) get(id uint64) (*job, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return nil, errJobNotFound
+	}
+	return j, nil
+}
+
+// list returns information about all the jobs, oldest first
+func (m *jobManager) list() []*rpc.JobInfo {
+	m.mtx.Lock()
+	out := make([]*rpc.JobInfo, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		out = append(out, j.info())
+	}
+	m.mtx.Unlock()
+
+	slices.SortFunc(out, func(a, b *rpc.JobInfo) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	return out
+}
+
+// progress sends a progress event to everyone watching the job
+func (j *job) progress(evt *rpc.JobEvent) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	evt.Info = j.infoLocked()
+	j.last = evt
+
+	for w := range j.watchers {
+		// Events are only sent under j.mtx, so the buffer can't fill up after
+		// this check. The last slot is reserved for the job's last event.
+		if len(w.ch) >= jobBuffer {
+			log.Debug("Job watcher is too slow, dropping event", slog.Uint64("id", j.id))
+			continue
+		}
+		w.ch <- evt
+		w.delivered = evt
+	}
+}
+
+// finish records the result of the job, and stops everyone watching it
+func (j *job) finish(ctx context.Context, err error) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	j.err = err
+	switch {
+	case err == nil:
+		j.state = rpc.JobInfo_Done
+		log.Info("Job finished", slog.Uint64("id", j.id))
+	case ctx.Err() != nil:
+		j.state = rpc.JobInfo_Cancelled
+		log.Info("Job cancelled", slog.Uint64("id", j.id))
+	default:
+		j.state = rpc.JobInfo_Failed
+		log.Warn("Job failed", slog.Uint64("id", j.id), slog.Any("error", err))
+	}
+
+	for w := range j.watchers {
+		// Make sure every watcher gets the last event, such as the end
+		// of a firmware upgrade, even if it dropped some events before.
+		if j.last != nil && w.delivered != j.last {
+			w.ch <- j.last
+		}
+		close(w.ch)
+	}
+	j.watchers = nil
+}
+
+// watch returns a channel that receives the job's progress events, starting
+// with the latest one. The channel is closed once the job finishes.
+// The returned function stops watching.
+func (j *job) watch() (<-chan *rpc.JobEvent, func()) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	w := &jobWatcher{ch: make(chan *rpc.JobEvent, jobBuffer+1)}
+	if j.last != nil {
+		w.ch <- j.last
+		w.delivered = j.last
+	}
+
+	if j.state != rpc.JobInfo_Running {
+		close(w.ch)
+		return w.ch, func() {}
+	}
+
+	j.watchers[w] = struct{}{}
+	return w.ch, func() {
+		j.mtx.Lock()
+		delete(j.watchers, w)
+		j.mtx.Unlock()
+	}
+}
+
+// follow sends the job's progress events to a client until the job finishes,
+// and returns the job's error. If the client goes away first, or sending to it
+// fails, the job is cancelled, unless the client asked to detach from it.
+func (j *job) follow(ctx context.Context, detach bool, send func(*rpc.JobEvent) error) error {
+	events, unwatch := j.watch()
+	defer unwatch()
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				j.mtx.Lock()
+				defer j.mtx.Unlock()
+				return j.err
+			}
+
+			err := send(evt)
+			if err != nil {
+				if !detach {
+					j.cancel()
+				}
+				return err
+			}
+		case <-ctx.Done():
+			if !detach {
+				j.cancel()
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+// stop cancels the job
+func (j *job) stop() error {
+	j.mtx.Lock()
+	running := j.state == rpc.JobInfo_Running
+	j.mtx.Unlock()
+
+	if !running {
+		return errJobFinished
+	}
+
+	log.Info("Cancelling job", slog.Uint64("id", j.id))
+	j.cancel()
+	return nil
+}
+
+// info returns information about the job
+func (j *job) info() *rpc.JobInfo {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	return j.infoLocked()
+}
+
+func (j *job) infoLocked() *rpc.JobInfo {
+	info := &rpc.JobInfo{
+		Id:              j.id,
+		Kind:            j.kind,
+		Description:     j.desc,
+		StartedUnixNano: j.started.UnixNano(),
+		State:           j.state,
+	}
+	if j.err != nil {
+		info.Error = j.err.Error()
+	}
+	return info
+}
+
+// Jobs implements the jobs RPC service
+type Jobs struct{}
+
+func (*Jobs) ListJobs(context.Context, *rpc.Empty) (*rpc.JobList, error) {
+	return &rpc.JobList{Jobs: jobs.list()}, nil
+}
+
+func (*Jobs) CancelJob(_ context.Context, req *rpc.JobRequest) (*rpc.Empty, error) {
+	j, err := jobs.get(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.Empty{}, j.stop()
+}
+
+// WatchJob sends a job's progress events until it finishes, followed by an
+// event containing only the final state of the job. Unlike the client that
+// started the job, a client watching it never cancels it.
+func (*Jobs) WatchJob(req *rpc.JobRequest, s rpc.DRPCJobs_WatchJobStream) error {
+	j, err := jobs.get(req.Id)
+	if err != nil {
+		return err
+	}
+
+	events, unwatch := j.watch()
+	defer unwatch()
+
+	for {
+		select {
+		case evt, ok := <-events:
+			if !ok {
+				return s.Send(&rpc.JobEvent{Info: j.info()})
+			}
+
+			err = s.Send(evt)
+			if err != nil {
+				return err
+			}
+		case <-s.Context().Done():
+			return s.Context().Err()
+		}
+	}
+}
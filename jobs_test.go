@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.elara.ws/itd/internal/rpc"
+)
+
+// blockingJob reports progress once, then waits until
+// it's cancelled or release is closed
+func blockingJob(release <-chan struct{}) func(ctx context.Context, j *job) error {
+	return func(ctx context.Context, j *job) error {
+		j.progress(&rpc.JobEvent{Progress: &rpc.JobEvent_Transfer{Transfer: &rpc.TransferProgress{Sent: 1, Total: 2}}})
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-release:
+			return nil
+		}
+	}
+}
+
+// waitState waits for a job to reach the given state
+func waitState(t *testing.T, j *job, state rpc.JobInfo_State) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for j.info().State != state {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected job state %s, got %s", state, j.info().State)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestJobs(t *testing.T) {
+	log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	mctx, mcancel := context.WithCancel(context.Background())
+	defer mcancel()
+	m := newJobManager(mctx)
+
+	// A client that goes away without detaching cancels the job
+	j := m.start("upload", "a", blockingJob(nil))
+	ctx, cancel := context.WithCancel(context.Background())
+	var got *rpc.JobEvent
+	err := j.follow(ctx, false, func(evt *rpc.JobEvent) error {
+		got = evt
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if got.GetTransfer().GetSent() != 1 || got.Info.GetId() != j.id {
+		t.Errorf("Expected progress event for job %d, got %v", j.id, got)
+	}
+	waitState(t, j, rpc.JobInfo_Cancelled)
+
+	// A client that fails to receive an event cancels the job as well
+	j = m.start("upload", "b", blockingJob(nil))
+	err = j.follow(context.Background(), false, func(*rpc.JobEvent) error {
+		return io.ErrClosedPipe
+	})
+	if !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("Expected io.ErrClosedPipe, got %v", err)
+	}
+	waitState(t, j, rpc.JobInfo_Cancelled)
+
+	// A detached job keeps running, and can be reattached to
+	release := make(chan struct{})
+	j = m.start("firmwareUpgrade", "c", blockingJob(release))
+	ctx, cancel = context.WithCancel(context.Background())
+	_ = j.follow(ctx, true, func(*rpc.JobEvent) error {
+		cancel()
+		return nil
+	})
+	if state := j.info().State; state != rpc.JobInfo_Running {
+		t.Fatalf("Expected detached job to keep running, got %s", state)
+	}
+
+	reattached, err := m.get(j.id)
+	if err != nil {
+		t.Fatalf("Error getting job: %s", err)
+	}
+	events, unwatch := reattached.watch()
+	defer unwatch()
+	if evt := <-events; evt.GetTransfer().GetSent() != 1 {
+		t.Errorf("Expected the latest event to be replayed, got %v", evt)
+	}
+
+	close(release)
+	for range events {
+	}
+	waitState(t, j, rpc.JobInfo_Done)
+
+	err = j.stop()
+	if !errors.Is(err, errJobFinished) {
+		t.Errorf("Expected errJobFinished, got %v", err)
+	}
+
+	list := m.list()
+	if len(list) != 3 || list[0].Id != 1 || list[2].Id != 3 {
+		t.Errorf("Expected 3 jobs sorted by ID, got %v", list)
+	}
+
+	_, err = m.get(42)
+	if !errors.Is(err, errJobNotFound) {
+		t.Errorf("Expected errJobNotFound, got %v", err)
+	}
+
+	// Shutting down cancels detached jobs as well
+	j = m.start("download", "d", blockingJob(nil))
+	mcancel()
+	waitState(t, j, rpc.JobInfo_Cancelled)
+}
+
+func TestJobSlowWatcher(t *testing.T) {
+	log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	m := newJobManager(context.Background())
+
+	release := make(chan struct{})
+	j := m.start("firmwareUpgrade", "slow", func(ctx context.Context, j *job) error {
+		<-release
+		for i := range jobBuffer * 2 {
+			j.progress(&rpc.JobEvent{Progress: &rpc.JobEvent_Dfu{Dfu: &rpc.DFUProgress{Recieved: int64(i)}}})
+		}
+		j.progress(&rpc.JobEvent{Progress: &rpc.JobEvent_Dfu{Dfu: &rpc.DFUProgress{Stage: rpc.DFUProgress_Done}}})
+		return nil
+	})
+
+	// Don't read any events until the job is done,
+	// so that the watcher falls behind
+	events, unwatch := j.watch()
+	defer unwatch()
+	close(release)
+	waitState(t, j, rpc.JobInfo_Done)
+
+	var last *rpc.JobEvent
+	n := 0
+	for evt := range events {
+		last = evt
+		n++
+	}
+	if n > jobBuffer+1 {
+		t.Errorf("Expected at most %d events, got %d", jobBuffer+1, n)
+	}
+	if last.GetDfu().GetStage() != rpc.DFUProgress_Done {
+		t.Errorf("Expected the last event to be delivered, got %v", last)
+	}
+}
@@ -39,7 +39,6 @@ import (
 )
 
 var (
-	firmwareUpdating = false
 	// The FS must be updated when the watch is reconnected
 	updateFS = false
 )
@@ -69,12 +68,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Load the version flashed before itd last exited, to check
+	// whether the watch reverted to the previous firmware since
+	loadFlashedVersion()
+
 	// Create infinitime options struct
 	opts := infinitime.Options{
+		Timeout: time.Duration(cfg.Bluetooh.Timeout) * time.Second,
+		Logger:  log,
 		OnReconnect: func(dev *infinitime.Device) {
+			// Log the drift before the time is set, so that it
+			// shows how far the watch's clock drifted while disconnected.
+			logClockDrift(dev)
+
 			if cfg.On.Reconnect.SetTime {
 				// Set time to current time
-				err = dev.SetTime(time.Now())
+				err = sched.run(priorityNormal, "time", func() error {
+					return dev.SetTime(time.Now())
+				})
 				if err != nil {
 					return
 				}
@@ -83,7 +94,9 @@ func main() {
 			// If config specifies to notify on reconnect
 			if cfg.On.Reconnect.Notify {
 				// Send notification to InfiniTime
-				err = dev.Notify("itd", "Successfully reconnected")
+				err = sched.run(priorityLow, "reconnectNotification", func() error {
+					return dev.Notify("itd", "Successfully reconnected")
+				})
 				if err != nil {
 					return
 				}
@@ -92,7 +105,11 @@ func main() {
 			// FS must be updated on reconnect
 			updateFS = true
 			// Resend weather on reconnect
-			sendWeatherCh <- struct{}{}
+			requestWeather()
+			// Resend music state on reconnect
+			requestMusicState()
+			// The watch may have lost its navigation data, so write every field again
+			navUpdates.reset()
 		},
 	}
 
@@ -115,8 +132,11 @@ func main() {
 	// Log connection
 	log.Info("Connected to InfiniTime", slog.String("version", ver), slog.String("addr", dev.Address()))
 
+	// Find out which optional features the watch supports
+	caps := dev.Capabilities()
+
 	// If config specifies to notify on connect
-	if cfg.On.Connect.Notify {
+	if cfg.On.Connect.Notify && caps.Notifications {
 		// Send notification to InfiniTime
 		err = dev.Notify("itd", "Successfully connected")
 		if err != nil {
@@ -124,7 +144,11 @@ func main() {
 		}
 	}
 
-	if cfg.On.Connect.SetTime {
+	if caps.Time {
+		logClockDrift(dev)
+	}
+
+	if cfg.On.Connect.SetTime && caps.Time {
 		// Set time to current time
 		err = dev.SetTime(time.Now())
 		if err != nil {
@@ -146,44 +170,65 @@ func main() {
 
 	wg := WaitGroup{&sync.WaitGroup{}}
 
+	// The watch may have rebooted into the previous firmware
+	initFirmwareRevertCheck(ctx, wg, dev)
+
 	// Initialize music controls
-	err = initMusicCtrl(ctx, wg, dev)
-	if err != nil {
-		log.Warn("Error initializing music control", slog.Any("error", err))
+	if supported(caps.Music, "music control") {
+		err = initMusicCtrl(ctx, wg, dev)
+		if err != nil {
+			log.Warn("Error initializing music control", slog.Any("error", err))
+		}
 	}
 
-	// Start control socket
-	err = initCallNotifs(ctx, wg, dev)
-	if err != nil {
-		log.Warn("Error initializing call notifications", slog.Any("error", err))
+	// Initialize call notifications
+	if supported(caps.Notifications && caps.CallControl, "call notifications") {
+		err = initCallNotifs(ctx, wg, dev)
+		if err != nil {
+			log.Warn("Error initializing call notifications", slog.Any("error", err))
+		}
 	}
 
 	// Initialize notification relay
-	err = initNotifRelay(ctx, wg, dev)
-	if err != nil {
-		log.Warn("Error initializing notification relay", slog.Any("error", err))
+	if supported(caps.Notifications, "notification relay") {
+		err = initNotifRelay(ctx, wg, dev)
+		if err != nil {
+			log.Warn("Error initializing notification relay", slog.Any("error", err))
+		}
 	}
 
 	// Initializa weather
-	err = initWeather(ctx, wg, dev)
-	if err != nil {
-		log.Warn("Error initializing weather", slog.Any("error", err))
+	if !cfg.Weather.Enabled || supported(caps.Weather, "weather") {
+		err = initWeather(ctx, wg, dev)
+		if err != nil {
+			log.Warn("Error initializing weather", slog.Any("error", err))
+		}
 	}
 
 	// Initialize metrics collection
-	err = initMetrics(ctx, wg, dev)
+	err = initMetrics(ctx, wg, dev, caps)
 	if err != nil {
 		log.Warn("Error initializing metrics collection", slog.Any("error", err))
 	}
 
+	// Initialize periodic time sync
+	if cfg.TimeSync.Enabled && supported(caps.Time, "time sync") {
+		err = initTimeSync(ctx, wg, dev)
+		if err != nil {
+			log.Warn("Error initializing time sync", slog.Any("error", err))
+		}
+	}
+
 	// Initialize puremaps integration
-	err = initPureMaps(ctx, wg, dev)
-	if err != nil {
-		log.Warn("Error initializing puremaps integration", slog.Any("error", err))
+	if supported(caps.Navigation, "navigation") {
+		err = initPureMaps(ctx, wg, dev)
+		if err != nil {
+			log.Warn("Error initializing puremaps integration", slog.Any("error", err))
+		}
 	}
 
 	// Start fuse socket
-	if cfg.Fuse.Enabled {
+	if cfg.Fuse.Enabled && supported(caps.FS, "filesystem") {
 		err = startFUSE(ctx, wg, dev)
 		if err != nil {
 			log.Warn("Error starting fuse socket", slog.Any("error", err))
@@ -199,6 +244,34 @@ func main() {
 	wg.Wait()
 }
 
+// maxClockDrift is the drift above which the watch's clock is likely failing
+const maxClockDrift = time.Minute
+
+// logClockDrift logs how far the watch's clock is from the host's clock
+func logClockDrift(dev *infinitime.Device) {
+	drift, err := dev.ClockDrift()
+	if err != nil {
+		log.Debug("Error reading time from InfiniTime", slog.Any("error", err))
+		return
+	}
+
+	drift = drift.Round(100 * time.Millisecond)
+	if drift.Abs() > maxClockDrift {
+		log.Warn("InfiniTime's clock has drifted significantly, its RTC may be failing", slog.Duration("drift", drift))
+	} else {
+		log.Info("InfiniTime clock drift", slog.Duration("drift", drift))
+	}
+}
+
+// supported logs a message if the watch doesn't support
+// a feature, and returns whether the feature is supported.
+func supported(ok bool, feature string) bool {
+	if !ok {
+		log.Info("Watch doesn't support this feature, skipping it", slog.String("feature", feature))
+	}
+	return ok
+}
+
 type x struct {
 	n int
 	*sync.WaitGroup
@@ -11,139 +11,88 @@ import (
 )
 
 const (
+	pureMapsName      = "io.github.rinigus.PureMaps"
+	navigatorPath     = "/io/github/rinigus/PureMaps/navigator"
 	interfaceName     = "io.github.rinigus.PureMaps.navigator"
 	iconProperty      = interfaceName + ".icon"
 	narrativeProperty = interfaceName + ".narrative"
 	manDistProperty   = interfaceName + ".manDist"
 	progressProperty  = interfaceName + ".progress"
+	runningProperty   = interfaceName + ".running"
 )
 
 func initPureMaps(ctx context.Context, wg WaitGroup, dev *infinitime.Device) error {
-	// Connect to session bus. This connection is for method calls.
+	// Connect to session bus. This connection is closed when ctx is canceled.
 	conn, err := utils.NewSessionBusConn(ctx)
 	if err != nil {
 		return err
 	}
 
-	exists, err := pureMapsExists(ctx, conn)
+	// Listen for property change signals from the navigator
+	err = conn.AddMatchSignalContext(ctx, dbus.WithMatchInterface(interfaceName))
 	if err != nil {
 		return err
 	}
 
-	// Connect to session bus. This connection is for method calls.
-	monitorConn, err := utils.NewSessionBusConn(ctx)
+	// Listen for PureMaps appearing on or disappearing from the bus
+	err = conn.AddMatchSignalContext(
+		ctx,
+		dbus.WithMatchSender("org.freedesktop.DBus"),
+		dbus.WithMatchInterface("org.freedesktop.DBus"),
+		dbus.WithMatchMember("NameOwnerChanged"),
+		dbus.WithMatchArg(0, pureMapsName),
+	)
 	if err != nil {
 		return err
 	}
 
-	// Define rules to listen for
-	rules := []string{
-		"type='signal',interface='io.github.rinigus.PureMaps.navigator'",
-	}
-	var flag uint = 0
-	// Becode monitor for notifications
-	call := monitorConn.BusObject().CallWithContext(
-		ctx, "org.freedesktop.DBus.Monitoring.BecomeMonitor", 0, rules, flag,
-	)
-	if call.Err != nil {
-		return call.Err
-	}
+	signalCh := make(chan *dbus.Signal, 10)
+	conn.Signal(signalCh)
 
-	var navigator dbus.BusObject
+	navigator := conn.Object(pureMapsName, navigatorPath)
+
+	exists, err := pureMapsExists(ctx, conn)
+	if err != nil {
+		return err
+	}
 
 	if exists {
-		navigator = conn.Object("io.github.rinigus.PureMaps", "/io/github/rinigus/PureMaps/navigator")
-		err = setAll(navigator, dev)
-		if err != nil {
-			log.Error("Error setting all navigation fields", slog.Any("error", err))
-		}
+		log.Info("Sending PureMaps data to InfiniTime")
+		updateNavRunning(navigator, dev)
 	}
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done("pureMaps")
 
-		signalCh := make(chan *dbus.Message, 10)
-		monitorConn.Eavesdrop(signalCh)
-
 		for {
 			select {
-			case sig := <-signalCh:
-				if sig.Type != dbus.TypeSignal {
-					continue
+			case sig, ok := <-signalCh:
+				if !ok {
+					return
 				}
 
-				var member string
-				err = sig.Headers[dbus.FieldMember].Store(&member)
-				if err != nil {
-					log.Error("Error getting dbus member field", slog.Any("error", err))
+				if sig.Name == "org.freedesktop.DBus.NameOwnerChanged" {
+					handlePureMapsOwner(sig, navigator, dev)
 					continue
 				}
 
-				if !strings.HasSuffix(member, "Changed") {
+				member, ok := strings.CutPrefix(sig.Name, interfaceName+".")
+				if !ok || !strings.HasSuffix(member, "Changed") {
 					continue
 				}
 
 				log.Debug("Signal received from PureMaps navigator", slog.String("member", member))
 
-				// The object must be retrieved in this loop in case PureMaps was not
-				// open at the time ITD was started.
-				navigator = conn.Object("io.github.rinigus.PureMaps", "/io/github/rinigus/PureMaps/navigator")
 				member = strings.TrimSuffix(member, "Changed")
+				if member == "running" {
+					updateNavRunning(navigator, dev)
+					continue
+				}
 
-				switch member {
-				case "icon":
-					var icon string
-					err = navigator.StoreProperty(iconProperty, &icon)
-					if err != nil {
-						log.Error("Error getting property", slog.Any("error", err), slog.String("property", member))
-						continue
-					}
-
-					err = dev.SetNavFlag(infinitime.NavFlag(icon))
-					if err != nil {
-						log.Error("Error setting flag", slog.Any("error", err), slog.String("property", member))
-						continue
-					}
-				case "narrative":
-					var narrative string
-					err = navigator.StoreProperty(narrativeProperty, &narrative)
-					if err != nil {
-						log.Error("Error getting property", slog.Any("error", err), slog.String("property", member))
-						continue
-					}
-
-					err = dev.SetNavNarrative(narrative)
-					if err != nil {
-						log.Error("Error setting flag", slog.Any("error", err), slog.String("property", member))
-						continue
-					}
-				case "manDist":
-					var manDist string
-					err = navigator.StoreProperty(manDistProperty, &manDist)
-					if err != nil {
-						log.Error("Error getting property", slog.Any("error", err), slog.String("property", member))
-						continue
-					}
-
-					err = dev.SetNavManeuverDistance(manDist)
-					if err != nil {
-						log.Error("Error setting flag", slog.Any("error", err), slog.String("property", member))
-						continue
-					}
-				case "progress":
-					var progress int32
-					err = navigator.StoreProperty(progressProperty, &progress)
-					if err != nil {
-						log.Error("Error getting property", slog.Any("error", err), slog.String("property", member))
-						continue
-					}
-
-					err = dev.SetNavProgress(uint8(progress))
-					if err != nil {
-						log.Error("Error setting flag", slog.Any("error", err), slog.String("property", member))
-						continue
-					}
+				err := setProperty(navigator, dev, member)
+				if err != nil {
+					log.Error("Error setting navigation property", slog.Any("error", err), slog.String("property", member))
 				}
 			case <-ctx.Done():
 				return
@@ -151,10 +100,84 @@ func initPureMaps(ctx context.Context, wg WaitGroup, dev *infinitime.Device) err
 		}
 	}()
 
-	if exists {
-		log.Info("Sending PureMaps data to InfiniTime")
+	return nil
+}
+
+// handlePureMapsOwner handles a NameOwnerChanged signal for PureMaps
+func handlePureMapsOwner(sig *dbus.Signal, navigator dbus.BusObject, dev *infinitime.Device) {
+	if len(sig.Body) != 3 {
+		return
+	}
+
+	newOwner, _ := sig.Body[2].(string)
+	if newOwner != "" {
+		log.Info("PureMaps started, sending data to InfiniTime")
+		updateNavRunning(navigator, dev)
+		return
+	}
+
+	log.Info("PureMaps exited, clearing navigation")
+	err := navUpdates.clear(dev)
+	if err != nil {
+		log.Error("Error clearing navigation", slog.Any("error", err))
 	}
+}
 
+// updateNavRunning sends all the navigation fields to the watch if
+// navigation is running, and clears the navigation app otherwise.
+func updateNavRunning(navigator dbus.BusObject, dev *infinitime.Device) {
+	var running bool
+	err := navigator.StoreProperty(runningProperty, &running)
+	if err != nil {
+		log.Error("Error getting property", slog.Any("error", err), slog.String("property", "running"))
+		return
+	}
+
+	if running {
+		err = setAll(navigator, dev)
+		if err != nil {
+			log.Error("Error setting all navigation fields", slog.Any("error", err))
+		}
+	} else {
+		err = navUpdates.clear(dev)
+		if err != nil {
+			log.Error("Error clearing navigation", slog.Any("error", err))
+		}
+	}
+}
+
+// setProperty gets the given navigator property and sends it to the watch
+func setProperty(navigator dbus.BusObject, dev *infinitime.Device, member string) error {
+	switch member {
+	case "icon":
+		var icon string
+		err := navigator.StoreProperty(iconProperty, &icon)
+		if err != nil {
+			return err
+		}
+		return navUpdates.setFlag(dev, infinitime.NavFlag(icon))
+	case "narrative":
+		var narrative string
+		err := navigator.StoreProperty(narrativeProperty, &narrative)
+		if err != nil {
+			return err
+		}
+		return navUpdates.setNarrative(dev, narrative)
+	case "manDist":
+		var manDist string
+		err := navigator.StoreProperty(manDistProperty, &manDist)
+		if err != nil {
+			return err
+		}
+		return navUpdates.setManDist(dev, manDist)
+	case "progress":
+		var progress int32
+		err := navigator.StoreProperty(progressProperty, &progress)
+		if err != nil {
+			return err
+		}
+		return navUpdates.setProgress(dev, uint8(progress))
+	}
 	return nil
 }
 
@@ -165,40 +188,30 @@ func setAll(navigator dbus.BusObject, dev *infinitime.Device) error {
 		return err
 	}
 
-	err = dev.SetNavFlag(infinitime.NavFlag(icon))
-	if err != nil {
-		return err
-	}
-
 	var narrative string
 	err = navigator.StoreProperty(narrativeProperty, &narrative)
 	if err != nil {
 		return err
 	}
 
-	err = dev.SetNavNarrative(narrative)
-	if err != nil {
-		return err
-	}
-
 	var manDist string
 	err = navigator.StoreProperty(manDistProperty, &manDist)
 	if err != nil {
 		return err
 	}
 
-	err = dev.SetNavManeuverDistance(manDist)
-	if err != nil {
-		return err
-	}
-
 	var progress int32
 	err = navigator.StoreProperty(progressProperty, &progress)
 	if err != nil {
 		return err
 	}
 
-	return dev.SetNavProgress(uint8(progress))
+	return navUpdates.setAll(dev, infinitime.NavState{
+		Flag:      infinitime.NavFlag(icon),
+		Narrative: narrative,
+		ManDist:   manDist,
+		Progress:  uint8(progress),
+	}, true)
 }
 
 // pureMapsExists checks to make sure the PureMaps service exists on the bus
@@ -210,5 +223,5 @@ func pureMapsExists(ctx context.Context, conn *dbus.Conn) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	return strSlcContains(names, "io.github.rinigus.PureMaps"), nil
+	return strSlcContains(names, pureMapsName), nil
 }
@@ -10,7 +10,7 @@ import (
 	_ "modernc.org/sqlite"
 )
 
-func initMetrics(ctx context.Context, wg WaitGroup, dev *infinitime.Device) error {
+func initMetrics(ctx context.Context, wg WaitGroup, dev *infinitime.Device, caps infinitime.Capabilities) error {
 	// If metrics disabled, return nil
 	if !cfg.Metrics.Enabled {
 		return nil
@@ -47,7 +47,7 @@ func initMetrics(ctx context.Context, wg WaitGroup, dev *infinitime.Device) erro
 	}
 
 	// Watch heart rate
-	if cfg.Metrics.HeartRate.Enabled {
+	if cfg.Metrics.HeartRate.Enabled && supported(caps.HeartRate, "heart rate metrics") {
 		err := dev.WatchHeartRate(ctx, func(heartRate uint8, err error) {
 			if err != nil {
 				// Handle error
@@ -64,7 +64,7 @@ func initMetrics(ctx context.Context, wg WaitGroup, dev *infinitime.Device) erro
 	}
 
 	// If step count metrics enabled in config
-	if cfg.Metrics.StepCount.Enabled {
+	if cfg.Metrics.StepCount.Enabled && supported(caps.StepCount, "step count metrics") {
 		// Watch step count
 		err := dev.WatchStepCount(ctx, func(count uint32, err error) {
 			if err != nil {
@@ -81,7 +81,7 @@ func initMetrics(ctx context.Context, wg WaitGroup, dev *infinitime.Device) erro
 	}
 
 	// Watch battery level
-	if cfg.Metrics.BattLevel.Enabled {
+	if cfg.Metrics.BattLevel.Enabled && supported(caps.Battery, "battery level metrics") {
 		err := dev.WatchBatteryLevel(ctx, func(battLevel uint8, err error) {
 			if err != nil {
 				// Handle error
@@ -98,7 +98,7 @@ func initMetrics(ctx context.Context, wg WaitGroup, dev *infinitime.Device) erro
 	}
 
 	// Watch motion values
-	if cfg.Metrics.Motion.Enabled {
+	if cfg.Metrics.Motion.Enabled && supported(caps.Motion, "motion metrics") {
 		log.Warn("Motion metrics are enabled; this may decrease the battery life of your PineTime!")
 		
 		err := dev.WatchMotion(ctx, func(motionVals infinitime.MotionValues, err error) {
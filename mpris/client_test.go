@@ -0,0 +1,469 @@
+package mpris
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/prop"
+	"go.elara.ws/itd/internal/utils"
+)
+
+const busConfig = `<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">
+<busconfig>
+  <type>session</type>
+  <listen>unix:path=%SOCKET%</listen>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>`
+
+// startBus starts a private dbus-daemon for the duration of the test
+// and returns its address. The test is skipped if dbus-daemon isn't installed.
+func startBus(t *testing.T) string {
+	t.Helper()
+
+	path, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		t.Skip("dbus-daemon not found, skipping")
+	}
+
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "bus.conf")
+	cfg := strings.ReplaceAll(busConfig, "%SOCKET%", filepath.Join(dir, "bus"))
+	err = os.WriteFile(cfgPath, []byte(cfg), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(path, "--config-file="+cfgPath, "--nofork", "--print-address")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cmd.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	addr, err := bufio.NewReader(stdout).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(addr)
+}
+
+// fakePlayer is a minimal MPRIS player that records the methods called on it
+type fakePlayer struct {
+	conn  *dbus.Conn
+	props *prop.Properties
+
+	mtx   sync.Mutex
+	calls []string
+}
+
+func (fp *fakePlayer) record(name string) *dbus.Error {
+	fp.mtx.Lock()
+	defer fp.mtx.Unlock()
+	fp.calls = append(fp.calls, name)
+	return nil
+}
+
+func (fp *fakePlayer) Play() *dbus.Error     { return fp.record("Play") }
+func (fp *fakePlayer) Pause() *dbus.Error    { return fp.record("Pause") }
+func (fp *fakePlayer) Next() *dbus.Error     { return fp.record("Next") }
+func (fp *fakePlayer) Previous() *dbus.Error { return fp.record("Previous") }
+
+func (fp *fakePlayer) lastCall() string {
+	fp.mtx.Lock()
+	defer fp.mtx.Unlock()
+	if len(fp.calls) == 0 {
+		return ""
+	}
+	return fp.calls[len(fp.calls)-1]
+}
+
+// set sets a property on the player, emitting PropertiesChanged
+func (fp *fakePlayer) set(t *testing.T, name string, val any) {
+	t.Helper()
+	derr := fp.props.Set("org.mpris.MediaPlayer2.Player", name, dbus.MakeVariant(val))
+	if derr != nil {
+		t.Fatal(derr)
+	}
+}
+
+// startPlayer exports a fake player with the given name on the bus at addr
+func startPlayer(t *testing.T, addr, name string) *fakePlayer {
+	t.Helper()
+
+	conn, err := utils.NewBusConn(context.Background(), addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	fp := &fakePlayer{conn: conn}
+	err = conn.Export(fp, "/org/mpris/MediaPlayer2", "org.mpris.MediaPlayer2.Player")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fp.props, err = prop.Export(conn, "/org/mpris/MediaPlayer2", prop.Map{
+		"org.mpris.MediaPlayer2.Player": {
+			"PlaybackStatus": {Value: "Stopped", Writable: true, Emit: prop.EmitTrue},
+			"Metadata":       {Value: map[string]dbus.Variant{}, Writable: true, Emit: prop.EmitTrue},
+			"Volume":         {Value: 0.5, Writable: true, Emit: prop.EmitTrue},
+			"Position":       {Value: int64(0), Writable: true, Emit: prop.EmitFalse},
+			"Shuffle":        {Value: false, Writable: true, Emit: prop.EmitTrue},
+			"LoopStatus":     {Value: "None", Writable: true, Emit: prop.EmitTrue},
+			"Rate":           {Value: 1.0, Writable: true, Emit: prop.EmitTrue},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := conn.RequestName("org.mpris.MediaPlayer2."+name, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		t.Fatalf("Expected to become primary owner of player name, got reply %d", reply)
+	}
+
+	return fp
+}
+
+// newTestClient starts a private bus and a fake player, and returns
+// a client connected to that bus.
+func newTestClient(t *testing.T) (*Client, *fakePlayer) {
+	t.Helper()
+	addr := startBus(t)
+	fp := startPlayer(t, addr, "fake")
+
+	c, err := NewFromAddress(context.Background(), addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	return c, fp
+}
+
+type change struct {
+	ct  ChangeType
+	val string
+}
+
+// awaitChange waits for a change of the given type on ch
+func awaitChange(t *testing.T, ch <-chan change, ct ChangeType) string {
+	t.Helper()
+	return awaitChanges(t, ch, ct)[ct]
+}
+
+// awaitChanges waits until changes of all the given types have been
+// received on ch, since the order of fields in a signal isn't defined.
+func awaitChanges(t *testing.T, ch <-chan change, types ...ChangeType) map[ChangeType]string {
+	t.Helper()
+	out := map[ChangeType]string{}
+	timeout := time.After(5 * time.Second)
+	for len(out) < len(types) {
+		select {
+		case c := <-ch:
+			for _, ct := range types {
+				if c.ct == ct {
+					out[ct] = c.val
+				}
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for changes, got %v", out)
+		}
+	}
+	return out
+}
+
+func subscribe(c *Client) <-chan change {
+	ch := make(chan change, 32)
+	c.OnChange(func(ct ChangeType, val string) {
+		ch <- change{ct, val}
+	})
+	return ch
+}
+
+// TestClientControls checks that the playback control methods
+// call the corresponding methods on the player.
+func TestClientControls(t *testing.T) {
+	c, fp := newTestClient(t)
+
+	tests := []struct {
+		fn     func() error
+		expect string
+	}{
+		{c.Play, "Play"},
+		{c.Pause, "Pause"},
+		{c.Next, "Next"},
+		{c.Prev, "Previous"},
+	}
+
+	for _, tt := range tests {
+		err := tt.fn()
+		if err != nil {
+			t.Fatalf("Expected no error calling %s, got %v", tt.expect, err)
+		}
+		if call := fp.lastCall(); call != tt.expect {
+			t.Errorf("Expected player method %q to be called, but got %q", tt.expect, call)
+		}
+	}
+}
+
+// TestClientVolume checks that VolUp and VolDown change the player's volume.
+func TestClientVolume(t *testing.T) {
+	c, fp := newTestClient(t)
+
+	err := c.VolUp(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vol := fp.props.GetMust("org.mpris.MediaPlayer2.Player", "Volume").(float64); vol < 0.59 || vol > 0.61 {
+		t.Errorf("Expected volume to be 0.6, but got %v", vol)
+	}
+
+	err = c.VolDown(20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vol := fp.props.GetMust("org.mpris.MediaPlayer2.Player", "Volume").(float64); vol < 0.39 || vol > 0.41 {
+		t.Errorf("Expected volume to be 0.4, but got %v", vol)
+	}
+}
+
+// ignoringProperties accepts writes to the player's volume
+// without changing it, like most web browsers do
+type ignoringProperties struct {
+	*prop.Properties
+}
+
+func (ip ignoringProperties) Set(iface, property string, newv dbus.Variant) *dbus.Error {
+	if property == "Volume" {
+		return nil
+	}
+	return ip.Properties.Set(iface, property, newv)
+}
+
+// TestClientVolumeIgnored checks that VolUp returns ErrNoVolume
+// if the player accepts volume changes but ignores them.
+func TestClientVolumeIgnored(t *testing.T) {
+	c, fp := newTestClient(t)
+
+	err := fp.conn.Export(ignoringProperties{fp.props}, "/org/mpris/MediaPlayer2", "org.freedesktop.DBus.Properties")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.VolUp(10)
+	if !errors.Is(err, ErrNoVolume) {
+		t.Errorf("Expected ErrNoVolume, but got %v", err)
+	}
+}
+
+// TestClientOnChange checks that changes are delivered to every subscriber,
+// and that cancelled subscribers no longer receive them.
+func TestClientOnChange(t *testing.T) {
+	c, fp := newTestClient(t)
+
+	ch1 := subscribe(c)
+	ch2 := make(chan change, 32)
+	cancel := c.OnChange(func(ct ChangeType, val string) {
+		ch2 <- change{ct, val}
+	})
+
+	fp.set(t, "Metadata", map[string]dbus.Variant{
+		"xesam:title":  dbus.MakeVariant("Song"),
+		"xesam:artist": dbus.MakeVariant([]string{"A", "B"}),
+		"xesam:album":  dbus.MakeVariant(""),
+		"mpris:length": dbus.MakeVariant(int64(180_000_000)),
+	})
+
+	expected := map[ChangeType]string{
+		ChangeTypeTitle:  "Song",
+		ChangeTypeArtist: "A, B",
+		ChangeTypeAlbum:  "Unknown Album",
+		ChangeTypeLength: "180000000",
+	}
+	for _, ch := range []<-chan change{ch1, ch2} {
+		changes := awaitChanges(t, ch, ChangeTypeTitle, ChangeTypeArtist, ChangeTypeAlbum, ChangeTypeLength)
+		if !reflect.DeepEqual(changes, expected) {
+			t.Errorf("Expected changes to be %v, but got %v", expected, changes)
+		}
+	}
+
+	cancel()
+
+	fp.set(t, "Shuffle", true)
+	if shuffle := awaitChange(t, ch1, ChangeTypeShuffle); shuffle != "true" {
+		t.Errorf("Expected shuffle to be %q, but got %q", "true", shuffle)
+	}
+	fp.set(t, "LoopStatus", "Playlist")
+	if loopStatus := awaitChange(t, ch1, ChangeTypeLoopStatus); loopStatus != "Playlist" {
+		t.Errorf("Expected loop status to be %q, but got %q", "Playlist", loopStatus)
+	}
+	fp.set(t, "Rate", 1.5)
+	if rate := awaitChange(t, ch1, ChangeTypeRate); rate != "1.5" {
+		t.Errorf("Expected rate to be %q, but got %q", "1.5", rate)
+	}
+
+	for len(ch2) > 0 {
+		if c := <-ch2; c.ct == ChangeTypeShuffle || c.ct == ChangeTypeLoopStatus || c.ct == ChangeTypeRate {
+			t.Errorf("Expected cancelled subscriber not to receive %s change", c.ct)
+		}
+	}
+}
+
+// TestClientPosition checks that position changes are reported
+// when the player seeks and when the playback status changes.
+func TestClientPosition(t *testing.T) {
+	c, fp := newTestClient(t)
+	ch := subscribe(c)
+
+	fp.set(t, "Position", int64(42_000_000))
+	fp.set(t, "PlaybackStatus", "Playing")
+	if status := awaitChange(t, ch, ChangeTypeStatus); status != "Playing" {
+		t.Errorf("Expected status to be %q, but got %q", "Playing", status)
+	}
+	if pos := awaitChange(t, ch, ChangeTypePosition); pos != "42000000" {
+		t.Errorf("Expected position to be %q, but got %q", "42000000", pos)
+	}
+
+	err := fp.conn.Emit("/org/mpris/MediaPlayer2", "org.mpris.MediaPlayer2.Player.Seeked", int64(10_000_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos := awaitChange(t, ch, ChangeTypePosition); pos != "10000000" {
+		t.Errorf("Expected position to be %q, but got %q", "10000000", pos)
+	}
+}
+
+// TestClientGetState checks that GetState returns the full state of the player.
+func TestClientGetState(t *testing.T) {
+	c, fp := newTestClient(t)
+
+	fp.set(t, "Metadata", map[string]dbus.Variant{
+		"xesam:title":  dbus.MakeVariant("Song"),
+		"xesam:artist": dbus.MakeVariant([]string{"Artist"}),
+		"mpris:length": dbus.MakeVariant(int64(60_000_000)),
+	})
+	fp.set(t, "PlaybackStatus", "Paused")
+	fp.set(t, "Position", int64(5_000_000))
+	fp.set(t, "Shuffle", true)
+
+	state, err := c.GetState()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := State{
+		Title:      "Song",
+		Artist:     "Artist",
+		Album:      "Unknown Album",
+		Status:     "Paused",
+		Position:   5 * time.Second,
+		Length:     time.Minute,
+		Shuffle:    true,
+		LoopStatus: "None",
+		Rate:       1,
+	}
+	if state != expected {
+		t.Errorf("Expected state to be %+v, but got %+v", expected, state)
+	}
+}
+
+// TestClientNoPlayer checks the client's behavior when there are no players on the bus.
+func TestClientNoPlayer(t *testing.T) {
+	addr := startBus(t)
+
+	c, err := NewFromAddress(context.Background(), addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if err := c.Play(); err != nil {
+		t.Errorf("Expected no error without a player, but got %v", err)
+	}
+	if _, err := c.GetState(); err != ErrNoPlayer {
+		t.Errorf("Expected ErrNoPlayer, but got %v", err)
+	}
+}
+
+// TestClientClose checks that the client closes when its context is
+// cancelled, and that closing it more than once doesn't panic.
+func TestClientClose(t *testing.T) {
+	addr := startBus(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c, err := NewFromAddress(ctx, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+
+	select {
+	case <-c.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for client to close after context cancellation")
+	}
+
+	c.Close()
+	c.Close()
+}
+
+// TestClientCloseBlockedCallback checks that Close doesn't wait
+// for a callback that never returns.
+func TestClientCloseBlockedCallback(t *testing.T) {
+	c, fp := newTestClient(t)
+
+	called := make(chan struct{})
+	block := make(chan struct{})
+	defer close(block)
+	var once sync.Once
+	c.OnChange(func(ChangeType, string) {
+		once.Do(func() { close(called) })
+		<-block
+	})
+
+	fp.set(t, "Shuffle", true)
+	select {
+	case <-called:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for callback")
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		c.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close waited for a blocked callback")
+	}
+}
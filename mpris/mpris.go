@@ -2,75 +2,156 @@ package mpris
 
 import (
 	"context"
+	"errors"
+	"math"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/godbus/dbus/v5"
 	"go.elara.ws/itd/internal/utils"
 )
 
 var (
+	// ErrNoPlayer is returned when there are no MPRIS players on the bus
+	ErrNoPlayer = errors.New("no mpris player found")
+	// ErrNoVolume is returned when the player doesn't allow its volume to be changed
+	ErrNoVolume = errors.New("mpris player doesn't support volume control")
+)
+
+// Client is an MPRIS client. It controls the active player and
+// notifies subscribers when the state of any player changes.
+type Client struct {
 	method, monitor *dbus.Conn
 	monitorCh       chan *dbus.Message
-	onChangeOnce    sync.Once
-)
 
-// Init makes required connections to DBus and
-// initializes change monitoring channel
-func Init(ctx context.Context) error {
-	// Connect to session bus for monitoring
-	monitorConn, err := utils.NewSessionBusConn(ctx)
+	mtx        sync.Mutex
+	nextFuncID int
+	callbacks  map[int]func(ChangeType, string)
+
+	// queue contains the changes that haven't been passed to the callbacks
+	// yet. They're passed by a separate goroutine, so that a slow callback
+	// can't hold up the monitor connection or Close.
+	queueMtx sync.Mutex
+	queue    []queuedChange
+	queued   chan struct{}
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+type queuedChange struct {
+	ct  ChangeType
+	val string
+}
+
+// New connects to the session bus and returns a new client.
+// The client is closed when ctx is canceled.
+func New(ctx context.Context) (*Client, error) {
+	return newClient(ctx, utils.NewSessionBusConn)
+}
+
+// NewFromAddress connects to the bus at the given address and
+// returns a new client. The client is closed when ctx is canceled.
+func NewFromAddress(ctx context.Context, addr string) (*Client, error) {
+	return newClient(ctx, func(ctx context.Context) (*dbus.Conn, error) {
+		return utils.NewBusConn(ctx, addr)
+	})
+}
+
+func newClient(ctx context.Context, connect func(context.Context) (*dbus.Conn, error)) (*Client, error) {
+	// Connect to the bus for monitoring
+	monitorConn, err := connect(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// Add match rule for PropertiesChanged on media player
-	monitorConn.AddMatchSignal(
+	err = monitorConn.AddMatchSignal(
 		dbus.WithMatchObjectPath("/org/mpris/MediaPlayer2"),
 		dbus.WithMatchInterface("org.freedesktop.DBus.Properties"),
 		dbus.WithMatchMember("PropertiesChanged"),
 	)
-	monitorCh = make(chan *dbus.Message, 10)
-	monitorConn.Eavesdrop(monitorCh)
+	if err != nil {
+		monitorConn.Close()
+		return nil, err
+	}
+	// Add match rule for Seeked, since players don't emit
+	// PropertiesChanged when the position changes
+	err = monitorConn.AddMatchSignal(
+		dbus.WithMatchObjectPath("/org/mpris/MediaPlayer2"),
+		dbus.WithMatchInterface("org.mpris.MediaPlayer2.Player"),
+		dbus.WithMatchMember("Seeked"),
+	)
+	if err != nil {
+		monitorConn.Close()
+		return nil, err
+	}
 
-	// Connect to session bus for method calls
-	methodConn, err := utils.NewSessionBusConn(ctx)
+	// Connect to the bus for method calls
+	methodConn, err := connect(ctx)
 	if err != nil {
-		return err
+		monitorConn.Close()
+		return nil, err
 	}
-	method, monitor = methodConn, monitorConn
-	return nil
+
+	c := &Client{
+		method:    methodConn,
+		monitor:   monitorConn,
+		monitorCh: make(chan *dbus.Message, 10),
+		callbacks: map[int]func(ChangeType, string){},
+		queued:    make(chan struct{}, 1),
+		done:      make(chan struct{}),
+	}
+	// The monitor connection closes this channel when it's closed
+	monitorConn.Eavesdrop(c.monitorCh)
+
+	go c.handleChanges()
+	go c.dispatchChanges()
+	context.AfterFunc(ctx, func() { c.Close() })
+
+	return c, nil
 }
 
-// Exit closes all connections and channels
-func Exit() {
-	close(monitorCh)
-	method.Close()
-	monitor.Close()
+// Close closes the client's connections. It doesn't wait for
+// callbacks that are running. It's safe to call Close more than once.
+func (c *Client) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		err = errors.Join(c.monitor.Close(), c.method.Close())
+		<-c.done
+	})
+	return err
 }
 
 // Play uses MPRIS to play media
-func Play() error {
-	player, err := getPlayerObj()
-	if err != nil {
-		return err
-	}
-	if player != nil {
-		call := player.Call("org.mpris.MediaPlayer2.Player.Play", 0)
-		if call.Err != nil {
-			return call.Err
-		}
-	}
-	return nil
+func (c *Client) Play() error {
+	return c.callPlayer("org.mpris.MediaPlayer2.Player.Play")
 }
 
 // Pause uses MPRIS to pause media
-func Pause() error {
-	player, err := getPlayerObj()
+func (c *Client) Pause() error {
+	return c.callPlayer("org.mpris.MediaPlayer2.Player.Pause")
+}
+
+// Next uses MPRIS to skip to next media
+func (c *Client) Next() error {
+	return c.callPlayer("org.mpris.MediaPlayer2.Player.Next")
+}
+
+// Prev uses MPRIS to skip to previous media
+func (c *Client) Prev() error {
+	return c.callPlayer("org.mpris.MediaPlayer2.Player.Previous")
+}
+
+// callPlayer calls the given method on the first player found in DBus
+func (c *Client) callPlayer(method string) error {
+	player, err := c.getPlayerObj()
 	if err != nil {
 		return err
 	}
 	if player != nil {
-		call := player.Call("org.mpris.MediaPlayer2.Player.Pause", 0)
+		call := player.Call(method, 0)
 		if call.Err != nil {
 			return call.Err
 		}
@@ -78,72 +159,135 @@ func Pause() error {
 	return nil
 }
 
-// Next uses MPRIS to skip to next media
-func Next() error {
-	player, err := getPlayerObj()
+// VolUp raises the player's volume by the given percentage.
+// Unlike the other controls, it returns [ErrNoPlayer] if there are
+// no players, and [ErrNoVolume] if the player doesn't expose its volume
+// or ignores changes to it, so that callers can fall back to a different
+// volume control.
+func (c *Client) VolUp(percent uint) error {
+	return c.changeVolume(float64(percent) / 100)
+}
+
+// VolDown lowers the player's volume by the given percentage.
+// See [Client.VolUp] for the errors it returns.
+func (c *Client) VolDown(percent uint) error {
+	return c.changeVolume(-float64(percent) / 100)
+}
+
+func (c *Client) changeVolume(delta float64) error {
+	player, err := c.getActivePlayerObj()
 	if err != nil {
 		return err
 	}
-	if player != nil {
-		call := player.Call("org.mpris.MediaPlayer2.Player.Next", 0)
-		if call.Err != nil {
-			return call.Err
-		}
+	if player == nil {
+		return ErrNoPlayer
 	}
-	return nil
-}
 
-// Prev uses MPRIS to skip to previous media
-func Prev() error {
-	player, err := getPlayerObj()
+	currentVal, err := player.GetProperty("org.mpris.MediaPlayer2.Player.Volume")
 	if err != nil {
-		return err
+		return ErrNoVolume
 	}
-	if player != nil {
-		call := player.Call("org.mpris.MediaPlayer2.Player.Previous", 0)
-		if call.Err != nil {
-			return call.Err
-		}
+	current, ok := currentVal.Value().(float64)
+	if !ok {
+		return ErrNoVolume
 	}
-	return nil
-}
 
-func VolUp(percent uint) error {
-	player, err := getPlayerObj()
+	newVal := min(max(current+delta, 0), 1)
+	err = player.SetProperty("org.mpris.MediaPlayer2.Player.Volume", dbus.MakeVariant(newVal))
 	if err != nil {
-		return err
+		return errors.Join(ErrNoVolume, err)
 	}
-	if player != nil {
-		currentVal, err := player.GetProperty("org.mpris.MediaPlayer2.Player.Volume")
+
+	// Some players, such as most web browsers, accept volume changes
+	// but ignore them, so read the volume back to check that it changed.
+	if newVal != current {
+		updatedVal, err := player.GetProperty("org.mpris.MediaPlayer2.Player.Volume")
 		if err != nil {
-			return err
+			return ErrNoVolume
 		}
-		newVal := currentVal.Value().(float64) + (float64(percent) / 100)
-		err = player.SetProperty("org.mpris.MediaPlayer2.Player.Volume", newVal)
-		if err != nil {
-			return err
+		updated, ok := updatedVal.Value().(float64)
+		if !ok || math.Abs(updated-newVal) > math.Abs(updated-current) {
+			return ErrNoVolume
 		}
 	}
+
 	return nil
 }
 
-func VolDown(percent uint) error {
-	player, err := getPlayerObj()
+// State represents the full state of an MPRIS player
+type State struct {
+	Title      string
+	Artist     string
+	Album      string
+	Status     string
+	Position   time.Duration
+	Length     time.Duration
+	Shuffle    bool
+	LoopStatus string
+	Rate       float64
+}
+
+// GetState gets the full state of the active player, which is the
+// first player that's currently playing, or the first player found if
+// none of them are.
+func (c *Client) GetState() (State, error) {
+	player, err := c.getActivePlayerObj()
 	if err != nil {
-		return err
+		return State{}, err
 	}
-	if player != nil {
-		currentVal, err := player.GetProperty("org.mpris.MediaPlayer2.Player.Volume")
-		if err != nil {
-			return err
+	if player == nil {
+		return State{}, ErrNoPlayer
+	}
+
+	var props map[string]dbus.Variant
+	err = player.Call("org.freedesktop.DBus.Properties.GetAll", 0, "org.mpris.MediaPlayer2.Player").Store(&props)
+	if err != nil {
+		return State{}, err
+	}
+
+	out := State{
+		Title:      "Unknown " + ChangeTypeTitle.String(),
+		Artist:     "Unknown " + ChangeTypeArtist.String(),
+		Album:      "Unknown " + ChangeTypeAlbum.String(),
+		LoopStatus: "None",
+		Rate:       1,
+	}
+
+	if fields, ok := props["Metadata"].Value().(map[string]dbus.Variant); ok {
+		if title, _ := fields["xesam:title"].Value().(string); title != "" {
+			out.Title = title
 		}
-		newVal := currentVal.Value().(float64) - (float64(percent) / 100)
-		err = player.SetProperty("org.mpris.MediaPlayer2.Player.Volume", newVal)
-		if err != nil {
-			return err
+		if album, _ := fields["xesam:album"].Value().(string); album != "" {
+			out.Album = album
+		}
+		switch artistVal := fields["xesam:artist"].Value().(type) {
+		case string:
+			if artistVal != "" {
+				out.Artist = artistVal
+			}
+		case []string:
+			if len(artistVal) > 0 {
+				out.Artist = strings.Join(artistVal, ", ")
+			}
+		}
+		if length, ok := variantInt64(fields["mpris:length"]); ok {
+			out.Length = time.Duration(length) * time.Microsecond
 		}
 	}
-	return nil
+
+	out.Status, _ = props["PlaybackStatus"].Value().(string)
+	if pos, ok := variantInt64(props["Position"]); ok {
+		out.Position = time.Duration(pos) * time.Microsecond
+	}
+	out.Shuffle, _ = props["Shuffle"].Value().(bool)
+	if loopStatus, ok := props["LoopStatus"].Value().(string); ok {
+		out.LoopStatus = loopStatus
+	}
+	if rate, ok := props["Rate"].Value().(float64); ok {
+		out.Rate = rate
+	}
+
+	return out, nil
 }
 
 type ChangeType int
@@ -153,6 +297,11 @@ const (
 	ChangeTypeArtist
 	ChangeTypeAlbum
 	ChangeTypeStatus
+	ChangeTypePosition
+	ChangeTypeLength
+	ChangeTypeShuffle
+	ChangeTypeLoopStatus
+	ChangeTypeRate
 )
 
 func (ct ChangeType) String() string {
@@ -165,63 +314,214 @@ func (ct ChangeType) String() string {
 		return "Artist"
 	case ChangeTypeStatus:
 		return "Status"
+	case ChangeTypePosition:
+		return "Position"
+	case ChangeTypeLength:
+		return "Length"
+	case ChangeTypeShuffle:
+		return "Shuffle"
+	case ChangeTypeLoopStatus:
+		return "LoopStatus"
+	case ChangeTypeRate:
+		return "Rate"
 	}
 	return ""
 }
 
-// OnChange runs cb when a value changes
-func OnChange(cb func(ChangeType, string)) {
-	go onChangeOnce.Do(func() {
-		// For every message on channel
-		for msg := range monitorCh {
-			// Parse PropertiesChanged
-			iface, changed, ok := parsePropertiesChanged(msg)
-			if !ok || iface != "org.mpris.MediaPlayer2.Player" {
-				continue
+// OnChange runs cb when a value changes.
+//
+// For ChangeTypePosition and ChangeTypeLength, the value is a
+// duration in microseconds. For ChangeTypeShuffle, it's "true" or
+// "false", and for ChangeTypeRate, it's a floating point number.
+//
+// Any amount of callbacks may be registered. The returned function
+// removes cb, so that it no longer receives changes.
+func (c *Client) OnChange(cb func(ChangeType, string)) (cancel func()) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	funcID := c.nextFuncID
+	c.callbacks[funcID] = cb
+	c.nextFuncID++
+
+	return func() {
+		c.mtx.Lock()
+		delete(c.callbacks, funcID)
+		c.mtx.Unlock()
+	}
+}
+
+// notify queues the given change for the registered callbacks
+func (c *Client) notify(ct ChangeType, val string) {
+	c.queueMtx.Lock()
+	c.queue = append(c.queue, queuedChange{ct, val})
+	c.queueMtx.Unlock()
+
+	select {
+	case c.queued <- struct{}{}:
+	default:
+	}
+}
+
+// dispatchChanges runs the registered callbacks for each queued change,
+// in order, until the client is closed
+func (c *Client) dispatchChanges() {
+	for {
+		select {
+		case <-c.queued:
+		case <-c.done:
+			return
+		}
+
+		c.queueMtx.Lock()
+		queue := c.queue
+		c.queue = nil
+		c.queueMtx.Unlock()
+
+		for _, change := range queue {
+			c.mtx.Lock()
+			callbacks := make([]func(ChangeType, string), 0, len(c.callbacks))
+			for _, cb := range c.callbacks {
+				callbacks = append(callbacks, cb)
 			}
+			c.mtx.Unlock()
+
+			for _, cb := range callbacks {
+				cb(change.ct, change.val)
+			}
+		}
+	}
+}
+
+// handleChanges parses incoming signals and notifies subscribers
+// of any changes, until the monitor connection is closed.
+func (c *Client) handleChanges() {
+	defer close(c.done)
+
+	// For every message on channel
+	for msg := range c.monitorCh {
+		// Seeked is emitted instead of a property change when the position changes
+		if pos, ok := parseSeeked(msg); ok {
+			c.notify(ChangeTypePosition, strconv.FormatInt(pos, 10))
+			continue
+		}
 
-			// For every property changed
-			for name, val := range changed {
-				// If metadata changed
-				if name == "Metadata" {
-					// Get fields
-					fields := val.Value().(map[string]dbus.Variant)
-					// For every field
-					for name, val := range fields {
-						// Handle each field appropriately
-						if strings.HasSuffix(name, "title") {
-							title := val.Value().(string)
-							if title == "" {
-								title = "Unknown " + ChangeTypeTitle.String()
-							}
-							cb(ChangeTypeTitle, title)
-						} else if strings.HasSuffix(name, "album") {
-							album := val.Value().(string)
-							if album == "" {
-								album = "Unknown " + ChangeTypeAlbum.String()
-							}
-							cb(ChangeTypeAlbum, album)
-						} else if strings.HasSuffix(name, "artist") {
-							var artists string
-							switch artistVal := val.Value().(type) {
-							case string:
-								artists = artistVal
-							case []string:
-								artists = strings.Join(artistVal, ", ")
-							}
-							if artists == "" {
-								artists = "Unknown " + ChangeTypeArtist.String()
-							}
-							cb(ChangeTypeArtist, artists)
+		// Parse PropertiesChanged
+		iface, changed, ok := parsePropertiesChanged(msg)
+		if !ok || iface != "org.mpris.MediaPlayer2.Player" {
+			continue
+		}
+
+		// The position is reset whenever the track or status changes,
+		// but players don't usually report it, so it has to be requested.
+		requestPos, posChanged := false, false
+
+		// For every property changed
+		for name, val := range changed {
+			switch name {
+			case "Metadata":
+				// Get fields
+				fields := val.Value().(map[string]dbus.Variant)
+				// For every field
+				for name, val := range fields {
+					// Handle each field appropriately
+					if strings.HasSuffix(name, "title") {
+						title := val.Value().(string)
+						if title == "" {
+							title = "Unknown " + ChangeTypeTitle.String()
+						}
+						c.notify(ChangeTypeTitle, title)
+					} else if strings.HasSuffix(name, "album") {
+						album := val.Value().(string)
+						if album == "" {
+							album = "Unknown " + ChangeTypeAlbum.String()
+						}
+						c.notify(ChangeTypeAlbum, album)
+					} else if strings.HasSuffix(name, "artist") {
+						var artists string
+						switch artistVal := val.Value().(type) {
+						case string:
+							artists = artistVal
+						case []string:
+							artists = strings.Join(artistVal, ", ")
+						}
+						if artists == "" {
+							artists = "Unknown " + ChangeTypeArtist.String()
+						}
+						c.notify(ChangeTypeArtist, artists)
+					} else if name == "mpris:length" {
+						if length, ok := variantInt64(val); ok {
+							c.notify(ChangeTypeLength, strconv.FormatInt(length, 10))
 						}
 					}
-				} else if name == "PlaybackStatus" {
-					// Handle status change
-					cb(ChangeTypeStatus, val.Value().(string))
+				}
+				requestPos = true
+			case "PlaybackStatus":
+				// Handle status change
+				c.notify(ChangeTypeStatus, val.Value().(string))
+				requestPos = true
+			case "Position":
+				if pos, ok := variantInt64(val); ok {
+					c.notify(ChangeTypePosition, strconv.FormatInt(pos, 10))
+					posChanged = true
+				}
+			case "Shuffle":
+				if shuffle, ok := val.Value().(bool); ok {
+					c.notify(ChangeTypeShuffle, strconv.FormatBool(shuffle))
+				}
+			case "LoopStatus":
+				if loopStatus, ok := val.Value().(string); ok {
+					c.notify(ChangeTypeLoopStatus, loopStatus)
+				}
+			case "Rate":
+				if rate, ok := val.Value().(float64); ok {
+					c.notify(ChangeTypeRate, strconv.FormatFloat(rate, 'f', -1, 64))
 				}
 			}
 		}
-	})
+
+		if requestPos && !posChanged {
+			pos, err := c.getPosition(msg)
+			if err == nil {
+				c.notify(ChangeTypePosition, strconv.FormatInt(pos, 10))
+			}
+		}
+	}
+}
+
+// getPosition gets the current position of the player that sent msg
+func (c *Client) getPosition(msg *dbus.Message) (int64, error) {
+	sender, ok := msg.Headers[dbus.FieldSender].Value().(string)
+	if !ok {
+		return 0, errors.New("message has no sender")
+	}
+
+	player := c.method.Object(sender, "/org/mpris/MediaPlayer2")
+	val, err := player.GetProperty("org.mpris.MediaPlayer2.Player.Position")
+	if err != nil {
+		return 0, err
+	}
+
+	pos, _ := variantInt64(val)
+	return pos, nil
+}
+
+// variantInt64 converts an integer variant to an int64. This is
+// required because some players don't use the types from the spec.
+func variantInt64(v dbus.Variant) (int64, bool) {
+	switch val := v.Value().(type) {
+	case int64:
+		return val, true
+	case uint64:
+		return int64(val), true
+	case int32:
+		return int64(val), true
+	case uint32:
+		return int64(val), true
+	case float64:
+		return int64(val), true
+	default:
+		return 0, false
+	}
 }
 
 // getPlayerNames gets all DBus MPRIS player bus names
@@ -242,15 +542,41 @@ func getPlayerNames(conn *dbus.Conn) ([]string, error) {
 
 // GetPlayerObj gets the object corresponding to the first
 // bus name found in DBus
-func getPlayerObj() (dbus.BusObject, error) {
-	players, err := getPlayerNames(method)
+func (c *Client) getPlayerObj() (dbus.BusObject, error) {
+	players, err := getPlayerNames(c.method)
+	if err != nil {
+		return nil, err
+	}
+	if len(players) == 0 {
+		return nil, nil
+	}
+	return c.method.Object(players[0], "/org/mpris/MediaPlayer2"), nil
+}
+
+// getActivePlayerObj gets the object corresponding to the first
+// player that's currently playing, falling back to the first player
+// found in DBus
+func (c *Client) getActivePlayerObj() (dbus.BusObject, error) {
+	players, err := getPlayerNames(c.method)
 	if err != nil {
 		return nil, err
 	}
 	if len(players) == 0 {
 		return nil, nil
 	}
-	return method.Object(players[0], "/org/mpris/MediaPlayer2"), nil
+
+	for _, name := range players {
+		player := c.method.Object(name, "/org/mpris/MediaPlayer2")
+		status, err := player.GetProperty("org.mpris.MediaPlayer2.Player.PlaybackStatus")
+		if err != nil {
+			continue
+		}
+		if status.Value() == "Playing" {
+			return player, nil
+		}
+	}
+
+	return c.method.Object(players[0], "/org/mpris/MediaPlayer2"), nil
 }
 
 // parsePropertiesChanged parses a DBus PropertiesChanged signal
@@ -268,3 +594,15 @@ func parsePropertiesChanged(msg *dbus.Message) (iface string, changed map[string
 	}
 	return
 }
+
+// parseSeeked parses an MPRIS Seeked signal
+func parseSeeked(msg *dbus.Message) (pos int64, ok bool) {
+	if member, _ := msg.Headers[dbus.FieldMember].Value().(string); member != "Seeked" {
+		return 0, false
+	}
+	if len(msg.Body) != 1 {
+		return 0, false
+	}
+	pos, ok = msg.Body[0].(int64)
+	return pos, ok
+}
@@ -82,3 +82,35 @@ func TestParsePropertiesChanged(t *testing.T) {
 		t.Error("Expected parsePropertiesChanged to return false, but got true")
 	}
 }
+
+// TestParseSeeked checks the parseSeeked function to make sure
+// it correctly parses an MPRIS Seeked signal.
+func TestParseSeeked(t *testing.T) {
+	msg := &dbus.Message{
+		Headers: map[dbus.HeaderField]dbus.Variant{
+			dbus.FieldMember: dbus.MakeVariant("Seeked"),
+		},
+		Body: []interface{}{int64(5_000_000)},
+	}
+
+	pos, ok := parseSeeked(msg)
+	if !ok {
+		t.Fatal("Expected parseSeeked to return true, but got false")
+	}
+	if pos != 5_000_000 {
+		t.Errorf("Expected pos to be %d, but got %d", 5_000_000, pos)
+	}
+
+	// Test a message with a different member
+	msg.Headers[dbus.FieldMember] = dbus.MakeVariant("PropertiesChanged")
+	_, ok = parseSeeked(msg)
+	if ok {
+		t.Error("Expected parseSeeked to return false, but got true")
+	}
+
+	// Test a message without a member header
+	_, ok = parseSeeked(&dbus.Message{Body: []interface{}{int64(0)}})
+	if ok {
+		t.Error("Expected parseSeeked to return false, but got true")
+	}
+}
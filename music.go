@@ -20,63 +20,396 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"strconv"
+	"sync"
+	"time"
 
 	"go.elara.ws/itd/infinitime"
 	"go.elara.ws/itd/mpris"
 	"go.elara.ws/itd/translit"
 )
 
+// sendMusicCh is used to request that the full music state be sent to the watch
+var sendMusicCh = make(chan struct{}, 1)
+
+// requestMusicState asks the music controller to send the full music
+// state to the watch, without blocking if a request is already pending.
+func requestMusicState() {
+	select {
+	case sendMusicCh <- struct{}{}:
+	default:
+	}
+}
+
 func initMusicCtrl(ctx context.Context, wg WaitGroup, dev *infinitime.Device) error {
-	mpris.Init(ctx)
+	player, err := mpris.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	vol, err := newVolumeBackend(player)
+	if err != nil {
+		return err
+	}
 
 	maps := cfg.Notifs.Translit.Use
 	translit.Transliterators["custom"] = translit.Map(cfg.Notifs.Translit.Custom)
 
-	mpris.OnChange(func(ct mpris.ChangeType, val string) {
+	player.OnChange(func(ct mpris.ChangeType, val string) {
 		newVal := translit.Transliterate(val, maps...)
-		if !firmwareUpdating {
+		err := watchMusic.update(dev, func(s *musicState) {
 			switch ct {
 			case mpris.ChangeTypeStatus:
-				dev.SetMusicStatus(val == "Playing")
+				s.Playing = val == "Playing"
 			case mpris.ChangeTypeTitle:
-				dev.SetMusicTrack(newVal)
+				s.Track = newVal
 			case mpris.ChangeTypeAlbum:
-				dev.SetMusicAlbum(newVal)
+				s.Album = newVal
 			case mpris.ChangeTypeArtist:
-				dev.SetMusicArtist(newVal)
+				s.Artist = newVal
+			case mpris.ChangeTypePosition:
+				usec, _ := strconv.ParseInt(val, 10, 64)
+				s.setPosition(time.Duration(usec) * time.Microsecond)
+			case mpris.ChangeTypeLength:
+				usec, _ := strconv.ParseInt(val, 10, 64)
+				s.Length = time.Duration(usec) * time.Microsecond
+			case mpris.ChangeTypeShuffle:
+				s.Shuffle = val == "true"
+			case mpris.ChangeTypeLoopStatus:
+				s.Repeat = val != "None"
+			case mpris.ChangeTypeRate:
+				rate, err := strconv.ParseFloat(val, 64)
+				if err == nil {
+					s.Rate = rate
+				}
 			}
+		})
+		if err != nil {
+			log.Warn("Error sending music data", slog.Any("error", err))
 		}
 	})
 
 	// Watch for music events
-	err := dev.WatchMusicEvents(ctx, func(event infinitime.MusicEvent, err error) {
+	err = dev.WatchMusicEvents(ctx, func(event infinitime.MusicEvent, err error) {
 		if err != nil {
 			log.Error("Music event error", slog.Any("error", err))
+			return
 		}
-		
+
 		// Perform appropriate action based on event
 		switch event {
+		case infinitime.MusicEventOpen:
+			requestMusicState()
 		case infinitime.MusicEventPlay:
-			mpris.Play()
+			err = player.Play()
 		case infinitime.MusicEventPause:
-			mpris.Pause()
+			err = player.Pause()
 		case infinitime.MusicEventNext:
-			mpris.Next()
+			err = player.Next()
 		case infinitime.MusicEventPrev:
-			mpris.Prev()
+			err = player.Prev()
 		case infinitime.MusicEventVolUp:
-			mpris.VolUp(cfg.Music.Vol.Interval)
+			err = vol.VolUp(cfg.Music.Vol.Interval)
 		case infinitime.MusicEventVolDown:
-			mpris.VolDown(cfg.Music.Vol.Interval)
+			err = vol.VolDown(cfg.Music.Vol.Interval)
+		}
+		if err != nil {
+			log.Warn("Error handling music event", slog.Any("error", err))
 		}
 	})
 	if err != nil {
 		return err
 	}
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done("musicState")
+		for {
+			select {
+			case <-sendMusicCh:
+				err := sendMusicState(dev, player, maps)
+				if errors.Is(err, mpris.ErrNoPlayer) {
+					// There's no MPRIS player, so resend whatever was last
+					// set, which may have come from the music RPC service
+					err = watchMusic.push(dev, nil)
+				}
+				if err != nil {
+					log.Error("Error sending music state", slog.Any("error", err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Send the initial state, since the watch may have stale metadata
+	requestMusicState()
+
 	// Log completed initialization
 	log.Info("Initialized InfiniTime music controls")
 
 	return nil
 }
+
+// sendMusicState gets the full state of the active MPRIS
+// player and sends it to the watch
+func sendMusicState(dev *infinitime.Device, player *mpris.Client, maps []string) error {
+	state, err := player.GetState()
+	if err != nil {
+		return err
+	}
+
+	return watchMusic.push(dev, func(s *musicState) {
+		s.Track = translit.Transliterate(state.Title, maps...)
+		s.Artist = translit.Transliterate(state.Artist, maps...)
+		s.Album = translit.Transliterate(state.Album, maps...)
+		s.Length = state.Length
+		s.setPosition(state.Position)
+		s.Rate = state.Rate
+		s.Shuffle = state.Shuffle
+		s.Repeat = state.LoopStatus != "None"
+		s.Playing = state.Status == "Playing"
+	})
+}
+
+// watchMusic keeps track of the music state and what was last sent to the watch
+var watchMusic = &musicTracker{state: musicState{Rate: 1}, sent: musicState{Rate: 1}}
+
+// musicState represents the music data shown by the watch's music app
+type musicState struct {
+	Artist  string
+	Track   string
+	Album   string
+	Length  time.Duration
+	Playing bool
+	Rate    float64
+	Shuffle bool
+	Repeat  bool
+
+	// position is the playback position at posTime
+	position time.Duration
+	posTime  time.Time
+}
+
+// setPosition sets the playback position, starting at the current time
+func (s *musicState) setPosition(pos time.Duration) {
+	s.position = pos
+	s.posTime = time.Now()
+}
+
+// Position returns the current playback position, based on the
+// last position that was set and the playback rate
+func (s musicState) Position() time.Duration {
+	if !s.Playing || s.posTime.IsZero() {
+		return s.position
+	}
+	pos := s.position + time.Duration(float64(time.Since(s.posTime))*s.Rate)
+	if s.Length > 0 && pos > s.Length {
+		return s.Length
+	}
+	return pos
+}
+
+// musicWriter writes music data to the watch. It's implemented by
+// [infinitime.Device], and replaced by a fake in tests.
+type musicWriter interface {
+	SetMusicTrack(track string) error
+	SetMusicArtist(artist string) error
+	SetMusicAlbum(album string) error
+	SetMusicLength(length time.Duration) error
+	SetMusicPosition(pos time.Duration) error
+	SetMusicPlaybackSpeed(speed float64) error
+	SetMusicShuffle(shuffle bool) error
+	SetMusicRepeat(repeat bool) error
+	SetMusicStatus(playing bool) error
+}
+
+// musicField is a set of fields of the music state, as they're sent to the watch
+type musicField uint16
+
+const (
+	musicTrack musicField = 1 << iota
+	musicArtist
+	musicAlbum
+	musicLength
+	musicPosition
+	musicRate
+	musicShuffle
+	musicRepeat
+	musicStatus
+
+	allMusicFields = musicStatus<<1 - 1
+)
+
+// musicWrites lists how each field is sent to the watch, and how it's recorded
+// once it was sent. The status is sent last, so that the watch starts counting
+// from the position and speed sent before it.
+var musicWrites = []struct {
+	field  musicField
+	write  func(w musicWriter, s musicState) error
+	record func(sent *musicState, s musicState)
+}{
+	{
+		musicTrack,
+		func(w musicWriter, s musicState) error { return w.SetMusicTrack(s.Track) },
+		func(sent *musicState, s musicState) { sent.Track = s.Track },
+	},
+	{
+		musicArtist,
+		func(w musicWriter, s musicState) error { return w.SetMusicArtist(s.Artist) },
+		func(sent *musicState, s musicState) { sent.Artist = s.Artist },
+	},
+	{
+		musicAlbum,
+		func(w musicWriter, s musicState) error { return w.SetMusicAlbum(s.Album) },
+		func(sent *musicState, s musicState) { sent.Album = s.Album },
+	},
+	{
+		musicLength,
+		func(w musicWriter, s musicState) error { return w.SetMusicLength(s.Length) },
+		func(sent *musicState, s musicState) { sent.Length = s.Length },
+	},
+	{
+		musicPosition,
+		func(w musicWriter, s musicState) error { return w.SetMusicPosition(s.Position()) },
+		func(sent *musicState, s musicState) { sent.position, sent.posTime = s.position, s.posTime },
+	},
+	{
+		musicRate,
+		func(w musicWriter, s musicState) error { return w.SetMusicPlaybackSpeed(s.Rate) },
+		func(sent *musicState, s musicState) { sent.Rate = s.Rate },
+	},
+	{
+		musicShuffle,
+		func(w musicWriter, s musicState) error { return w.SetMusicShuffle(s.Shuffle) },
+		func(sent *musicState, s musicState) { sent.Shuffle = s.Shuffle },
+	},
+	{
+		musicRepeat,
+		func(w musicWriter, s musicState) error { return w.SetMusicRepeat(s.Repeat) },
+		func(sent *musicState, s musicState) { sent.Repeat = s.Repeat },
+	},
+	{
+		musicStatus,
+		func(w musicWriter, s musicState) error { return w.SetMusicStatus(s.Playing) },
+		func(sent *musicState, s musicState) { sent.Playing = s.Playing },
+	},
+}
+
+// changed returns the fields of s that differ from old
+func (s musicState) changed(old musicState) musicField {
+	var out musicField
+	if s.Track != old.Track {
+		out |= musicTrack
+	}
+	if s.Artist != old.Artist {
+		out |= musicArtist
+	}
+	if s.Album != old.Album {
+		out |= musicAlbum
+	}
+	if s.Length != old.Length {
+		out |= musicLength
+	}
+	if s.posTime != old.posTime || s.Playing != old.Playing {
+		out |= musicPosition
+	}
+	if s.Rate != old.Rate {
+		out |= musicRate
+	}
+	if s.Shuffle != old.Shuffle {
+		out |= musicShuffle
+	}
+	if s.Repeat != old.Repeat {
+		out |= musicRepeat
+	}
+	if s.Playing != old.Playing {
+		out |= musicStatus
+	}
+	return out
+}
+
+// musicTracker sends music data to the watch and remembers it, so that
+// it can be resent or retrieved by clients later
+type musicTracker struct {
+	mtx   sync.Mutex
+	state musicState
+	// sent contains the fields that were successfully sent to the watch
+	sent musicState
+	// pending contains the fields that must be sent even if they haven't
+	// changed, because everything is being resent or their last write failed
+	pending musicField
+}
+
+// get returns the current music state
+func (mt *musicTracker) get() musicState {
+	mt.mtx.Lock()
+	defer mt.mtx.Unlock()
+	return mt.state
+}
+
+// update applies fn to the music state and sends any fields
+// that changed to the watch
+func (mt *musicTracker) update(w musicWriter, fn func(s *musicState)) error {
+	return mt.apply(w, fn, false)
+}
+
+// push applies fn to the music state, if it's non-nil, and then
+// sends every field to the watch
+func (mt *musicTracker) push(w musicWriter, fn func(s *musicState)) error {
+	return mt.apply(w, fn, true)
+}
+
+// apply updates the music state right away, but sends it through the
+// scheduler. If sending is deferred, later changes replace the deferred
+// send, which then sends everything that changed in the meantime.
+func (mt *musicTracker) apply(w musicWriter, fn func(s *musicState), all bool) error {
+	mt.mtx.Lock()
+	old := mt.state
+	if fn != nil {
+		fn(&mt.state)
+	}
+	// If playback started or stopped without a new position, restart
+	// the position clock from wherever playback currently is
+	if mt.state.Playing != old.Playing && mt.state.posTime == old.posTime {
+		mt.state.setPosition(old.Position())
+	}
+	if all {
+		mt.pending = allMusicFields
+	}
+	mt.mtx.Unlock()
+
+	return sched.run(priorityNormal, "music", func() error {
+		return mt.send(w)
+	})
+}
+
+// send sends the fields that differ from the ones last sent to the watch,
+// or that are pending. Fields are only recorded as sent once their write
+// succeeds, so fields that fail are sent again next time.
+func (mt *musicTracker) send(w musicWriter) error {
+	mt.mtx.Lock()
+	defer mt.mtx.Unlock()
+
+	s := mt.state
+	fields := mt.pending | s.changed(mt.sent)
+	mt.pending = 0
+
+	var errs []error
+	for _, mw := range musicWrites {
+		if fields&mw.field == 0 {
+			continue
+		}
+
+		err := mw.write(w, s)
+		if err != nil {
+			errs = append(errs, err)
+			mt.pending |= mw.field
+			continue
+		}
+		mw.record(&mt.sent, s)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+var errWrite = errors.New("write failed")
+
+// fakeMusicWriter records the music writes, and fails
+// the ones whose names are in fail
+type fakeMusicWriter struct {
+	fail   map[string]bool
+	writes []string
+}
+
+func (f *fakeMusicWriter) write(name string, v any) error {
+	if f.fail[name] {
+		return errWrite
+	}
+	f.writes = append(f.writes, fmt.Sprintf("%s=%v", name, v))
+	return nil
+}
+
+func (f *fakeMusicWriter) SetMusicTrack(v string) error         { return f.write("track", v) }
+func (f *fakeMusicWriter) SetMusicArtist(v string) error        { return f.write("artist", v) }
+func (f *fakeMusicWriter) SetMusicAlbum(v string) error         { return f.write("album", v) }
+func (f *fakeMusicWriter) SetMusicLength(v time.Duration) error { return f.write("length", v) }
+func (f *fakeMusicWriter) SetMusicShuffle(v bool) error         { return f.write("shuffle", v) }
+func (f *fakeMusicWriter) SetMusicRepeat(v bool) error          { return f.write("repeat", v) }
+func (f *fakeMusicWriter) SetMusicStatus(v bool) error          { return f.write("status", v) }
+
+func (f *fakeMusicWriter) SetMusicPlaybackSpeed(v float64) error {
+	return f.write("speed", v)
+}
+
+func (f *fakeMusicWriter) SetMusicPosition(v time.Duration) error {
+	// The position depends on the time the write happens, so
+	// only record it to the second
+	return f.write("position", v.Truncate(time.Second))
+}
+
+// musicStep is an update applied to the music tracker, along with
+// the writes it's expected to cause
+type musicStep struct {
+	fn       func(s *musicState)
+	push     bool
+	fail     []string
+	expected []string
+	err      bool
+}
+
+func TestMusicTracker(t *testing.T) {
+	log = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name  string
+		steps []musicStep
+	}{
+		{
+			name: "diff",
+			steps: []musicStep{
+				{
+					fn:       func(s *musicState) { s.Track, s.Artist = "Song", "Band" },
+					expected: []string{"track=Song", "artist=Band"},
+				},
+				{
+					// Only the field that changed is sent
+					fn:       func(s *musicState) { s.Track = "Other Song" },
+					expected: []string{"track=Other Song"},
+				},
+				{
+					// Setting a field to the value it already has sends nothing
+					fn: func(s *musicState) { s.Artist = "Band" },
+				},
+			},
+		},
+		{
+			name: "push",
+			steps: []musicStep{
+				{
+					fn:       func(s *musicState) { s.Track, s.Length = "Song", time.Minute },
+					expected: []string{"track=Song", "length=1m0s"},
+				},
+				{
+					// Everything is resent, with the status last
+					push: true,
+					expected: []string{
+						"track=Song", "artist=", "album=", "length=1m0s", "position=0s",
+						"speed=1", "shuffle=false", "repeat=false", "status=false",
+					},
+				},
+			},
+		},
+		{
+			name: "resend failed",
+			steps: []musicStep{
+				{
+					fn:       func(s *musicState) { s.Track, s.Album = "Song", "Record" },
+					fail:     []string{"track"},
+					expected: []string{"album=Record"},
+					err:      true,
+				},
+				{
+					// The track failed, so it's sent again even though it didn't change
+					fn:       func(s *musicState) { s.Shuffle = true },
+					expected: []string{"track=Song", "shuffle=true"},
+				},
+				{
+					fn: func(s *musicState) { s.Album = "Record" },
+				},
+			},
+		},
+		{
+			name: "position clock",
+			steps: []musicStep{
+				{
+					fn:       func(s *musicState) { s.Length = time.Hour; s.setPosition(30 * time.Second) },
+					expected: []string{"length=1h0m0s", "position=30s"},
+				},
+				{
+					// Starting playback restarts the clock from the current position,
+					// and sends it before the status
+					fn:       func(s *musicState) { s.Playing = true },
+					expected: []string{"position=30s", "status=true"},
+				},
+				{
+					// Changing the rate doesn't move the position
+					fn:       func(s *musicState) { s.Rate = 2 },
+					expected: []string{"speed=2"},
+				},
+				{
+					fn:       func(s *musicState) { s.Playing = false },
+					expected: []string{"position=30s", "status=false"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mt := &musicTracker{state: musicState{Rate: 1}, sent: musicState{Rate: 1}}
+			for i, step := range tt.steps {
+				w := &fakeMusicWriter{fail: map[string]bool{}}
+				for _, name := range step.fail {
+					w.fail[name] = true
+				}
+
+				var err error
+				if step.push {
+					err = mt.push(w, step.fn)
+				} else {
+					err = mt.update(w, step.fn)
+				}
+
+				if step.err != (err != nil) {
+					t.Errorf("Step %d: expected error %t, got %v", i, step.err, err)
+				}
+				if !slices.Equal(w.writes, step.expected) {
+					t.Errorf("Step %d: expected writes %v, got %v", i, step.expected, w.writes)
+				}
+			}
+		})
+	}
+}
+
+func TestMusicPosition(t *testing.T) {
+	s := musicState{Playing: true, Rate: 2, Length: time.Minute}
+	s.position = 10 * time.Second
+	s.posTime = time.Now().Add(-5 * time.Second)
+	if pos := s.Position().Truncate(time.Second); pos != 20*time.Second {
+		t.Errorf("Expected position 20s, got %v", pos)
+	}
+
+	// The position never goes past the end of the track
+	s.posTime = time.Now().Add(-time.Hour)
+	if pos := s.Position(); pos != time.Minute {
+		t.Errorf("Expected position 1m0s, got %v", pos)
+	}
+
+	// The position doesn't move while paused
+	s.Playing = false
+	if pos := s.Position(); pos != 10*time.Second {
+		t.Errorf("Expected position 10s, got %v", pos)
+	}
+}
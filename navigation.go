@@ -0,0 +1,282 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.elara.ws/itd/infinitime"
+)
+
+// navUpdates coalesces the navigation updates from every navigation source
+var navUpdates = &navAggregator{
+	flag:      navField[infinitime.NavFlag]{name: "navFlag"},
+	narrative: navField[string]{name: "navNarrative"},
+	manDist:   navField[string]{name: "navManDist"},
+	progress:  navField[uint8]{name: "navProgress"},
+}
+
+// navAggregator coalesces navigation updates so that each field is written
+// to the watch at most once per update interval, and only if its value has
+// changed since it was last written.
+type navAggregator struct {
+	flag      navField[infinitime.NavFlag]
+	narrative navField[string]
+	manDist   navField[string]
+	progress  navField[uint8]
+}
+
+func (na *navAggregator) setFlag(dev *infinitime.Device, flag infinitime.NavFlag) error {
+	return na.flag.set(flag, dev.SetNavFlag)
+}
+
+func (na *navAggregator) setNarrative(dev *infinitime.Device, narrative string) error {
+	return na.narrative.set(narrative, dev.SetNavNarrative)
+}
+
+func (na *navAggregator) setManDist(dev *infinitime.Device, manDist string) error {
+	return na.manDist.set(manDist, dev.SetNavManeuverDistance)
+}
+
+func (na *navAggregator) setProgress(dev *infinitime.Device, progress uint8) error {
+	return na.progress.set(progress, dev.SetNavProgress)
+}
+
+// setAll immediately writes every field that has changed, cancelling
+// any scheduled writes. If force is true, every field is written at
+// once, even if it hasn't changed.
+func (na *navAggregator) setAll(dev *infinitime.Device, state infinitime.NavState, force bool) error {
+	if force {
+		return na.forceAll(dev, state)
+	}
+
+	na.lockAll()
+	writes := []func() error{
+		na.flag.replaceLocked(state.Flag, dev.SetNavFlag),
+		na.narrative.replaceLocked(state.Narrative, dev.SetNavNarrative),
+		na.manDist.replaceLocked(state.ManDist, dev.SetNavManeuverDistance),
+		na.progress.replaceLocked(state.Progress, dev.SetNavProgress),
+	}
+	na.unlockAll()
+
+	errs := make([]error, len(writes))
+	for i, write := range writes {
+		errs[i] = write()
+	}
+	return errors.Join(errs...)
+}
+
+// forceAll writes every field at once. The fields are only
+// recorded as sent once the write has actually happened.
+func (na *navAggregator) forceAll(dev *infinitime.Device, state infinitime.NavState) error {
+	na.lockAll()
+	flagSeq := na.flag.cancelLocked()
+	narrativeSeq := na.narrative.cancelLocked()
+	manDistSeq := na.manDist.cancelLocked()
+	progressSeq := na.progress.cancelLocked()
+	na.unlockAll()
+
+	return sched.run(priorityNormal, "nav", func() error {
+		err := dev.SetNav(state)
+
+		na.lockAll()
+		defer na.unlockAll()
+		na.flag.recordLocked(flagSeq, state.Flag, err)
+		na.narrative.recordLocked(narrativeSeq, state.Narrative, err)
+		na.manDist.recordLocked(manDistSeq, state.ManDist, err)
+		na.progress.recordLocked(progressSeq, state.Progress, err)
+		return err
+	})
+}
+
+// clear clears the navigation app and cancels any pending writes
+func (na *navAggregator) clear(dev *infinitime.Device) error {
+	return na.setAll(dev, infinitime.NavState{Flag: infinitime.NavFlagFlag}, true)
+}
+
+// reset forgets the values written to the watch, so that
+// the next update to each field is always written.
+func (na *navAggregator) reset() {
+	na.lockAll()
+	defer na.unlockAll()
+	na.flag.resetLocked()
+	na.narrative.resetLocked()
+	na.manDist.resetLocked()
+	na.progress.resetLocked()
+}
+
+func (na *navAggregator) lockAll() {
+	na.flag.mtx.Lock()
+	na.narrative.mtx.Lock()
+	na.manDist.mtx.Lock()
+	na.progress.mtx.Lock()
+}
+
+func (na *navAggregator) unlockAll() {
+	na.progress.mtx.Unlock()
+	na.manDist.mtx.Unlock()
+	na.narrative.mtx.Unlock()
+	na.flag.mtx.Unlock()
+}
+
+// navField keeps track of the writes to a single navigation field.
+// Its lock is never held while writing to the watch.
+type navField[T comparable] struct {
+	mtx sync.Mutex
+	// name is the name of the field's writes in the scheduler, so that
+	// only the latest value is kept while the scheduler is paused
+	name string
+
+	sent    T
+	hasSent bool
+	// seq is incremented whenever a write starts, so that the result
+	// of a write is ignored if a newer one has started since
+	seq uint64
+
+	pending    T
+	hasPending bool
+	write      func(T) error
+
+	lastWrite time.Time
+	timer     *time.Timer
+}
+
+// set writes val immediately if the field hasn't been written within the
+// update interval. Otherwise, it schedules a write at the end of the interval,
+// replacing any value that's already scheduled.
+func (nf *navField[T]) set(val T, write func(T) error) error {
+	return nf.schedule(val, write)()
+}
+
+// schedule records val as the pending value, and returns a function
+// that writes it if it should be written right away
+func (nf *navField[T]) schedule(val T, write func(T) error) func() error {
+	nf.mtx.Lock()
+	defer nf.mtx.Unlock()
+
+	if nf.hasSent && val == nf.sent {
+		// The watch already has this value, so any pending write is obsolete
+		nf.hasPending = false
+		return noNavWrite
+	}
+
+	nf.pending, nf.hasPending, nf.write = val, true, write
+	if nf.timer != nil {
+		// A write is already scheduled, and it will use the new value
+		return noNavWrite
+	}
+
+	wait := navUpdateInterval() - time.Since(nf.lastWrite)
+	if wait <= 0 {
+		return nf.takeLocked()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(wait, func() {
+		err := nf.fire(&timer)()
+		if err != nil {
+			log.Warn("Error writing navigation data", slog.Any("error", err))
+		}
+	})
+	nf.timer = timer
+
+	return noNavWrite
+}
+
+// fire returns a function that writes the pending value when
+// timer fires, unless the timer was stopped after it fired.
+// The timer is read under the lock, since it's set after it starts.
+func (nf *navField[T]) fire(timer **time.Timer) func() error {
+	nf.mtx.Lock()
+	defer nf.mtx.Unlock()
+
+	if nf.timer != *timer {
+		return noNavWrite
+	}
+	nf.timer = nil
+	return nf.takeLocked()
+}
+
+// replaceLocked cancels any scheduled write, and returns
+// a function that writes val if it has changed
+func (nf *navField[T]) replaceLocked(val T, write func(T) error) func() error {
+	nf.stopTimerLocked()
+	if nf.hasSent && val == nf.sent {
+		nf.hasPending = false
+		return noNavWrite
+	}
+	nf.pending, nf.hasPending, nf.write = val, true, write
+	return nf.takeLocked()
+}
+
+// takeLocked takes the pending value, and returns a function that writes it
+// through the scheduler. The value is only recorded as sent once the write
+// succeeds, which happens later if the scheduler is paused.
+func (nf *navField[T]) takeLocked() func() error {
+	if !nf.hasPending {
+		return noNavWrite
+	}
+	nf.hasPending = false
+	nf.lastWrite = time.Now()
+	nf.seq++
+
+	val, write, seq := nf.pending, nf.write, nf.seq
+	return func() error {
+		return sched.run(priorityNormal, nf.name, func() error {
+			err := write(val)
+
+			nf.mtx.Lock()
+			defer nf.mtx.Unlock()
+			nf.recordLocked(seq, val, err)
+			return err
+		})
+	}
+}
+
+// cancelLocked cancels any scheduled write, and starts a write
+// done by someone else, returning its sequence number
+func (nf *navField[T]) cancelLocked() uint64 {
+	nf.stopTimerLocked()
+	nf.hasPending = false
+	nf.lastWrite = time.Now()
+	nf.seq++
+	return nf.seq
+}
+
+// recordLocked records the result of the write with the given sequence
+// number, unless a newer write has started since
+func (nf *navField[T]) recordLocked(seq uint64, val T, err error) {
+	if seq != nf.seq {
+		return
+	}
+
+	if err != nil {
+		// The value on the watch is unknown, so make sure the next one is written
+		nf.hasSent = false
+		return
+	}
+	nf.sent, nf.hasSent = val, true
+}
+
+func (nf *navField[T]) resetLocked() {
+	nf.stopTimerLocked()
+	nf.hasPending = false
+	nf.hasSent = false
+	// Ignore the result of any write that's still running
+	nf.seq++
+}
+
+func (nf *navField[T]) stopTimerLocked() {
+	if nf.timer != nil {
+		nf.timer.Stop()
+		nf.timer = nil
+	}
+}
+
+func noNavWrite() error { return nil }
+
+// navUpdateInterval returns the minimum time between writes to each navigation field
+func navUpdateInterval() time.Duration {
+	return time.Duration(cfg.Nav.UpdateInterval) * time.Millisecond
+}
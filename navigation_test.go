@@ -0,0 +1,100 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNavFieldCoalesce(t *testing.T) {
+	cfg.Nav.UpdateInterval = 50
+
+	var mtx sync.Mutex
+	var writes []string
+	write := func(s string) error {
+		mtx.Lock()
+		defer mtx.Unlock()
+		writes = append(writes, s)
+		return nil
+	}
+
+	var nf navField[string]
+	// The first value is written immediately
+	nf.set("300 m", write)
+	// These are within the interval, so only the last one should be written
+	nf.set("250 m", write)
+	nf.set("200 m", write)
+	nf.set("150 m", write)
+
+	time.Sleep(100 * time.Millisecond)
+
+	// This is unchanged, so it shouldn't be written at all
+	nf.set("150 m", write)
+
+	time.Sleep(100 * time.Millisecond)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	expected := []string{"300 m", "150 m"}
+	if len(writes) != len(expected) {
+		t.Fatalf("Expected writes %v, got %v", expected, writes)
+	}
+	for i := range expected {
+		if writes[i] != expected[i] {
+			t.Errorf("Expected writes %v, got %v", expected, writes)
+		}
+	}
+}
+
+func TestNavFieldDeferred(t *testing.T) {
+	log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg.Nav.UpdateInterval = 0
+
+	written := make(chan string, 1)
+	write := func(s string) error {
+		written <- s
+		return nil
+	}
+
+	nf := navField[string]{name: "navTest"}
+	resume := sched.pause("test")
+	err := nf.set("100 m", write)
+	if err != nil {
+		t.Fatalf("Expected deferred write to succeed, got %v", err)
+	}
+
+	// The write hasn't happened yet, so the value mustn't be recorded as sent
+	nf.mtx.Lock()
+	hasSent := nf.hasSent
+	nf.mtx.Unlock()
+	if hasSent {
+		t.Error("Expected deferred value not to be recorded as sent")
+	}
+
+	resume()
+	select {
+	case val := <-written:
+		if val != "100 m" {
+			t.Errorf("Expected deferred write of 100 m, got %s", val)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected deferred write to run after resuming")
+	}
+
+	// Wait for the result of the write to be recorded
+	deadline := time.Now().Add(time.Second)
+	for {
+		nf.mtx.Lock()
+		sent, hasSent := nf.sent, nf.hasSent
+		nf.mtx.Unlock()
+		if hasSent && sent == "100 m" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 100 m to be recorded as sent, got %q", sent)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -21,6 +21,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/godbus/dbus/v5"
 	"go.elara.ws/itd/infinitime"
@@ -60,11 +61,6 @@ func initNotifRelay(ctx context.Context, wg WaitGroup, dev *infinitime.Device) e
 		for {
 			select {
 			case v := <-notifCh:
-				// If firmware is updating, skip
-				if firmwareUpdating {
-					continue
-				}
-
 				// If body does not contain 5 elements, skip
 				if len(v.Body) < 5 {
 					continue
@@ -93,7 +89,14 @@ func initNotifRelay(ctx context.Context, wg WaitGroup, dev *infinitime.Device) e
 					msg = fmt.Sprintf("%s\n\n%s", summary, body)
 				}
 
-				dev.Notify(sender, msg)
+				// Notifications are deferred rather than dropped
+				// while the scheduler is paused, so none are missed.
+				err := sched.run(priorityHigh, "notification", func() error {
+					return dev.Notify(sender, msg)
+				})
+				if err != nil {
+					log.Warn("Error relaying notification", slog.Any("error", err))
+				}
 			case <-ctx.Done():
 				bus.Close()
 				return
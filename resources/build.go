@@ -0,0 +1,287 @@
+// Package resources builds InfiniTime resource packages from TTF/OTF fonts
+// and images, without the Python and Node.js tools InfiniTime uses.
+//
+// A package is described by a spec, which mirrors the fonts.json and
+// images.json files in InfiniTime's source tree:
+//
+//	{
+//	  "fonts": {
+//	    "lv_font_dots_40": {
+//	      "sources": [{"file": "Dots.ttf", "range": "0x20-0x7e"}],
+//	      "size": 40,
+//	      "bpp": 1,
+//	      "target_path": "/fonts/"
+//	    }
+//	  },
+//	  "images": {
+//	    "pine_small": {
+//	      "sources": "pine_logo.png",
+//	      "color_format": "CF_TRUE_COLOR_ALPHA",
+//	      "target_path": "/images/"
+//	    }
+//	  },
+//	  "obsolete_files": [{"path": "/fonts/old.bin", "since": "1.14.0"}]
+//	}
+//
+// Each font and image becomes a file named after its key, with a .bin
+// extension, which is loaded into its target path on the watch.
+package resources
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"golang.org/x/image/font/sfnt"
+)
+
+// ManifestName is the name of the manifest in a resource package
+const ManifestName = "resources.json"
+
+const (
+	defaultFontPath  = "/fonts/"
+	defaultImagePath = "/images/"
+)
+
+// Spec describes the contents of a resource package
+type Spec struct {
+	Fonts    map[string]FontSpec  `json:"fonts"`
+	Images   map[string]ImageSpec `json:"images"`
+	Obsolete []ObsoleteFile       `json:"obsolete_files,omitempty"`
+	// Dir is the directory that relative source paths are resolved against
+	Dir string `json:"-"`
+}
+
+// FontSpec describes a font to build
+type FontSpec struct {
+	Sources []FontSourceSpec `json:"sources"`
+	// Size is the size of the font in pixels
+	Size float64 `json:"size"`
+	// BPP is the amount of bits per pixel, from 1 to 4. It defaults to 1.
+	BPP int `json:"bpp"`
+	// TargetPath is the directory on the watch. It defaults to /fonts/.
+	TargetPath string `json:"target_path"`
+}
+
+// FontSourceSpec is a font file and the characters to take from it
+type FontSourceSpec struct {
+	File string `json:"file"`
+	// Range is a list of characters, such as "0x20-0x7e, 0xb0"
+	Range string `json:"range"`
+	// Symbols contains characters to take from the font, in addition to Range
+	Symbols string `json:"symbols"`
+}
+
+// ImageSpec describes an image to build
+type ImageSpec struct {
+	// Source is the path of a PNG or JPEG image
+	Source string `json:"sources"`
+	// ColorFormat defaults to CF_TRUE_COLOR_ALPHA
+	ColorFormat ColorFormat `json:"color_format"`
+	// TargetPath is the directory on the watch. It defaults to /images/.
+	TargetPath string `json:"target_path"`
+}
+
+// ObsoleteFile is a file that the package removes from the watch
+type ObsoleteFile struct {
+	Path  string `json:"path"`
+	Since string `json:"since"`
+}
+
+// manifest is the structure of resources.json
+type manifest struct {
+	Resources []manifestResource `json:"resources"`
+	Obsolete  []ObsoleteFile     `json:"obsolete_files"`
+}
+
+type manifestResource struct {
+	Name string `json:"filename"`
+	Path string `json:"path"`
+}
+
+// LoadSpec reads a spec from a JSON file. Relative source
+// paths are resolved against the file's directory.
+func LoadSpec(specPath string) (Spec, error) {
+	fl, err := os.Open(specPath)
+	if err != nil {
+		return Spec{}, err
+	}
+	defer fl.Close()
+
+	var spec Spec
+	err = json.NewDecoder(fl).Decode(&spec)
+	if err != nil {
+		return Spec{}, fmt.Errorf("invalid resource spec: %w", err)
+	}
+
+	spec.Dir = filepath.Dir(specPath)
+	return spec, nil
+}
+
+// Build converts the fonts and images in the spec, and writes a resource
+// package to w that can be loaded with [infinitime.LoadResources].
+func Build(spec Spec, w io.Writer) error {
+	files := map[string][]byte{}
+	var res []manifestResource
+
+	add := func(name, targetPath string, data []byte) error {
+		fileName := name + ".bin"
+		if _, ok := files[fileName]; ok {
+			return fmt.Errorf("duplicate resource name: %s", name)
+		}
+		files[fileName] = data
+		res = append(res, manifestResource{
+			Name: fileName,
+			Path: path.Join(targetPath, fileName),
+		})
+		return nil
+	}
+
+	fonts := map[string]*sfnt.Font{}
+	for name, fs := range spec.Fonts {
+		data, err := buildFont(spec.Dir, fs, fonts)
+		if err != nil {
+			return fmt.Errorf("font %s: %w", name, err)
+		}
+
+		err = add(name, orDefault(fs.TargetPath, defaultFontPath), data)
+		if err != nil {
+			return err
+		}
+	}
+
+	for name, is := range spec.Images {
+		data, err := buildImage(spec.Dir, is)
+		if err != nil {
+			return fmt.Errorf("image %s: %w", name, err)
+		}
+
+		err = add(name, orDefault(is.TargetPath, defaultImagePath), data)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Sort the resources so that the same spec always builds the same package
+	slices.SortFunc(res, func(a, b manifestResource) int {
+		return cmpString(a.Name, b.Name)
+	})
+
+	zw := zip.NewWriter(w)
+
+	mw, err := zw.Create(ManifestName)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(mw)
+	enc.SetIndent("", "  ")
+	obsolete := spec.Obsolete
+	if obsolete == nil {
+		obsolete = []ObsoleteFile{}
+	}
+	err = enc.Encode(manifest{Resources: res, Obsolete: obsolete})
+	if err != nil {
+		return err
+	}
+
+	for _, r := range res {
+		fw, err := zw.Create(r.Name)
+		if err != nil {
+			return err
+		}
+
+		_, err = fw.Write(files[r.Name])
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// buildFont converts a font, caching the parsed font files in fonts
+func buildFont(dir string, fs FontSpec, fonts map[string]*sfnt.Font) ([]byte, error) {
+	srcs := make([]FontSource, 0, len(fs.Sources))
+	for _, src := range fs.Sources {
+		p := resolve(dir, src.File)
+
+		f, ok := fonts[p]
+		if !ok {
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return nil, err
+			}
+
+			f, err = sfnt.Parse(data)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", src.File, err)
+			}
+			fonts[p] = f
+		}
+
+		runes, err := ParseRange(src.Range)
+		if err != nil {
+			return nil, err
+		}
+		runes = append(runes, []rune(src.Symbols)...)
+
+		srcs = append(srcs, FontSource{Font: f, Runes: runes})
+	}
+
+	bpp := fs.BPP
+	if bpp == 0 {
+		bpp = 1
+	}
+	return ConvertFont(srcs, fs.Size, bpp)
+}
+
+// buildImage converts an image
+func buildImage(dir string, is ImageSpec) ([]byte, error) {
+	fl, err := os.Open(resolve(dir, is.Source))
+	if err != nil {
+		return nil, err
+	}
+	defer fl.Close()
+
+	img, _, err := image.Decode(fl)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", is.Source, err)
+	}
+
+	return ConvertImage(img, orDefault(is.ColorFormat, ColorFormatTrueColorAlpha))
+}
+
+// resolve resolves p against dir, unless it's absolute
+func resolve(dir, p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(dir, p)
+}
+
+func orDefault[T comparable](v, def T) T {
+	var zero T
+	if v == zero {
+		return def
+	}
+	return v
+}
+
+func cmpString(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
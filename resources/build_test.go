@@ -0,0 +1,136 @@
+package resources
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestBuild(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "Go-Regular.ttf"), goregular.TTF, 0o644)
+	if err != nil {
+		t.Fatalf("Error writing font: %s", err)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	img.Set(1, 0, color.NRGBA{B: 255, A: 128})
+	fl, err := os.Create(filepath.Join(dir, "logo.png"))
+	if err != nil {
+		t.Fatalf("Error creating image: %s", err)
+	}
+	err = png.Encode(fl, img)
+	fl.Close()
+	if err != nil {
+		t.Fatalf("Error encoding image: %s", err)
+	}
+
+	specData := `{
+		"fonts": {
+			"go_20": {"sources": [{"file": "Go-Regular.ttf", "range": "0x20-0x7e"}], "size": 20}
+		},
+		"images": {
+			"logo": {"sources": "logo.png", "target_path": "/img/"}
+		},
+		"obsolete_files": [{"path": "/fonts/old.bin", "since": "1.0.0"}]
+	}`
+	specPath := filepath.Join(dir, "spec.json")
+	err = os.WriteFile(specPath, []byte(specData), 0o644)
+	if err != nil {
+		t.Fatalf("Error writing spec: %s", err)
+	}
+
+	spec, err := LoadSpec(specPath)
+	if err != nil {
+		t.Fatalf("Error loading spec: %s", err)
+	}
+
+	buf := &bytes.Buffer{}
+	err = Build(spec, buf)
+	if err != nil {
+		t.Fatalf("Error building resources: %s", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Error reading package: %s", err)
+	}
+
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	expectedNames := []string{ManifestName, "go_20.bin", "logo.bin"}
+	if !slices.Equal(names, expectedNames) {
+		t.Errorf("Expected files %v, got %v", expectedNames, names)
+	}
+
+	mfl, err := zr.Open(ManifestName)
+	if err != nil {
+		t.Fatalf("Error opening manifest: %s", err)
+	}
+	defer mfl.Close()
+
+	var m manifest
+	err = json.NewDecoder(mfl).Decode(&m)
+	if err != nil {
+		t.Fatalf("Error decoding manifest: %s", err)
+	}
+
+	expected := []manifestResource{
+		{Name: "go_20.bin", Path: "/fonts/go_20.bin"},
+		{Name: "logo.bin", Path: "/img/logo.bin"},
+	}
+	if !slices.Equal(m.Resources, expected) {
+		t.Errorf("Expected resources %v, got %v", expected, m.Resources)
+	}
+	if len(m.Obsolete) != 1 || m.Obsolete[0].Path != "/fonts/old.bin" {
+		t.Errorf("Expected obsolete file /fonts/old.bin, got %v", m.Obsolete)
+	}
+
+	// Building the same spec again should produce the same package
+	buf2 := &bytes.Buffer{}
+	err = Build(spec, buf2)
+	if err != nil {
+		t.Fatalf("Error building resources: %s", err)
+	}
+	if !bytes.Equal(buf.Bytes(), buf2.Bytes()) {
+		t.Error("Expected identical packages from the same spec")
+	}
+}
+
+func TestConvertImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	img.Set(1, 0, color.NRGBA{B: 255, A: 128})
+
+	data, err := ConvertImage(img, ColorFormatTrueColorAlpha)
+	if err != nil {
+		t.Fatalf("Error converting image: %s", err)
+	}
+
+	expected := []byte{
+		0x05, 0x08, 0x20, 0x00, // header: CF 5, 2x1
+		0xF8, 0x00, 0xFF, // red
+		0x00, 0x1F, 0x80, // blue, half transparent
+	}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("Expected %x, got %x", expected, data)
+	}
+
+	_, err = ConvertImage(image.NewNRGBA(image.Rect(0, 0, 2048, 1)), ColorFormatTrueColor)
+	if err != ErrImageTooLarge {
+		t.Errorf("Expected ErrImageTooLarge, got %v", err)
+	}
+}
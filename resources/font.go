@@ -0,0 +1,390 @@
+package resources
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+var (
+	ErrInvalidBPP   = errors.New("bits per pixel must be between 1 and 4")
+	ErrNoGlyphs     = errors.New("none of the requested characters are in the fonts")
+	ErrInvalidRange = errors.New("invalid character range")
+)
+
+// FontSource is a font and the characters to take from it
+type FontSource struct {
+	Font  *sfnt.Font
+	Runes []rune
+}
+
+// ParseRange parses a list of characters in the format used by lv_font_conv,
+// such as "0x20-0x7e, 0x410-0x44f, 0xf294". Numbers may be decimal or hex.
+func ParseRange(s string) ([]rune, error) {
+	var out []rune
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		startStr, endStr, isRange := strings.Cut(part, "-")
+		start, err := strconv.ParseInt(strings.TrimSpace(startStr), 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidRange, part)
+		}
+
+		end := start
+		if isRange {
+			end, err = strconv.ParseInt(strings.TrimSpace(endStr), 0, 32)
+			if err != nil || end < start {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidRange, part)
+			}
+		}
+
+		for r := start; r <= end; r++ {
+			out = append(out, rune(r))
+		}
+	}
+	return out, nil
+}
+
+// fontGlyph is a rendered glyph
+type fontGlyph struct {
+	r    rune
+	adv  int
+	x, y int
+	w, h int
+	// bitmap contains one value per pixel, row by row
+	bitmap []byte
+}
+
+// ConvertFont renders the given characters at size pixels with bpp bits per
+// pixel, and encodes them in LVGL's binary font format, which InfiniTime loads
+// external fonts from. If a character is in several sources, the first one is
+// used. Characters that aren't in any source are left out. Kerning isn't
+// included, so LVGL spaces the characters by their advance widths.
+func ConvertFont(srcs []FontSource, size float64, bpp int) ([]byte, error) {
+	if bpp < 1 || bpp > 4 {
+		return nil, ErrInvalidBPP
+	}
+	if len(srcs) == 0 {
+		return nil, ErrNoGlyphs
+	}
+
+	var (
+		glyphs  []fontGlyph
+		metrics font.Metrics
+		seen    = map[rune]bool{}
+		buf     sfnt.Buffer
+	)
+
+	for i, src := range srcs {
+		face, err := opentype.NewFace(src.Font, &opentype.FaceOptions{
+			Size:    size,
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// The line metrics come from the first font,
+		// since the rest usually just add symbols
+		if i == 0 {
+			metrics = face.Metrics()
+		}
+
+		for _, r := range src.Runes {
+			if seen[r] {
+				continue
+			}
+
+			idx, err := src.Font.GlyphIndex(&buf, r)
+			if err != nil {
+				face.Close()
+				return nil, err
+			}
+			if idx == 0 {
+				continue
+			}
+
+			g, ok := renderGlyph(face, r, bpp)
+			if !ok {
+				continue
+			}
+			glyphs = append(glyphs, g)
+			seen[r] = true
+		}
+
+		face.Close()
+	}
+
+	if len(glyphs) == 0 {
+		return nil, ErrNoGlyphs
+	}
+	slices.SortFunc(glyphs, func(a, b fontGlyph) int { return int(a.r - b.r) })
+
+	var underlinePos, underlineThickness int
+	if post := srcs[0].Font.PostTable(); post != nil {
+		scale := size / float64(srcs[0].Font.UnitsPerEm())
+		underlinePos = int(math.Round(float64(post.UnderlinePosition) * scale))
+		underlineThickness = int(math.Round(float64(post.UnderlineThickness) * scale))
+	}
+
+	return encodeFont(glyphs, fontHeader{
+		size:               int(math.Round(size)),
+		ascent:             metrics.Ascent.Ceil(),
+		descent:            -metrics.Descent.Ceil(),
+		bpp:                bpp,
+		underlinePos:       underlinePos,
+		underlineThickness: underlineThickness,
+	}), nil
+}
+
+// renderGlyph renders r and crops it to the pixels that aren't empty
+func renderGlyph(face font.Face, r rune, bpp int) (fontGlyph, bool) {
+	dr, mask, maskp, adv, ok := face.Glyph(fixed.Point26_6{}, r)
+	if !ok {
+		return fontGlyph{}, false
+	}
+
+	g := fontGlyph{r: r, adv: adv.Round()}
+	if mask == nil || dr.Empty() {
+		return g, true
+	}
+
+	maxVal := 1<<bpp - 1
+	vals := make([]byte, dr.Dx()*dr.Dy())
+	bounds := image.Rectangle{Min: dr.Size()}
+	for y := 0; y < dr.Dy(); y++ {
+		for x := 0; x < dr.Dx(); x++ {
+			_, _, _, a := mask.At(maskp.X+x, maskp.Y+y).RGBA()
+			v := byte((int(a>>8)*maxVal + 127) / 255)
+			vals[y*dr.Dx()+x] = v
+			if v != 0 {
+				bounds = bounds.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	if bounds.Empty() {
+		return g, true
+	}
+
+	g.w, g.h = bounds.Dx(), bounds.Dy()
+	g.x = dr.Min.X + bounds.Min.X
+	// LVGL measures the bottom of the glyph upwards from the baseline
+	g.y = -(dr.Min.Y + bounds.Max.Y)
+	g.bitmap = make([]byte, 0, g.w*g.h)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row := y * dr.Dx()
+		g.bitmap = append(g.bitmap, vals[row+bounds.Min.X:row+bounds.Max.X]...)
+	}
+	return g, true
+}
+
+// fontHeader contains the font-wide values of the head table
+type fontHeader struct {
+	size               int
+	ascent, descent    int
+	bpp                int
+	underlinePos       int
+	underlineThickness int
+}
+
+// encodeFont encodes glyphs, sorted by character, in LVGL's binary font
+// format, which consists of the head, cmap, loca and glyf tables.
+func encodeFont(glyphs []fontGlyph, hdr fontHeader) []byte {
+	var minY, maxY, maxXY, maxWH, maxAdv int
+	for _, g := range glyphs {
+		minY = min(minY, g.y)
+		maxY = max(maxY, g.y+g.h)
+		maxXY = max(maxXY, abs(g.x), abs(g.y))
+		maxWH = max(maxWH, g.w, g.h)
+		maxAdv = max(maxAdv, g.adv)
+	}
+
+	xyBits := bitLen(maxXY) + 1
+	whBits := max(bitLen(maxWH), 1)
+	advBits := max(bitLen(maxAdv), 1)
+
+	// Glyph ID 0 is reserved, so the glyphs start at 1 and
+	// an empty glyph is written for ID 0.
+	glyf := newTable("glyf")
+	offsets := make([]uint32, 0, len(glyphs)+1)
+	for _, g := range append([]fontGlyph{{}}, glyphs...) {
+		offsets = append(offsets, uint32(glyf.Len()))
+
+		var bw bitWriter
+		bw.write(uint32(g.adv), advBits)
+		bw.write(uint32(g.x), xyBits)
+		bw.write(uint32(g.y), xyBits)
+		bw.write(uint32(g.w), whBits)
+		bw.write(uint32(g.h), whBits)
+		for _, v := range g.bitmap {
+			bw.write(uint32(v), hdr.bpp)
+		}
+		glyf.Write(bw.bytes())
+	}
+
+	locaFormat := uint8(0)
+	if glyf.Len() > math.MaxUint16 {
+		locaFormat = 1
+	}
+	loca := newTable("loca")
+	put(loca, uint32(len(offsets)))
+	for _, off := range offsets {
+		if locaFormat == 0 {
+			put(loca, uint16(off))
+		} else {
+			put(loca, off)
+		}
+	}
+
+	glyphIDFormat := uint8(0)
+	if len(offsets) > math.MaxUint8 {
+		glyphIDFormat = 1
+	}
+
+	head := newTable("head")
+	put(head, uint32(0))                      // version
+	put(head, uint16(3))                      // tables after head: cmap, loca, glyf
+	put(head, uint16(hdr.size))               // font size
+	put(head, uint16(hdr.ascent))             // ascent
+	put(head, int16(hdr.descent))             // descent
+	put(head, uint16(hdr.ascent))             // typo ascent
+	put(head, int16(hdr.descent))             // typo descent
+	put(head, uint16(0))                      // typo line gap
+	put(head, int16(minY))                    // min y
+	put(head, int16(maxY))                    // max y
+	put(head, uint16(0))                      // default advance width
+	put(head, uint16(0))                      // kerning scale
+	put(head, locaFormat)                     // index to loc format
+	put(head, glyphIDFormat)                  // glyph id format
+	put(head, uint8(0))                       // advance width format: integer
+	put(head, uint8(hdr.bpp))                 // bits per pixel
+	put(head, uint8(xyBits))                  // bbox x/y bits
+	put(head, uint8(whBits))                  // bbox w/h bits
+	put(head, uint8(advBits))                 // advance width bits
+	put(head, uint8(0))                       // compression: raw
+	put(head, uint8(0))                       // subpixel rendering: none
+	put(head, uint8(0))                       // padding
+	put(head, int16(hdr.underlinePos))        // underline position
+	put(head, uint16(hdr.underlineThickness)) // underline thickness
+
+	out := &bytes.Buffer{}
+	for _, t := range []*bytes.Buffer{head, encodeCmap(glyphs), loca, glyf} {
+		out.Write(finishTable(t))
+	}
+	return out.Bytes()
+}
+
+// encodeCmap maps characters to glyph IDs using a subtable for each run
+// of consecutive characters, in the format that doesn't need any data
+// besides the subtable header.
+func encodeCmap(glyphs []fontGlyph) *bytes.Buffer {
+	type run struct {
+		start   rune
+		length  int
+		firstID int
+	}
+
+	var runs []run
+	for i, g := range glyphs {
+		if n := len(runs); n > 0 {
+			last := &runs[n-1]
+			if g.r == last.start+rune(last.length) && last.length < math.MaxUint16 {
+				last.length++
+				continue
+			}
+		}
+		runs = append(runs, run{g.r, 1, i + 1})
+	}
+
+	cmap := newTable("cmap")
+	put(cmap, uint32(len(runs)))
+	for _, r := range runs {
+		put(cmap, uint32(0))         // data offset, there's no data
+		put(cmap, uint32(r.start))   // range start
+		put(cmap, uint16(r.length))  // range length
+		put(cmap, uint16(r.firstID)) // glyph id offset
+		put(cmap, uint16(0))         // entries count
+		put(cmap, uint8(2))          // format: format 0 tiny
+		put(cmap, uint8(0))          // padding
+	}
+	return cmap
+}
+
+// newTable starts a table with a placeholder for its size, followed by its label
+func newTable(label string) *bytes.Buffer {
+	t := &bytes.Buffer{}
+	put(t, uint32(0))
+	t.WriteString(label)
+	return t
+}
+
+// finishTable pads the table to a multiple of 4 bytes
+// and fills in its size
+func finishTable(t *bytes.Buffer) []byte {
+	for t.Len()%4 != 0 {
+		t.WriteByte(0)
+	}
+	b := t.Bytes()
+	binary.LittleEndian.PutUint32(b, uint32(len(b)))
+	return b
+}
+
+func put(t *bytes.Buffer, v any) {
+	_ = binary.Write(t, binary.LittleEndian, v)
+}
+
+// bitWriter packs values into bytes, most significant bit first
+type bitWriter struct {
+	buf  []byte
+	nbit int
+}
+
+// write writes the lowest n bits of v
+func (bw *bitWriter) write(v uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if bw.nbit%8 == 0 {
+			bw.buf = append(bw.buf, 0)
+		}
+		if v>>i&1 != 0 {
+			bw.buf[len(bw.buf)-1] |= 0x80 >> (bw.nbit % 8)
+		}
+		bw.nbit++
+	}
+}
+
+func (bw *bitWriter) bytes() []byte {
+	return bw.buf
+}
+
+// bitLen returns the amount of bits needed to store v as an unsigned number
+func bitLen(v int) int {
+	n := 0
+	for ; v > 0; v >>= 1 {
+		n++
+	}
+	return n
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,135 @@
+package resources
+
+import (
+	"encoding/binary"
+	"errors"
+	"slices"
+	"testing"
+
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+)
+
+func TestParseRange(t *testing.T) {
+	runes, err := ParseRange("0x41-0x43, 97 ,0xb0")
+	if err != nil {
+		t.Fatalf("Error parsing range: %s", err)
+	}
+
+	expected := []rune{'A', 'B', 'C', 'a', '°'}
+	if !slices.Equal(runes, expected) {
+		t.Errorf("Expected %q, got %q", expected, runes)
+	}
+
+	for _, s := range []string{"0x43-0x41", "abc", "0x20-"} {
+		_, err = ParseRange(s)
+		if !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("Expected ErrInvalidRange for %q, got %v", s, err)
+		}
+	}
+}
+
+func TestConvertFont(t *testing.T) {
+	f, err := sfnt.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Error parsing font: %s", err)
+	}
+
+	// U+E000 isn't in the font, so it should be left out
+	srcs := []FontSource{{Font: f, Runes: []rune{' ', 'A', 'B', 'x', 0xE000}}}
+	data, err := ConvertFont(srcs, 20, 2)
+	if err != nil {
+		t.Fatalf("Error converting font: %s", err)
+	}
+
+	tables := map[string][]byte{}
+	var labels []string
+	for off := 0; off < len(data); {
+		size := int(binary.LittleEndian.Uint32(data[off:]))
+		if size < 8 || off+size > len(data) || size%4 != 0 {
+			t.Fatalf("Invalid table size %d at offset %d", size, off)
+		}
+		label := string(data[off+4 : off+8])
+		labels = append(labels, label)
+		tables[label] = data[off+8 : off+size]
+		off += size
+	}
+
+	expectedLabels := []string{"head", "cmap", "loca", "glyf"}
+	if !slices.Equal(labels, expectedLabels) {
+		t.Fatalf("Expected tables %v, got %v", expectedLabels, labels)
+	}
+
+	head := tables["head"]
+	if n := binary.LittleEndian.Uint16(head[4:]); n != 3 {
+		t.Errorf("Expected 3 tables after head, got %d", n)
+	}
+	if bpp := head[29]; bpp != 2 {
+		t.Errorf("Expected 2 bits per pixel, got %d", bpp)
+	}
+
+	// The runs should be " ", "AB" and "x", with glyph IDs starting at 1
+	cmap := tables["cmap"]
+	if n := binary.LittleEndian.Uint32(cmap); n != 3 {
+		t.Fatalf("Expected 3 cmap subtables, got %d", n)
+	}
+	sub := cmap[4+16:]
+	start := binary.LittleEndian.Uint32(sub[4:])
+	length := binary.LittleEndian.Uint16(sub[8:])
+	firstID := binary.LittleEndian.Uint16(sub[10:])
+	if start != 'A' || length != 2 || firstID != 2 {
+		t.Errorf("Expected range A with length 2 at ID 2, got %q with length %d at ID %d", rune(start), length, firstID)
+	}
+
+	loca := tables["loca"]
+	if n := binary.LittleEndian.Uint32(loca); n != 5 {
+		t.Errorf("Expected 5 glyphs, got %d", n)
+	}
+
+	// Decode the box of 'A' and compare it to the rendered glyph
+	xyBits, whBits, advBits := int(head[30]), int(head[31]), int(head[32])
+	glyphOff := int(binary.LittleEndian.Uint16(loca[4+2*2:])) - 8
+	br := bitReader{buf: tables["glyf"][glyphOff:]}
+	adv := br.read(advBits)
+	br.read(xyBits)
+	br.read(xyBits)
+	w := br.read(whBits)
+	h := br.read(whBits)
+
+	if adv <= 0 || w <= 0 || h <= 0 {
+		t.Errorf("Expected a non-empty glyph for A, got adv=%d w=%d h=%d", adv, w, h)
+	}
+}
+
+func TestConvertFontInvalid(t *testing.T) {
+	f, err := sfnt.Parse(goregular.TTF)
+	if err != nil {
+		t.Fatalf("Error parsing font: %s", err)
+	}
+
+	_, err = ConvertFont([]FontSource{{Font: f, Runes: []rune{'A'}}}, 20, 5)
+	if !errors.Is(err, ErrInvalidBPP) {
+		t.Errorf("Expected ErrInvalidBPP, got %v", err)
+	}
+
+	_, err = ConvertFont([]FontSource{{Font: f, Runes: []rune{0xE000}}}, 20, 1)
+	if !errors.Is(err, ErrNoGlyphs) {
+		t.Errorf("Expected ErrNoGlyphs, got %v", err)
+	}
+}
+
+// bitReader reads values packed by bitWriter
+type bitReader struct {
+	buf  []byte
+	nbit int
+}
+
+func (br *bitReader) read(n int) int {
+	v := 0
+	for i := 0; i < n; i++ {
+		bit := br.buf[br.nbit/8] >> (7 - br.nbit%8) & 1
+		v = v<<1 | int(bit)
+		br.nbit++
+	}
+	return v
+}
@@ -0,0 +1,93 @@
+package resources
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+)
+
+var ErrImageTooLarge = errors.New("image must be at most 2047x2047 pixels")
+
+// ColorFormat is an LVGL image color format
+type ColorFormat string
+
+const (
+	// ColorFormatTrueColor stores each pixel as RGB565
+	ColorFormatTrueColor ColorFormat = "CF_TRUE_COLOR"
+	// ColorFormatTrueColorAlpha stores each pixel as RGB565 followed by
+	// an 8-bit alpha value. InfiniTime uses it for its own images.
+	ColorFormatTrueColorAlpha ColorFormat = "CF_TRUE_COLOR_ALPHA"
+)
+
+// lvglCF returns LVGL's ID for the color format
+func (cf ColorFormat) lvglCF() (uint32, error) {
+	switch cf {
+	case ColorFormatTrueColor:
+		return 4, nil
+	case ColorFormatTrueColorAlpha:
+		return 5, nil
+	default:
+		return 0, fmt.Errorf("unsupported color format: %q", cf)
+	}
+}
+
+// maxImageSize is the largest width or height that fits in an LVGL image header
+const maxImageSize = 1<<11 - 1
+
+// ConvertImage encodes img in LVGL's binary image format, which InfiniTime
+// loads external images from. Colors are stored as byte-swapped RGB565,
+// since InfiniTime is built with LV_COLOR_16_SWAP.
+func ConvertImage(img image.Image, cf ColorFormat) ([]byte, error) {
+	id, err := cf.lvglCF()
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w > maxImageSize || h > maxImageSize {
+		return nil, ErrImageTooLarge
+	}
+
+	pxSize := 2
+	if cf == ColorFormatTrueColorAlpha {
+		pxSize = 3
+	}
+
+	out := make([]byte, 4, 4+w*h*pxSize)
+	// The header is a bitfield containing the color format,
+	// two reserved fields, the width and the height.
+	binary.LittleEndian.PutUint32(out, id|uint32(w)<<10|uint32(h)<<21)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if cf == ColorFormatTrueColor {
+				// Without an alpha channel, blend the pixel onto black
+				c = color.NRGBA{
+					R: uint8(uint16(c.R) * uint16(c.A) / 255),
+					G: uint8(uint16(c.G) * uint16(c.A) / 255),
+					B: uint8(uint16(c.B) * uint16(c.A) / 255),
+				}
+			}
+
+			px := rgb565(c)
+			out = append(out, byte(px>>8), byte(px))
+			if cf == ColorFormatTrueColorAlpha {
+				out = append(out, c.A)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// rgb565 converts c to RGB565, rounding each channel
+func rgb565(c color.NRGBA) uint16 {
+	r := (uint16(c.R)*31 + 127) / 255
+	g := (uint16(c.G)*63 + 127) / 255
+	b := (uint16(c.B)*31 + 127) / 255
+	return r<<11 | g<<5 | b
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// errBLEPaused is returned for work that's dropped because
+// communication with the watch is paused
+var errBLEPaused = errors.New("communication with the watch is paused")
+
+// maxDeferred is the maximum amount of work that's kept while paused
+const maxDeferred = 100
+
+// blePriority controls what happens to work that's
+// submitted while the scheduler is paused
+type blePriority uint8
+
+const (
+	// priorityLow work is dropped while paused, because
+	// it's pointless by the time the scheduler resumes.
+	priorityLow blePriority = iota
+	// priorityNormal work is deferred while paused. Only the latest
+	// work with each name is kept, since it supersedes the older work.
+	priorityNormal
+	// priorityHigh work is deferred while paused, and all of it runs
+	// before any normal priority work once the scheduler resumes.
+	priorityHigh
+)
+
+// sched schedules all the background communication with the watch
+var sched = &bleScheduler{}
+
+// bleJob is a unit of work submitted to the scheduler
+type bleJob struct {
+	name string
+	fn   func() error
+}
+
+// bleScheduler runs work that communicates with the watch. Work runs
+// immediately, unless something that needs exclusive access to the watch,
+// such as a firmware upgrade, has paused the scheduler. Work submitted
+// while paused is dropped or deferred depending on its priority.
+type bleScheduler struct {
+	mtx    sync.Mutex
+	pauses int
+	// draining is true while deferred work is running
+	draining bool
+	high     []bleJob
+	normal   []bleJob
+	// running is the amount of work that's currently running, and idle
+	// is closed once it drops back to zero, so that pausing can wait
+	// for work that started before the pause.
+	running int
+	idle    chan struct{}
+}
+
+// run runs fn immediately if the scheduler isn't paused. Otherwise,
+// low priority work is dropped and errBLEPaused is returned, while
+// other work is deferred and nil is returned.
+func (s *bleScheduler) run(pri blePriority, name string, fn func() error) error {
+	s.mtx.Lock()
+	if s.pauses > 0 && pri == priorityLow {
+		s.mtx.Unlock()
+		log.Debug("Dropping work while communication with the watch is paused", slog.String("name", name))
+		return errBLEPaused
+	}
+
+	// Work submitted while deferred work is draining is deferred as well,
+	// so that it can't be overwritten by older work.
+	if s.pauses > 0 || s.draining {
+		defer s.mtx.Unlock()
+		return s.deferLocked(pri, bleJob{name, fn})
+	}
+	s.startLocked()
+	s.mtx.Unlock()
+	defer s.finish()
+
+	return fn()
+}
+
+// startLocked records that work has started running.
+// The caller must hold s.mtx.
+func (s *bleScheduler) startLocked() {
+	if s.running == 0 {
+		s.idle = make(chan struct{})
+	}
+	s.running++
+}
+
+// finish records that work has finished running
+func (s *bleScheduler) finish() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.running--
+	if s.running == 0 {
+		close(s.idle)
+		s.idle = nil
+	}
+}
+
+// deferLocked queues job to run once the scheduler resumes
+func (s *bleScheduler) deferLocked(pri blePriority, job bleJob) error {
+	if pri != priorityHigh {
+		// Newer work replaces older work with the same name, and
+		// moves to the back of the queue so it runs in order.
+		s.normal = slices.DeleteFunc(s.normal, func(j bleJob) bool {
+			return j.name == job.name
+		})
+	}
+
+	if len(s.high)+len(s.normal) >= maxDeferred {
+		log.Warn("Too much work deferred, dropping it", slog.String("name", job.name))
+		return errBLEPaused
+	}
+
+	if pri == priorityHigh {
+		s.high = append(s.high, job)
+	} else {
+		s.normal = append(s.normal, job)
+	}
+	return nil
+}
+
+// pause pauses the scheduler until the returned function is called,
+// waiting for any work that's already running to finish first.
+// The scheduler only resumes once every pause has ended.
+func (s *bleScheduler) pause(reason string) (resume func()) {
+	s.mtx.Lock()
+	s.pauses++
+	idle := s.idle
+	s.mtx.Unlock()
+	log.Info("Pausing communication with the watch", slog.String("reason", reason))
+
+	if idle != nil {
+		<-idle
+	}
+
+	return sync.OnceFunc(func() {
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+
+		s.pauses--
+		if s.pauses > 0 {
+			return
+		}
+		log.Info("Resuming communication with the watch", slog.String("reason", reason))
+
+		if !s.draining && len(s.high)+len(s.normal) > 0 {
+			s.draining = true
+			go s.drain()
+		}
+	})
+}
+
+// drain runs deferred work in order of priority until there's
+// none left, or the scheduler is paused again.
+func (s *bleScheduler) drain() {
+	for {
+		s.mtx.Lock()
+		var job bleJob
+		switch {
+		case s.pauses > 0:
+			s.draining = false
+			s.mtx.Unlock()
+			return
+		case len(s.high) > 0:
+			job, s.high = s.high[0], s.high[1:]
+		case len(s.normal) > 0:
+			job, s.normal = s.normal[0], s.normal[1:]
+		default:
+			s.draining = false
+			s.mtx.Unlock()
+			return
+		}
+		s.startLocked()
+		s.mtx.Unlock()
+
+		err := job.fn()
+		s.finish()
+		if err != nil {
+			log.Warn("Error running deferred work", slog.String("name", job.name), slog.Any("error", err))
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	log = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	var mtx sync.Mutex
+	var ran []string
+	job := func(name string) func() error {
+		return func() error {
+			mtx.Lock()
+			defer mtx.Unlock()
+			ran = append(ran, name)
+			return nil
+		}
+	}
+
+	s := &bleScheduler{}
+
+	// Work runs immediately while the scheduler isn't paused
+	err := s.run(priorityLow, "low", job("low"))
+	if err != nil || len(ran) != 1 {
+		t.Fatalf("Expected work to run immediately, got %v, %v", ran, err)
+	}
+	ran = nil
+
+	resume := s.pause("test")
+	// A nested pause must not resume the scheduler early
+	s.pause("nested")()
+
+	err = s.run(priorityLow, "low", job("low"))
+	if !errors.Is(err, errBLEPaused) {
+		t.Errorf("Expected %v, got %v", errBLEPaused, err)
+	}
+	s.run(priorityNormal, "music", job("music 1"))
+	s.run(priorityHigh, "notification", job("notification 1"))
+	s.run(priorityNormal, "weather", job("weather"))
+	s.run(priorityNormal, "music", job("music 2"))
+	s.run(priorityHigh, "notification", job("notification 2"))
+
+	mtx.Lock()
+	if len(ran) != 0 {
+		t.Errorf("Expected no work to run while paused, got %v", ran)
+	}
+	mtx.Unlock()
+
+	resume()
+	// Resuming more than once must not affect other pauses
+	resume()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.mtx.Lock()
+		draining := s.draining
+		s.mtx.Unlock()
+		if !draining || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	expected := []string{"notification 1", "notification 2", "weather", "music 2"}
+	if !slices.Equal(ran, expected) {
+		t.Errorf("Expected deferred work %v, got %v", expected, ran)
+	}
+}
+
+func TestSchedulerPauseWaits(t *testing.T) {
+	log = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	s := &bleScheduler{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go s.run(priorityLow, "slow", func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+
+	paused := make(chan func())
+	go func() {
+		paused <- s.pause("test")
+	}()
+
+	// Pausing must wait for the work that's already running
+	select {
+	case <-paused:
+		t.Fatal("Expected pause to wait for running work")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case resume := <-paused:
+		resume()
+	case <-time.After(time.Second):
+		t.Fatal("Expected pause to return once running work finished")
+	}
+}
@@ -19,19 +19,22 @@
 package main
 
 import (
-	"archive/zip"
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"go.elara.ws/drpc/muxserver"
 	"go.elara.ws/itd/infinitime"
 	"go.elara.ws/itd/internal/rpc"
+	"go.elara.ws/itd/translit"
 	"storj.io/drpc/drpcmux"
 )
 
@@ -60,6 +63,9 @@ func startSocket(ctx context.Context, wg WaitGroup, dev *infinitime.Device) erro
 		return err
 	}
 
+	// Jobs outlive the requests that start them, but not itd
+	jobs = newJobManager(ctx)
+
 	fs := dev.FS()
 	mux := drpcmux.New()
 
@@ -73,6 +79,21 @@ func startSocket(ctx context.Context, wg WaitGroup, dev *infinitime.Device) erro
 		return err
 	}
 
+	err = rpc.DRPCRegisterMusic(mux, &Music{dev})
+	if err != nil {
+		return err
+	}
+
+	err = rpc.DRPCRegisterNavigation(mux, &Navigation{dev})
+	if err != nil {
+		return err
+	}
+
+	err = rpc.DRPCRegisterJobs(mux, &Jobs{})
+	if err != nil {
+		return err
+	}
+
 	log.Info("Starting control socket", slog.String("path", cfg.Socket.Path))
 
 	wg.Add(1)
@@ -88,8 +109,8 @@ type ITD struct {
 	dev *infinitime.Device
 }
 
-func (i *ITD) HeartRate(_ context.Context, _ *rpc.Empty) (*rpc.IntResponse, error) {
-	hr, err := i.dev.HeartRate()
+func (i *ITD) HeartRate(ctx context.Context, _ *rpc.Empty) (*rpc.IntResponse, error) {
+	hr, err := i.dev.HeartRateContext(ctx)
 	return &rpc.IntResponse{Value: uint32(hr)}, err
 }
 
@@ -119,8 +140,8 @@ func (i *ITD) WatchHeartRate(_ *rpc.Empty, s rpc.DRPCITD_WatchHeartRateStream) e
 	}
 }
 
-func (i *ITD) BatteryLevel(_ context.Context, _ *rpc.Empty) (*rpc.IntResponse, error) {
-	bl, err := i.dev.BatteryLevel()
+func (i *ITD) BatteryLevel(ctx context.Context, _ *rpc.Empty) (*rpc.IntResponse, error) {
+	bl, err := i.dev.BatteryLevelContext(ctx)
 	return &rpc.IntResponse{Value: uint32(bl)}, err
 }
 
@@ -150,8 +171,8 @@ func (i *ITD) WatchBatteryLevel(_ *rpc.Empty, s rpc.DRPCITD_WatchBatteryLevelStr
 	}
 }
 
-func (i *ITD) Motion(_ context.Context, _ *rpc.Empty) (*rpc.MotionResponse, error) {
-	motionVals, err := i.dev.Motion()
+func (i *ITD) Motion(ctx context.Context, _ *rpc.Empty) (*rpc.MotionResponse, error) {
+	motionVals, err := i.dev.MotionContext(ctx)
 	return &rpc.MotionResponse{
 		X: int32(motionVals.X),
 		Y: int32(motionVals.Y),
@@ -189,8 +210,8 @@ func (i *ITD) WatchMotion(_ *rpc.Empty, s rpc.DRPCITD_WatchMotionStream) error {
 	}
 }
 
-func (i *ITD) StepCount(_ context.Context, _ *rpc.Empty) (*rpc.IntResponse, error) {
-	sc, err := i.dev.StepCount()
+func (i *ITD) StepCount(ctx context.Context, _ *rpc.Empty) (*rpc.IntResponse, error) {
+	sc, err := i.dev.StepCountContext(ctx)
 	return &rpc.IntResponse{Value: sc}, err
 }
 
@@ -220,8 +241,8 @@ func (i *ITD) WatchStepCount(_ *rpc.Empty, s rpc.DRPCITD_WatchStepCountStream) e
 	}
 }
 
-func (i *ITD) Version(_ context.Context, _ *rpc.Empty) (*rpc.StringResponse, error) {
-	v, err := i.dev.Version()
+func (i *ITD) Version(ctx context.Context, _ *rpc.Empty) (*rpc.StringResponse, error) {
+	v, err := i.dev.VersionContext(ctx)
 	return &rpc.StringResponse{Value: v}, err
 }
 
@@ -229,28 +250,79 @@ func (i *ITD) Address(_ context.Context, _ *rpc.Empty) (*rpc.StringResponse, err
 	return &rpc.StringResponse{Value: i.dev.Address()}, nil
 }
 
-func (i *ITD) Notify(_ context.Context, data *rpc.NotifyRequest) (*rpc.Empty, error) {
-	return &rpc.Empty{}, i.dev.Notify(data.Title, data.Body)
+func (i *ITD) Capabilities(_ context.Context, _ *rpc.Empty) (*rpc.CapabilitiesResponse, error) {
+	caps := i.dev.Capabilities()
+	return &rpc.CapabilitiesResponse{
+		Notifications: caps.Notifications,
+		CallControl:   caps.CallControl,
+		Time:          caps.Time,
+		LocalTime:     caps.LocalTime,
+		Battery:       caps.Battery,
+		HeartRate:     caps.HeartRate,
+		StepCount:     caps.StepCount,
+		Motion:        caps.Motion,
+		Music:         caps.Music,
+		Navigation:    caps.Navigation,
+		Weather:       caps.Weather,
+		Fs:            caps.FS,
+		Dfu:           caps.DFU,
+	}, nil
+}
+
+func (i *ITD) GetTime(ctx context.Context, _ *rpc.Empty) (*rpc.TimeResponse, error) {
+	drift, err := i.dev.ClockDriftContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The watch's time is derived from the drift,
+	// so that both come from the same read
+	return &rpc.TimeResponse{
+		UnixNano:  time.Now().Add(drift).UnixNano(),
+		DriftNano: int64(drift),
+	}, nil
+}
+
+func (i *ITD) Notify(ctx context.Context, data *rpc.NotifyRequest) (*rpc.Empty, error) {
+	// The notification may be deferred until after the request is done
+	ctx = context.WithoutCancel(ctx)
+	err := sched.run(priorityHigh, "notification", func() error {
+		return i.dev.NotifyContext(ctx, data.Title, data.Body)
+	})
+	return &rpc.Empty{}, err
 }
 
-func (i *ITD) SetTime(_ context.Context, data *rpc.SetTimeRequest) (*rpc.Empty, error) {
-	return &rpc.Empty{}, i.dev.SetTime(time.Unix(0, data.UnixNano))
+func (i *ITD) SetTime(ctx context.Context, data *rpc.SetTimeRequest) (*rpc.Empty, error) {
+	// The requested time would be stale if it was deferred, so it's dropped instead
+	err := sched.run(priorityLow, "setTime", func() error {
+		return i.dev.SetTimeContext(ctx, time.Unix(0, data.UnixNano))
+	})
+	return &rpc.Empty{}, err
 }
 
 func (i *ITD) WeatherUpdate(context.Context, *rpc.Empty) (*rpc.Empty, error) {
-	sendWeatherCh <- struct{}{}
+	requestWeather()
 	return &rpc.Empty{}, nil
 }
 
-func (i *ITD) FirmwareUpgrade(data *rpc.FirmwareUpgradeRequest, s rpc.DRPCITD_FirmwareUpgradeStream) (err error) {
-	var fwimg, initpkt *os.File
+func (i *ITD) FirmwareUpgrade(data *rpc.FirmwareUpgradeRequest, s rpc.DRPCITD_FirmwareUpgradeStream) error {
+	var (
+		fwimg, initpkt io.Reader
+		closers        []io.Closer
+	)
 
 	switch data.Type {
 	case rpc.FirmwareUpgradeRequest_Archive:
-		fwimg, initpkt, err = extractDFU(data.Files[0])
+		if len(data.Files) < 1 {
+			return ErrDFUNotEnoughFiles
+		}
+
+		archive, err := infinitime.ReadDFUArchive(data.Files[0])
 		if err != nil {
 			return err
 		}
+		initpkt = bytes.NewReader(archive.InitPacket)
+		fwimg = bytes.NewReader(archive.Image)
 	case rpc.FirmwareUpgradeRequest_Files:
 		if len(data.Files) < 2 {
 			return ErrDFUNotEnoughFiles
@@ -264,48 +336,127 @@ func (i *ITD) FirmwareUpgrade(data *rpc.FirmwareUpgradeRequest, s rpc.DRPCITD_Fi
 			return ErrDFUInvalidFile
 		}
 
-		initpkt, err = os.Open(data.Files[0])
+		initpktFl, err := os.Open(data.Files[0])
 		if err != nil {
 			return err
 		}
 
-		fwimg, err = os.Open(data.Files[1])
+		fwimgFl, err := os.Open(data.Files[1])
 		if err != nil {
+			initpktFl.Close()
 			return err
 		}
+
+		initpkt, fwimg = initpktFl, fwimgFl
+		closers = append(closers, initpktFl, fwimgFl)
 	default:
 		return ErrDFUInvalidUpgType
 	}
 
-	defer os.Remove(fwimg.Name())
-	defer os.Remove(initpkt.Name())
-	defer fwimg.Close()
-	defer initpkt.Close()
+	j := jobs.start("firmwareUpgrade", strings.Join(data.Files, ", "), func(ctx context.Context, j *job) error {
+		for _, c := range closers {
+			defer c.Close()
+		}
 
-	firmwareUpdating = true
-	defer func() { firmwareUpdating = false }()
+		send := func(p *rpc.DFUProgress) {
+			p.JobId = j.id
+			j.progress(&rpc.JobEvent{Progress: &rpc.JobEvent_Dfu{Dfu: p}})
+		}
 
-	return i.dev.UpgradeFirmware(infinitime.DFUOptions{
-		InitPacket:    initpkt,
-		FirmwareImage: fwimg,
-		ProgressFunc: func(sent, received, total uint32) {
-			_ = s.Send(&rpc.DFUProgress{
+		// The previous version is only used to tell whether
+		// the firmware changed, so it's fine if it's unknown.
+		var prevStr string
+		current, err := i.dev.FirmwareVersionContext(ctx)
+		if err == nil {
+			prevStr = current.String()
+		}
+
+		// The version of the firmware in the files isn't known, so
+		// the watch staying on the previous version is reported to the
+		// client rather than treated as a revert, since the same
+		// version may have been flashed again.
+		booted, err := upgradeFirmware(ctx, i.dev, initpkt, fwimg, nil, func(sent, received, total uint32) {
+			send(&rpc.DFUProgress{
 				Sent:     int64(sent),
 				Recieved: int64(received),
 				Total:    int64(total),
 			})
-		},
+		}, func() {
+			send(&rpc.DFUProgress{
+				Stage:           rpc.DFUProgress_Reboot,
+				PreviousVersion: prevStr,
+			})
+		})
+		if err != nil {
+			return err
+		}
+
+		send(&rpc.DFUProgress{
+			Stage:           rpc.DFUProgress_Done,
+			PreviousVersion: prevStr,
+			Version:         booted.String(),
+		})
+		return nil
+	})
+
+	return j.follow(s.Context(), data.Detach, func(evt *rpc.JobEvent) error {
+		return s.Send(evt.GetDfu())
 	})
 }
 
+func (i *ITD) FirmwareUpdate(req *rpc.FirmwareUpdateRequest, s rpc.DRPCITD_FirmwareUpdateStream) error {
+	desc := req.Version
+	if desc == "" {
+		desc = "latest"
+	}
+
+	j := jobs.start("firmwareUpdate", desc, func(ctx context.Context, j *job) error {
+		return updateFirmware(ctx, i.dev, req, func(p *rpc.FirmwareUpdateProgress) error {
+			p.JobId = j.id
+			j.progress(&rpc.JobEvent{Progress: &rpc.JobEvent_FirmwareUpdate{FirmwareUpdate: p}})
+			return nil
+		})
+	})
+
+	return j.follow(s.Context(), req.Detach, func(evt *rpc.JobEvent) error {
+		return s.Send(evt.GetFirmwareUpdate())
+	})
+}
+
+// FirmwareValidateReminder checks that MCUBoot hasn't already reverted to
+// the previous firmware, and sends a notification asking the user to validate
+// the running firmware on the watch, since InfiniTime only lets the user do
+// that from its settings app.
+func (i *ITD) FirmwareValidateReminder(ctx context.Context, _ *rpc.Empty) (*rpc.FirmwareValidateReminderResponse, error) {
+	current, err := i.dev.FirmwareVersionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkFirmwareReverted(current)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sched.run(priorityLow, "validateReminder", func() error {
+		msg := fmt.Sprintf("Open Settings > Firmware and validate InfiniTime %s to keep it after the next reboot.", current)
+		return i.dev.NotifyContext(ctx, "itd", msg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpc.FirmwareValidateReminderResponse{Version: current.String()}, nil
+}
+
 type FS struct {
 	dev *infinitime.Device
 	fs  *infinitime.FS
 }
 
-func (fs *FS) RemoveAll(_ context.Context, req *rpc.PathsRequest) (*rpc.Empty, error) {
+func (fs *FS) RemoveAll(ctx context.Context, req *rpc.PathsRequest) (*rpc.Empty, error) {
 	for _, path := range req.Paths {
-		err := fs.fs.RemoveAll(path)
+		err := fs.fs.RemoveAllContext(ctx, path)
 		if err != nil {
 			return &rpc.Empty{}, err
 		}
@@ -313,9 +464,9 @@ func (fs *FS) RemoveAll(_ context.Context, req *rpc.PathsRequest) (*rpc.Empty, e
 	return &rpc.Empty{}, nil
 }
 
-func (fs *FS) Remove(_ context.Context, req *rpc.PathsRequest) (*rpc.Empty, error) {
+func (fs *FS) Remove(ctx context.Context, req *rpc.PathsRequest) (*rpc.Empty, error) {
 	for _, path := range req.Paths {
-		err := fs.fs.Remove(path)
+		err := fs.fs.RemoveContext(ctx, path)
 		if err != nil {
 			return &rpc.Empty{}, err
 		}
@@ -323,13 +474,13 @@ func (fs *FS) Remove(_ context.Context, req *rpc.PathsRequest) (*rpc.Empty, erro
 	return &rpc.Empty{}, nil
 }
 
-func (fs *FS) Rename(_ context.Context, req *rpc.RenameRequest) (*rpc.Empty, error) {
-	return &rpc.Empty{}, fs.fs.Rename(req.From, req.To)
+func (fs *FS) Rename(ctx context.Context, req *rpc.RenameRequest) (*rpc.Empty, error) {
+	return &rpc.Empty{}, fs.fs.RenameContext(ctx, req.From, req.To)
 }
 
-func (fs *FS) MkdirAll(_ context.Context, req *rpc.PathsRequest) (*rpc.Empty, error) {
+func (fs *FS) MkdirAll(ctx context.Context, req *rpc.PathsRequest) (*rpc.Empty, error) {
 	for _, path := range req.Paths {
-		err := fs.fs.MkdirAll(path)
+		err := fs.fs.MkdirAllContext(ctx, path)
 		if err != nil {
 			return &rpc.Empty{}, err
 		}
@@ -337,9 +488,9 @@ func (fs *FS) MkdirAll(_ context.Context, req *rpc.PathsRequest) (*rpc.Empty, er
 	return &rpc.Empty{}, nil
 }
 
-func (fs *FS) Mkdir(_ context.Context, req *rpc.PathsRequest) (*rpc.Empty, error) {
+func (fs *FS) Mkdir(ctx context.Context, req *rpc.PathsRequest) (*rpc.Empty, error) {
 	for _, path := range req.Paths {
-		err := fs.fs.Mkdir(path)
+		err := fs.fs.MkdirContext(ctx, path)
 		if err != nil {
 			return &rpc.Empty{}, err
 		}
@@ -347,8 +498,8 @@ func (fs *FS) Mkdir(_ context.Context, req *rpc.PathsRequest) (*rpc.Empty, error
 	return &rpc.Empty{}, nil
 }
 
-func (fs *FS) ReadDir(_ context.Context, req *rpc.PathRequest) (*rpc.DirResponse, error) {
-	entries, err := fs.fs.ReadDir(req.Path)
+func (fs *FS) ReadDir(ctx context.Context, req *rpc.PathRequest) (*rpc.DirResponse, error) {
+	entries, err := fs.fs.ReadDirContext(ctx, req.Path)
 	if err != nil {
 		return nil, err
 	}
@@ -376,26 +527,30 @@ func (fs *FS) Upload(req *rpc.TransferRequest, s rpc.DRPCFS_UploadStream) error
 
 	localInfo, err := localFile.Stat()
 	if err != nil {
+		localFile.Close()
 		return err
 	}
 
-	remoteFile, err := fs.fs.Create(req.Destination, uint32(localInfo.Size()))
-	if err != nil {
-		return err
-	}
+	j := jobs.start("upload", req.Source+" -> "+req.Destination, func(ctx context.Context, j *job) error {
+		defer localFile.Close()
 
-	remoteFile.ProgressFunc = func(transferred, total uint32) {
-		_ = s.Send(&rpc.TransferProgress{
-			Total: total,
-			Sent:  transferred,
-		})
-	}
+		remoteFile, err := fs.fs.CreateContext(ctx, req.Destination, uint32(localInfo.Size()))
+		if err != nil {
+			return err
+		}
+		defer logCharCacheStats(fs.dev, "upload", time.Now())
 
-	io.Copy(remoteFile, localFile)
-	localFile.Close()
-	remoteFile.Close()
+		remoteFile.ProgressFunc = transferProgress(j)
 
-	return nil
+		_, err = io.Copy(remoteFile, localFile)
+		remoteFile.Close()
+
+		return err
+	})
+
+	return j.follow(s.Context(), req.Detach, func(evt *rpc.JobEvent) error {
+		return s.Send(evt.GetTransfer())
+	})
 }
 
 func (fs *FS) Download(req *rpc.TransferRequest, s rpc.DRPCFS_DownloadStream) error {
@@ -404,110 +559,188 @@ func (fs *FS) Download(req *rpc.TransferRequest, s rpc.DRPCFS_DownloadStream) er
 		return err
 	}
 
-	remoteFile, err := fs.fs.Open(req.Source)
-	if err != nil {
-		return err
-	}
+	j := jobs.start("download", req.Source+" -> "+req.Destination, func(ctx context.Context, j *job) error {
+		defer localFile.Close()
 
-	defer localFile.Close()
-	defer remoteFile.Close()
+		remoteFile, err := fs.fs.OpenContext(ctx, req.Source)
+		if err != nil {
+			return err
+		}
 
-	remoteFile.ProgressFunc = func(transferred, total uint32) {
-		_ = s.Send(&rpc.TransferProgress{
-			Total: total,
-			Sent:  transferred,
-		})
-	}
+		defer remoteFile.Close()
+		defer logCharCacheStats(fs.dev, "download", time.Now())
 
-	_, err = io.Copy(localFile, remoteFile)
-	if err != nil {
-		return err
-	}
+		remoteFile.ProgressFunc = transferProgress(j)
 
-	return nil
-}
+		_, err = io.Copy(localFile, remoteFile)
+		return err
+	})
 
-func (fs *FS) LoadResources(req *rpc.PathRequest, s rpc.DRPCFS_LoadResourcesStream) error {
-	return infinitime.LoadResources(req.Path, fs.fs, func(evt infinitime.ResourceLoadProgress) {
-		_ = s.Send(&rpc.ResourceLoadProgress{
-			Name:      evt.Name,
-			Total:     int64(evt.Total),
-			Sent:      int64(evt.Transferred),
-			Operation: rpc.ResourceLoadProgress_Operation(evt.Operation),
-		})
+	return j.follow(s.Context(), req.Detach, func(evt *rpc.JobEvent) error {
+		return s.Send(evt.GetTransfer())
 	})
 }
 
-func extractDFU(path string) (fwimg, initpkt *os.File, err error) {
-	zipReader, err := zip.OpenReader(path)
-	if err != nil {
-		return nil, nil, err
+// transferProgress returns a progress function that
+// reports the progress of a file transfer job
+func transferProgress(j *job) func(transferred, total uint32) {
+	return func(transferred, total uint32) {
+		j.progress(&rpc.JobEvent{Progress: &rpc.JobEvent_Transfer{Transfer: &rpc.TransferProgress{
+			Total: total,
+			Sent:  transferred,
+			JobId: j.id,
+		}}})
 	}
-	defer zipReader.Close()
+}
 
-	for _, file := range zipReader.File {
-		if fwimg != nil && initpkt != nil {
-			break
+func (fs *FS) LoadResources(req *rpc.LoadResourcesRequest, s rpc.DRPCFS_LoadResourcesStream) error {
+	j := jobs.start("loadResources", req.Path, func(ctx context.Context, j *job) error {
+		defer logCharCacheStats(fs.dev, "loadResources", time.Now())
+		if !req.DryRun {
+			defer sched.pause("loading resources")()
 		}
 
-		switch filepath.Ext(file.Name) {
-		case ".bin":
-			fwimg, err = os.CreateTemp(os.TempDir(), "itd_dfu_fwimg_*.bin")
-			if err != nil {
-				return nil, nil, err
-			}
+		return infinitime.LoadResourcesWithOptions(ctx, req.Path, fs.fs, infinitime.ResourceLoadOptions{
+			DryRun: req.DryRun,
+			Force:  req.Force,
+			Progress: func(evt infinitime.ResourceLoadProgress) {
+				j.progress(&rpc.JobEvent{Progress: &rpc.JobEvent_Resources{Resources: &rpc.ResourceLoadProgress{
+					Name:      evt.Name,
+					Total:     int64(evt.Total),
+					Sent:      int64(evt.Transferred),
+					Operation: rpc.ResourceLoadProgress_Operation(evt.Operation),
+					JobId:     j.id,
+				}}})
+			},
+		})
+	})
 
-			zipFile, err := file.Open()
-			if err != nil {
-				return nil, nil, err
-			}
-			defer zipFile.Close()
+	return j.follow(s.Context(), req.Detach, func(evt *rpc.JobEvent) error {
+		return s.Send(evt.GetResources())
+	})
+}
 
-			_, err = io.Copy(fwimg, zipFile)
-			if err != nil {
-				return nil, nil, err
-			}
+// logCharCacheStats logs the characteristic cache statistics at debug level,
+// so that the effect of the cache on large transfers can be measured.
+func logCharCacheStats(dev *infinitime.Device, op string, start time.Time) {
+	stats := dev.CharCacheStats()
+	log.Debug(
+		"Characteristic cache statistics",
+		slog.String("operation", op),
+		slog.Duration("duration", time.Since(start)),
+		slog.Uint64("hits", stats.Hits),
+		slog.Uint64("misses", stats.Misses),
+		slog.Duration("discoveryTime", stats.DiscoveryTime),
+	)
+}
 
-			err = zipFile.Close()
-			if err != nil {
-				return nil, nil, err
-			}
+type Music struct {
+	dev *infinitime.Device
+}
 
-			_, err = fwimg.Seek(0, io.SeekStart)
-			if err != nil {
-				return nil, nil, err
-			}
-		case ".dat":
-			initpkt, err = os.CreateTemp(os.TempDir(), "itd_dfu_initpkt_*.dat")
-			if err != nil {
-				return nil, nil, err
-			}
+// SetMetadata sets the fields of the music metadata that are set in req
+func (m *Music) SetMetadata(_ context.Context, req *rpc.MusicMetadata) (*rpc.Empty, error) {
+	maps := cfg.Notifs.Translit.Use
+	return &rpc.Empty{}, watchMusic.update(m.dev, func(s *musicState) {
+		if req.Artist != nil {
+			s.Artist = translit.Transliterate(*req.Artist, maps...)
+		}
+		if req.Track != nil {
+			s.Track = translit.Transliterate(*req.Track, maps...)
+		}
+		if req.Album != nil {
+			s.Album = translit.Transliterate(*req.Album, maps...)
+		}
+		if req.LengthNano != nil {
+			s.Length = time.Duration(*req.LengthNano)
+		}
+	})
+}
 
-			zipFile, err := file.Open()
-			if err != nil {
-				return nil, nil, err
+// SetStatus sets the fields of the playback status that are set in req
+func (m *Music) SetStatus(_ context.Context, req *rpc.MusicStatus) (*rpc.Empty, error) {
+	return &rpc.Empty{}, watchMusic.update(m.dev, func(s *musicState) {
+		if req.Playing != nil {
+			s.Playing = *req.Playing
+		}
+		if req.PositionNano != nil {
+			s.setPosition(time.Duration(*req.PositionNano))
+		}
+		if req.Shuffle != nil {
+			s.Shuffle = *req.Shuffle
+		}
+		if req.Repeat != nil {
+			s.Repeat = *req.Repeat
+		}
+		if req.Rate != nil {
+			s.Rate = *req.Rate
+			// A zero rate means the client doesn't know the speed, so assume normal speed
+			if s.Rate == 0 {
+				s.Rate = 1
 			}
+		}
+	})
+}
 
-			_, err = io.Copy(initpkt, zipFile)
-			if err != nil {
-				return nil, nil, err
-			}
+func (m *Music) GetState(_ context.Context, _ *rpc.Empty) (*rpc.MusicState, error) {
+	s := watchMusic.get()
+	length, pos := int64(s.Length), int64(s.Position())
+	return &rpc.MusicState{
+		Metadata: &rpc.MusicMetadata{
+			Artist:     &s.Artist,
+			Track:      &s.Track,
+			Album:      &s.Album,
+			LengthNano: &length,
+		},
+		Status: &rpc.MusicStatus{
+			Playing:      &s.Playing,
+			PositionNano: &pos,
+			Rate:         &s.Rate,
+			Shuffle:      &s.Shuffle,
+			Repeat:       &s.Repeat,
+		},
+	}, nil
+}
 
-			err = zipFile.Close()
-			if err != nil {
-				return nil, nil, err
-			}
+func (m *Music) WatchMusicEvents(_ *rpc.Empty, s rpc.DRPCMusic_WatchMusicEventsStream) error {
+	errCh := make(chan error, 1)
 
-			_, err = initpkt.Seek(0, io.SeekStart)
-			if err != nil {
-				return nil, nil, err
+	err := m.dev.WatchMusicEvents(s.Context(), func(event infinitime.MusicEvent, err error) {
+		if err == nil {
+			err = s.Send(&rpc.MusicEvent{Event: uint32(event)})
+		}
+		if err != nil {
+			select {
+			case errCh <- err:
+			default:
 			}
 		}
+	})
+	if err != nil {
+		return err
 	}
 
-	if fwimg == nil || initpkt == nil {
-		return nil, nil, errors.New("invalid dfu archive")
+	select {
+	case err := <-errCh:
+		return err
+	case <-s.Context().Done():
+		return nil
 	}
+}
+
+type Navigation struct {
+	dev *infinitime.Device
+}
+
+func (n *Navigation) SetNav(_ context.Context, req *rpc.NavigationState) (*rpc.Empty, error) {
+	return &rpc.Empty{}, navUpdates.setAll(n.dev, infinitime.NavState{
+		Flag:      infinitime.NavFlag(req.Flag),
+		Narrative: req.Narrative,
+		ManDist:   req.ManDist,
+		Progress:  uint8(min(req.Progress, 100)),
+	}, false)
+}
 
-	return fwimg, initpkt, nil
+func (n *Navigation) ClearNav(_ context.Context, _ *rpc.Empty) (*rpc.Empty, error) {
+	return &rpc.Empty{}, navUpdates.clear(n.dev)
 }
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.elara.ws/itd/infinitime"
+)
+
+// dstSyncDelay is how long after a DST transition the time is synced,
+// so that the system clock is definitely past the transition.
+const dstSyncDelay = time.Second
+
+// initTimeSync starts a job that syncs the watch's time
+// at DST transitions and on the configured interval.
+func initTimeSync(ctx context.Context, wg WaitGroup, dev *infinitime.Device) error {
+	interval := time.Duration(cfg.TimeSync.Interval) * time.Minute
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done("timeSync")
+
+		for {
+			next, reason := nextTimeSync(time.Now(), interval)
+			if next.IsZero() {
+				// There's no interval and no upcoming DST transition
+				<-ctx.Done()
+				return
+			}
+
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-timer.C:
+				syncTime(dev, reason)
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// maxZoneChanges is the maximum amount of zone changes nextTimeSync
+// looks through for one that changes the offset, so that it can't loop
+// forever on a timezone whose changes never change the offset.
+const maxZoneChanges = 100
+
+// nextTimeSync returns the time of the next time sync after now, and the
+// reason for it. If the local timezone changes its offset before the next
+// interval, the sync happens right after the change. If there's no interval
+// and no upcoming offset change, the zero time is returned.
+func nextTimeSync(now time.Time, interval time.Duration) (time.Time, string) {
+	var next time.Time
+	if interval > 0 {
+		next = now.Add(interval)
+	}
+
+	// Zones can change without changing the offset, such as when only
+	// the abbreviation changes, which doesn't matter, so look past those.
+	_, offset := now.Zone()
+	t := now
+	for range maxZoneChanges {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || (!next.IsZero() && !end.Before(next)) {
+			break
+		}
+
+		if _, newOffset := end.Zone(); newOffset != offset {
+			return end.Add(dstSyncDelay), "dst"
+		}
+		t = end
+	}
+
+	return next, "interval"
+}
+
+// syncTime sets the watch's time to the current time. If the scheduler
+// is paused, the time is set once it resumes.
+func syncTime(dev *infinitime.Device, reason string) {
+	err := sched.run(priorityNormal, "time", func() error {
+		return dev.SetTime(time.Now())
+	})
+	if err != nil {
+		log.Warn("Error syncing time", slog.Any("error", err), slog.String("reason", reason))
+		return
+	}
+
+	log.Debug("Synced time", slog.String("reason", reason))
+}
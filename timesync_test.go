@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestNextTimeSync(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// DST starts in Berlin at 2024-03-31 01:00 UTC
+	transition := time.Date(2024, 3, 31, 1, 0, 0, 0, time.UTC)
+
+	// Knox, Indiana switched from EST to CDT on 2006-04-02, which only
+	// changed the abbreviation, and then to CST at 2006-10-29 07:00 UTC
+	knox, err := time.LoadLocation("America/Indiana/Knox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	knoxTransition := time.Date(2006, 10, 29, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		interval   time.Duration
+		want       time.Time
+		wantReason string
+	}{
+		{
+			name:       "interval before transition",
+			now:        time.Date(2024, 3, 30, 12, 0, 0, 0, berlin),
+			interval:   time.Hour,
+			want:       time.Date(2024, 3, 30, 13, 0, 0, 0, berlin),
+			wantReason: "interval",
+		},
+		{
+			name:       "transition before interval",
+			now:        transition.Add(-30 * time.Minute).In(berlin),
+			interval:   time.Hour,
+			want:       transition.Add(dstSyncDelay),
+			wantReason: "dst",
+		},
+		{
+			name:       "transition without interval",
+			now:        time.Date(2024, 1, 1, 0, 0, 0, 0, berlin),
+			want:       transition.Add(dstSyncDelay),
+			wantReason: "dst",
+		},
+		{
+			name:       "transition after abbreviation change without interval",
+			now:        time.Date(2006, 1, 1, 0, 0, 0, 0, knox),
+			want:       knoxTransition.Add(dstSyncDelay),
+			wantReason: "dst",
+		},
+		{
+			name:       "interval before transition after abbreviation change",
+			now:        time.Date(2006, 1, 1, 0, 0, 0, 0, knox),
+			interval:   time.Hour,
+			want:       time.Date(2006, 1, 1, 1, 0, 0, 0, knox),
+			wantReason: "interval",
+		},
+		{
+			name: "no transition or interval",
+			now:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		got, reason := nextTimeSync(tt.now, tt.interval)
+		if !got.Equal(tt.want) || (!tt.want.IsZero() && reason != tt.wantReason) {
+			t.Errorf("%s: got %v (%s), want %v (%s)", tt.name, got, reason, tt.want, tt.wantReason)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"go.elara.ws/itd/mpris"
+)
+
+// volumeBackend changes the volume when the watch's volume buttons are pressed
+type volumeBackend interface {
+	VolUp(percent uint) error
+	VolDown(percent uint) error
+}
+
+// newVolumeBackend returns the volume backend selected in the config
+func newVolumeBackend(player *mpris.Client) (volumeBackend, error) {
+	switch cfg.Music.Vol.Backend {
+	case "auto", "":
+		return fallbackVolume{player, pactlVolume{}}, nil
+	case "mpris":
+		return player, nil
+	case "pulse", "pipewire":
+		return pactlVolume{}, nil
+	case "command":
+		if cfg.Music.Vol.UpCommand == "" || cfg.Music.Vol.DownCommand == "" {
+			return nil, errors.New("command volume backend requires vol.upCommand and vol.downCommand")
+		}
+		return commandVolume{cfg.Music.Vol.UpCommand, cfg.Music.Vol.DownCommand}, nil
+	default:
+		return nil, fmt.Errorf("unknown volume backend: %q", cfg.Music.Vol.Backend)
+	}
+}
+
+// fallbackVolume changes the player's volume, falling back to the
+// system volume if there's no player or the player doesn't support it.
+type fallbackVolume struct {
+	player   volumeBackend
+	fallback volumeBackend
+}
+
+func (fv fallbackVolume) VolUp(percent uint) error {
+	err := fv.player.VolUp(percent)
+	if errors.Is(err, mpris.ErrNoPlayer) || errors.Is(err, mpris.ErrNoVolume) {
+		log.Debug("Player volume unavailable, changing system volume", slog.Any("error", err))
+		return fv.fallback.VolUp(percent)
+	}
+	return err
+}
+
+func (fv fallbackVolume) VolDown(percent uint) error {
+	err := fv.player.VolDown(percent)
+	if errors.Is(err, mpris.ErrNoPlayer) || errors.Is(err, mpris.ErrNoVolume) {
+		log.Debug("Player volume unavailable, changing system volume", slog.Any("error", err))
+		return fv.fallback.VolDown(percent)
+	}
+	return err
+}
+
+// pactlVolume changes the volume of the default PulseAudio sink
+// using pactl. This also works with PipeWire via pipewire-pulse.
+type pactlVolume struct{}
+
+func (pactlVolume) VolUp(percent uint) error {
+	return pactlSetVolume(fmt.Sprintf("+%d%%", percent))
+}
+
+func (pactlVolume) VolDown(percent uint) error {
+	return pactlSetVolume(fmt.Sprintf("-%d%%", percent))
+}
+
+func pactlSetVolume(change string) error {
+	out, err := exec.Command("pactl", "set-sink-volume", "@DEFAULT_SINK@", change).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("pactl: %w: %s", err, out)
+	}
+	return nil
+}
+
+// commandVolume runs user-provided shell commands to change the volume.
+// The interval is passed to the commands in $ITD_VOL_INTERVAL.
+type commandVolume struct {
+	up, down string
+}
+
+func (cv commandVolume) VolUp(percent uint) error {
+	return runVolumeCommand(cv.up, percent)
+}
+
+func (cv commandVolume) VolDown(percent uint) error {
+	return runVolumeCommand(cv.down, percent)
+}
+
+func runVolumeCommand(command string, percent uint) error {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Env = append(os.Environ(), "ITD_VOL_INTERVAL="+strconv.FormatUint(uint64(percent), 10))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("volume command: %w: %s", err, out)
+	}
+	return nil
+}
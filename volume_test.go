@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"go.elara.ws/itd/mpris"
+)
+
+// fakeVolume records volume changes, and returns err for every change
+type fakeVolume struct {
+	err     error
+	changes []int
+}
+
+func (fv *fakeVolume) VolUp(percent uint) error {
+	fv.changes = append(fv.changes, int(percent))
+	return fv.err
+}
+
+func (fv *fakeVolume) VolDown(percent uint) error {
+	fv.changes = append(fv.changes, -int(percent))
+	return fv.err
+}
+
+func TestFallbackVolume(t *testing.T) {
+	log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	errOther := errors.New("other error")
+
+	type testCase struct {
+		name        string
+		playerErr   error
+		expectFall  bool
+		expectedErr error
+	}
+
+	cases := []testCase{
+		{"player", nil, false, nil},
+		{"no player", mpris.ErrNoPlayer, true, nil},
+		{"no volume", mpris.ErrNoVolume, true, nil},
+		{"ignored write", errors.Join(mpris.ErrNoVolume, errOther), true, nil},
+		{"other error", errOther, false, errOther},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			player := &fakeVolume{err: tc.playerErr}
+			system := &fakeVolume{}
+			fv := fallbackVolume{player, system}
+
+			err := fv.VolUp(5)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected error %v, got %v", tc.expectedErr, err)
+			}
+			err = fv.VolDown(10)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected error %v, got %v", tc.expectedErr, err)
+			}
+
+			if len(player.changes) != 2 {
+				t.Errorf("Expected 2 player volume changes, got %v", player.changes)
+			}
+
+			var expected []int
+			if tc.expectFall {
+				expected = []int{5, -10}
+			}
+			if !slices.Equal(system.changes, expected) {
+				t.Errorf("Expected system volume changes %v, got %v", expected, system.changes)
+			}
+		})
+	}
+}
@@ -56,6 +56,22 @@ type METData struct {
 	} `json:"next_6_hours"`
 }
 
+// METSunResponse represents a response from
+// the MET Norway sunrise API
+type METSunResponse struct {
+	Properties struct {
+		Sunrise struct {
+			Time string `json:"time"`
+		} `json:"sunrise"`
+		Sunset struct {
+			Time string `json:"time"`
+		} `json:"sunset"`
+	} `json:"properties"`
+}
+
+// metSunURL is the URL of the MET Norway sunrise API
+var metSunURL = "https://api.met.no/weatherapi/sunrise/3.0/sun"
+
 // OSMData represents lat/long data from
 // OpenStreetMap Nominatim
 type OSMData []struct {
@@ -65,6 +81,16 @@ type OSMData []struct {
 
 var sendWeatherCh = make(chan struct{}, 1)
 
+// requestWeather asks the weather updater to send new weather data
+// to the watch, without blocking if a request is already pending
+// or the weather updater isn't running.
+func requestWeather() {
+	select {
+	case sendWeatherCh <- struct{}{}:
+	default:
+	}
+}
+
 func sleepCtx(ctx context.Context, d time.Duration) {
 	select {
 	case <-time.After(d):
@@ -106,29 +132,18 @@ func initWeather(ctx context.Context, wg WaitGroup, dev *infinitime.Device) erro
 				continue
 			}
 
-			// Get current data
-			current := data.Properties.Timeseries[0]
-			currentData := current.Data.Instant.Details
-
-			icon := parseSymbol(current.Data.NextHour.Summary.SymbolCode)
-			if icon == infinitime.WeatherIconClear {
-				switch {
-				case currentData.CloudAreaFraction > 50:
-					icon = infinitime.WeatherIconHeavyClouds
-				case currentData.CloudAreaFraction == 50:
-					icon = infinitime.WeatherIconClouds
-				case currentData.CloudAreaFraction > 0:
-					icon = infinitime.WeatherIconFewClouds
-				}
+			now := time.Now()
+			weather := currentWeather(data, now)
+
+			// The sunrise and sunset times are optional, so the
+			// weather is still sent if they can't be retrieved
+			weather.Sunrise, weather.Sunset, err = getSunTimes(ctx, lat, lon, now)
+			if err != nil {
+				log.Warn("Error getting sunrise and sunset times", slog.Any("error", err))
 			}
 
-			err = dev.SetCurrentWeather(infinitime.CurrentWeather{
-				Time:        time.Now(),
-				CurrentTemp: currentData.Temperature,
-				MaxTemp:     current.Data.Next6Hours.Details.MaxTemp,
-				MinTemp:     current.Data.Next6Hours.Details.MinTemp,
-				Location:    cfg.Weather.Location,
-				Icon:        icon,
+			err = sched.run(priorityNormal, "weather", func() error {
+				return dev.SetCurrentWeather(weather)
 			})
 			if err != nil {
 				log.Error("Error setting weather", slog.Any("error", err))
@@ -150,6 +165,103 @@ func initWeather(ctx context.Context, wg WaitGroup, dev *infinitime.Device) erro
 	return nil
 }
 
+// currentWeather converts the first entry of a MET Norway response
+// to the current weather at the given time
+func currentWeather(data *METResponse, now time.Time) infinitime.CurrentWeather {
+	current := data.Properties.Timeseries[0]
+	currentData := current.Data.Instant.Details
+
+	icon := parseSymbol(current.Data.NextHour.Summary.SymbolCode)
+	if icon == infinitime.WeatherIconClear {
+		switch {
+		case currentData.CloudAreaFraction > 50:
+			icon = infinitime.WeatherIconHeavyClouds
+		case currentData.CloudAreaFraction == 50:
+			icon = infinitime.WeatherIconClouds
+		case currentData.CloudAreaFraction > 0:
+			icon = infinitime.WeatherIconFewClouds
+		}
+	}
+
+	return infinitime.CurrentWeather{
+		Time:        now,
+		CurrentTemp: currentData.Temperature,
+		MaxTemp:     current.Data.Next6Hours.Details.MaxTemp,
+		MinTemp:     current.Data.Next6Hours.Details.MinTemp,
+		Location:    cfg.Weather.Location,
+		Icon:        icon,
+	}
+}
+
+// getSunTimes gets the times of the sunrise and sunset on the given day,
+// in the day's location, since the watch receives them as local times.
+// Either time is zero if the sun doesn't rise or set on that day.
+func getSunTimes(ctx context.Context, lat, lon float64, day time.Time) (sunrise, sunset time.Time, err error) {
+	_, offset := day.Zone()
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+
+	query := url.Values{}
+	query.Set("lat", fmt.Sprintf("%.2f", lat))
+	query.Set("lon", fmt.Sprintf("%.2f", lon))
+	query.Set("date", day.Format(time.DateOnly))
+	query.Set("offset", fmt.Sprintf("%c%02d:%02d", sign, offset/3600, offset%3600/60))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metSunURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	// Set identifying user agent as per NMI requirements
+	req.Header.Set("User-Agent", fmt.Sprintf("ITD/%s gitea.elara.ws/Elara6331/itd", strings.TrimSpace(version)))
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return time.Time{}, time.Time{}, fmt.Errorf("unexpected status from sunrise API: %s", res.Status)
+	}
+
+	var out METSunResponse
+	err = json.NewDecoder(res.Body).Decode(&out)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	sunrise, err = parseSunTime(out.Properties.Sunrise.Time, day.Location())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	sunset, err = parseSunTime(out.Properties.Sunset.Time, day.Location())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return sunrise, sunset, nil
+}
+
+// parseSunTime parses a time from the sunrise API, which is empty if
+// the sun doesn't rise or set, and converts it to the given location
+func parseSunTime(s string, loc *time.Location) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	// The API leaves out the seconds
+	t, err := time.Parse("2006-01-02T15:04Z07:00", s)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, s)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
+
 // getLocation returns the latitude and longitude
 // given a location
 func getLocation(ctx context.Context, loc string) (lat, lon float64, err error) {
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.elara.ws/itd/infinitime"
+	"go.elara.ws/itd/infinitime/wire"
+)
+
+func TestWeatherSunTimes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if date, offset := r.URL.Query().Get("date"), r.URL.Query().Get("offset"); date != "2024-03-01" || offset != "+01:00" {
+			t.Errorf("Expected date 2024-03-01 and offset +01:00, got %s and %s", date, offset)
+		}
+		w.Write([]byte(`{"properties": {
+			"sunrise": {"time": "2024-03-01T07:05+01:00"},
+			"sunset": {"time": "2024-03-01T18:45+01:00"}
+		}}`))
+	}))
+	defer srv.Close()
+
+	oldURL := metSunURL
+	metSunURL = srv.URL
+	defer func() { metSunURL = oldURL }()
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	sunrise, sunset, err := getSunTimes(context.Background(), 59.91, 10.75, now)
+	if err != nil {
+		t.Fatalf("Error getting sun times: %s", err)
+	}
+
+	data := &METResponse{}
+	data.Properties.Timeseries = make([]struct {
+		Time time.Time
+		Data METData
+	}, 1)
+	weather := currentWeather(data, now)
+	weather.Sunrise, weather.Sunset = sunrise, sunset
+
+	b, err := weather.Encode(infinitime.WeatherVersion1)
+	if err != nil {
+		t.Fatalf("Error encoding weather: %s", err)
+	}
+
+	// The times are sent as minutes since local midnight
+	decoded, _, err := wire.DecodeCurrentWeather(b)
+	if err != nil {
+		t.Fatalf("Error decoding weather: %s", err)
+	}
+	if h, m, _ := decoded.Sunrise.Clock(); h != 7 || m != 5 {
+		t.Errorf("Expected sunrise at 07:05, got %s", decoded.Sunrise)
+	}
+	if h, m, _ := decoded.Sunset.Clock(); h != 18 || m != 45 {
+		t.Errorf("Expected sunset at 18:45, got %s", decoded.Sunset)
+	}
+}